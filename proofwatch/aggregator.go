@@ -0,0 +1,98 @@
+package proofwatch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// aggregatorSlotsPerWindow sets the resolution at which samples expire from an
+// Aggregator window.
+const aggregatorSlotsPerWindow = 12
+
+// Aggregator maintains the pass rate of each policy over a sliding window. Only pass
+// and fail results count towards the rate; other statuses are ignored. Samples expire
+// in steps of a twelfth of the window, so the rate covers between eleven twelfths of
+// the window and the whole window. It is safe for concurrent use.
+type Aggregator struct {
+	mu       sync.Mutex
+	window   time.Duration
+	width    time.Duration
+	policies map[string]*metrics.RatioWindow
+	now      func() time.Time
+}
+
+// NewAggregator creates an Aggregator over window. A window below 12ms is raised to it.
+func NewAggregator(window time.Duration) *Aggregator {
+	width := max(window/aggregatorSlotsPerWindow, time.Millisecond)
+	return &Aggregator{
+		window:   width * aggregatorSlotsPerWindow,
+		width:    width,
+		policies: make(map[string]*metrics.RatioWindow),
+		now:      time.Now,
+	}
+}
+
+// Observe records an evaluation of the policy with the given status.
+func (a *Aggregator) Observe(policyID string, status EvaluationStatus) {
+	if policyID == "" || (status != EvaluationStatusPass && status != EvaluationStatusFail) {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	window, ok := a.policies[policyID]
+	if !ok {
+		window = metrics.NewRatioWindow(a.width, aggregatorSlotsPerWindow)
+		a.policies[policyID] = window
+	}
+	window.Observe(a.now(), status == EvaluationStatusPass)
+}
+
+// PassRate returns the percentage of evaluations of the policy that passed within the
+// window, or 0 when there were none.
+func (a *Aggregator) PassRate(policyID string) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	rate, _ := a.rate(a.policies[policyID], a.now())
+	return rate
+}
+
+// PassRates returns the pass rate of every policy evaluated within the window,
+// forgetting policies that were not.
+func (a *Aggregator) PassRates() map[string]float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	rates := make(map[string]float64, len(a.policies))
+	for policyID, window := range a.policies {
+		rate, ok := a.rate(window, now)
+		if !ok {
+			delete(a.policies, policyID)
+			continue
+		}
+		rates[policyID] = rate
+	}
+	return rates
+}
+
+// rate returns the pass rate over the window ending at now, and whether any
+// evaluation falls within it. Callers hold a.mu.
+func (a *Aggregator) rate(window *metrics.RatioWindow, now time.Time) (float64, bool) {
+	if window == nil {
+		return 0, false
+	}
+	pass, total := window.Counts(now, a.window)
+	if total == 0 {
+		return 0, false
+	}
+	return 100 * float64(pass) / float64(total), true
+}
+
+// aggregate feeds result to the configured aggregator, if any.
+func (i *Instrumentation) aggregate(result EvaluationResult) {
+	if i.aggregator != nil {
+		i.aggregator.Observe(result.PolicyID, result.Status)
+	}
+}
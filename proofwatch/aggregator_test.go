@@ -0,0 +1,131 @@
+package proofwatch
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// newTestAggregator returns an Aggregator over a one minute window whose clock is
+// advanced with the returned function.
+func newTestAggregator() (*Aggregator, func(time.Duration)) {
+	a := NewAggregator(time.Minute)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+	return a, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestAggregatorPassRate(t *testing.T) {
+	a, advance := newTestAggregator()
+
+	assert.Zero(t, a.PassRate("policy-a"))
+
+	// Three passes and a fail at the start of the window.
+	for _, status := range []EvaluationStatus{EvaluationStatusPass, EvaluationStatusPass, EvaluationStatusFail, EvaluationStatusPass} {
+		a.Observe("policy-a", status)
+	}
+	// Statuses other than pass and fail do not count.
+	a.Observe("policy-a", EvaluationStatusError)
+	a.Observe("policy-a", EvaluationStatusSkipped)
+	a.Observe("policy-b", EvaluationStatusFail)
+	assert.Equal(t, 75.0, a.PassRate("policy-a"))
+	assert.Zero(t, a.PassRate("policy-b"))
+
+	// Half a window later, two more fails.
+	advance(30 * time.Second)
+	a.Observe("policy-a", EvaluationStatusFail)
+	a.Observe("policy-a", EvaluationStatusFail)
+	assert.Equal(t, 50.0, a.PassRate("policy-a"))
+	assert.Equal(t, map[string]float64{"policy-a": 50, "policy-b": 0}, a.PassRates())
+
+	// Once the window slides past the first samples only the later fails remain.
+	advance(30 * time.Second)
+	assert.Zero(t, a.PassRate("policy-a"))
+	a.Observe("policy-a", EvaluationStatusPass)
+	assert.InDelta(t, 100.0/3, a.PassRate("policy-a"), 1e-9)
+
+	// Policies without samples in the window are forgotten.
+	assert.Equal(t, map[string]float64{"policy-a": 100.0 / 3}, a.PassRates())
+	advance(time.Minute)
+	assert.Empty(t, a.PassRates())
+	assert.Zero(t, a.PassRate("policy-a"))
+}
+
+func TestAggregatorExpiresSamplesAsTheWindowSlides(t *testing.T) {
+	a, advance := newTestAggregator()
+
+	a.Observe("policy-a", EvaluationStatusPass)
+	advance(55 * time.Second)
+	a.Observe("policy-a", EvaluationStatusFail)
+	assert.Equal(t, 50.0, a.PassRate("policy-a"))
+
+	// The pass expires once it is a whole window old, while the fail remains.
+	advance(5 * time.Second)
+	assert.Zero(t, a.PassRate("policy-a"))
+	assert.Equal(t, map[string]float64{"policy-a": 0}, a.PassRates())
+}
+
+func TestAggregatorConcurrentObserve(t *testing.T) {
+	a := NewAggregator(time.Hour)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := EvaluationStatusPass
+			if i%2 == 1 {
+				status = EvaluationStatusFail
+			}
+			for range 100 {
+				a.Observe("policy-a", status)
+				_ = a.PassRate("policy-a")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50.0, a.PassRate("policy-a"))
+}
+
+func TestInstrumentationAggregator(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = meterProvider.Shutdown(context.Background()) })
+
+	aggregator := NewAggregator(time.Hour)
+	inst, err := NewInstrumentation(WithMeterProvider(meterProvider), WithAggregator(aggregator))
+	require.NoError(t, err)
+
+	for _, status := range []EvaluationStatus{EvaluationStatusPass, EvaluationStatusFail, EvaluationStatusPass, EvaluationStatusPass} {
+		_, err := inst.Evaluate(context.Background(), createTestEvidence(), evaluatorFunc(func(context.Context, Evidence) (EvaluationResult, error) {
+			return EvaluationResult{PolicyID: "policy-a", Status: status}, nil
+		}))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 75.0, aggregator.PassRate("policy-a"))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var found bool
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "evidence_pass_rate" {
+				continue
+			}
+			dps := m.Data.(metricdata.Gauge[float64]).DataPoints
+			require.Len(t, dps, 1)
+			policyID, _ := dps[0].Attributes.Value("policy.id")
+			assert.Equal(t, "policy-a", policyID.AsString())
+			assert.Equal(t, 75.0, dps[0].Value)
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,118 @@
+package proofwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+var _ Evidence = (*CloudEventEvidence)(nil)
+
+// CloudEvents attributes recorded on evidence received in a CloudEvents envelope.
+const (
+	CLOUDEVENTS_EVENT_ID   = "cloudevents.event_id"
+	CLOUDEVENTS_EVENT_TYPE = "cloudevents.event_type"
+)
+
+// CloudEventEvidence represents evidence received in a CloudEvents envelope. The
+// envelope's source identifies the policy source and its data carries the evidence
+// payload as JSON.
+type CloudEventEvidence struct {
+	ID     string          `json:"id"`
+	Source string          `json:"source"`
+	Type   string          `json:"type"`
+	Time   time.Time       `json:"time,omitzero"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// CloudEventError reports a CloudEvent that cannot be converted into evidence, either
+// because the envelope is malformed or because its data is not JSON. Callers should
+// record the event as dropped with reason validation_failed.
+type CloudEventError struct {
+	// ID is the event ID, if the envelope carried one.
+	ID string
+	// Reason describes what is wrong with the event.
+	Reason string
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *CloudEventError) Error() string {
+	msg := "invalid cloud event"
+	if e.ID != "" {
+		msg += fmt.Sprintf(" %q", e.ID)
+	}
+	msg += ": " + e.Reason
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *CloudEventError) Unwrap() error {
+	return e.Err
+}
+
+// FromCloudEvent converts a CloudEvent, received in either binary or structured mode,
+// into evidence. The event must be valid, carry data and declare a JSON content type,
+// or none at all; otherwise a *CloudEventError is returned.
+func FromCloudEvent(ev cloudevents.Event) (Evidence, error) {
+	if err := ev.Validate(); err != nil {
+		return nil, &CloudEventError{ID: ev.ID(), Reason: "malformed envelope", Err: err}
+	}
+	if mediaType := ev.DataMediaType(); !isJSONMediaType(mediaType) {
+		if mediaType == "" {
+			mediaType = ev.DataContentType()
+		}
+		return nil, &CloudEventError{ID: ev.ID(), Reason: fmt.Sprintf("unsupported content type %q", mediaType)}
+	}
+	data := ev.Data()
+	if len(data) == 0 {
+		return nil, &CloudEventError{ID: ev.ID(), Reason: "missing data"}
+	}
+	if !json.Valid(data) {
+		return nil, &CloudEventError{ID: ev.ID(), Reason: "data is not valid JSON"}
+	}
+
+	return CloudEventEvidence{
+		ID:     ev.ID(),
+		Source: ev.Source(),
+		Type:   ev.Type(),
+		Time:   ev.Time(),
+		Data:   json.RawMessage(data),
+	}, nil
+}
+
+// isJSONMediaType reports whether mediaType is JSON, a structured syntax suffixed JSON
+// type such as application/cloudevents+json, or absent, which CloudEvents treats as JSON.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "" ||
+		mediaType == cloudevents.ApplicationJSON ||
+		mediaType == "text/json" ||
+		strings.HasSuffix(mediaType, "+json")
+}
+
+func (c CloudEventEvidence) ToJSON() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c CloudEventEvidence) Attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		metrics.SourceKey.String(c.Source),
+		attribute.String(CLOUDEVENTS_EVENT_ID, c.ID),
+		attribute.String(CLOUDEVENTS_EVENT_TYPE, c.Type),
+	}
+}
+
+func (c CloudEventEvidence) Timestamp() time.Time {
+	if c.Time.IsZero() {
+		return time.Now()
+	}
+	return c.Time
+}
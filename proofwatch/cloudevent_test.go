@@ -0,0 +1,110 @@
+package proofwatch
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const testEvidenceData = `{"policy":{"uid":"policy-123"},"status":"pass"}`
+
+func decodeRequest(t *testing.T, req *http.Request) cloudevents.Event {
+	t.Helper()
+	ev, err := cehttp.NewEventFromHTTPRequest(req)
+	require.NoError(t, err)
+	return *ev
+}
+
+func TestFromCloudEvent(t *testing.T) {
+	eventTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("binary mode", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testEvidenceData))
+		req.Header.Set("ce-specversion", "1.0")
+		req.Header.Set("ce-id", "event-1")
+		req.Header.Set("ce-source", "https://scanner.example.com")
+		req.Header.Set("ce-type", "com.example.evidence")
+		req.Header.Set("ce-time", eventTime.Format(time.RFC3339))
+		req.Header.Set("Content-Type", "application/json")
+
+		evidence, err := FromCloudEvent(decodeRequest(t, req))
+		require.NoError(t, err)
+
+		assert.Equal(t, eventTime, evidence.Timestamp())
+		assert.Contains(t, evidence.Attributes(), attribute.String("policy.source", "https://scanner.example.com"))
+		assert.Contains(t, evidence.Attributes(), attribute.String(CLOUDEVENTS_EVENT_ID, "event-1"))
+		assert.Contains(t, evidence.Attributes(), attribute.String(CLOUDEVENTS_EVENT_TYPE, "com.example.evidence"))
+
+		data, err := evidence.ToJSON()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":"event-1","source":"https://scanner.example.com","type":"com.example.evidence","time":"2025-01-02T03:04:05Z","data":`+testEvidenceData+`}`, string(data))
+	})
+
+	t.Run("structured mode", func(t *testing.T) {
+		body := `{
+			"specversion": "1.0",
+			"id": "event-2",
+			"source": "/scanners/opa",
+			"type": "com.example.evidence",
+			"datacontenttype": "application/json",
+			"data": ` + testEvidenceData + `
+		}`
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/cloudevents+json")
+
+		evidence, err := FromCloudEvent(decodeRequest(t, req))
+		require.NoError(t, err)
+
+		ce, ok := evidence.(CloudEventEvidence)
+		require.True(t, ok)
+		assert.Equal(t, "event-2", ce.ID)
+		assert.Equal(t, "/scanners/opa", ce.Source)
+		assert.JSONEq(t, testEvidenceData, string(ce.Data))
+		assert.Contains(t, evidence.Attributes(), attribute.String("policy.source", "/scanners/opa"))
+		assert.False(t, evidence.Timestamp().IsZero())
+	})
+
+	t.Run("rejects invalid events", func(t *testing.T) {
+		newEvent := func(contentType string, data []byte) cloudevents.Event {
+			ev := cloudevents.NewEvent()
+			ev.SetID("event-3")
+			ev.SetSource("/scanners/opa")
+			ev.SetType("com.example.evidence")
+			ev.SetDataContentType(contentType)
+			ev.DataEncoded = data
+			return ev
+		}
+		missingID := newEvent(cloudevents.ApplicationJSON, []byte(testEvidenceData))
+		missingID.SetID("")
+
+		tests := []struct {
+			name   string
+			event  cloudevents.Event
+			reason string
+		}{
+			{name: "missing id", event: missingID, reason: "malformed envelope"},
+			{name: "unsupported content type", event: newEvent("application/xml", []byte("<evidence/>")), reason: `unsupported content type "application/xml"`},
+			{name: "missing data", event: newEvent(cloudevents.ApplicationJSON, nil), reason: "missing data"},
+			{name: "invalid JSON", event: newEvent(cloudevents.ApplicationJSON, []byte("{")), reason: "data is not valid JSON"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				evidence, err := FromCloudEvent(tt.event)
+				assert.Nil(t, evidence)
+
+				var ceErr *CloudEventError
+				require.True(t, errors.As(err, &ceErr))
+				assert.Equal(t, tt.reason, ceErr.Reason)
+			})
+		}
+	})
+}
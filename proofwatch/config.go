@@ -1,15 +1,39 @@
 package proofwatch
 
 import (
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+	"github.com/complytime/complybeacon/proofwatch/provenance"
 )
 
 type config struct {
 	LoggerProvider log.LoggerProvider
 	MeterProvider  metric.MeterProvider
 	TracerProvider trace.TracerProvider
+	DeadLetterSink DeadLetterSink
+	Validator      *Validator
+	ProvenanceKeys provenance.KeySet
+	RateLimiter    RateLimiter
+	Store          Store
+	Aggregator     *Aggregator
+	Redactor       *Redactor
+	SlogLogger     *slog.Logger
+	MaxAge         time.Duration
+	ClockSkew      time.Duration
+	RecordingSpans bool
+	// MetricKeys are recorded as metric labels in addition to the default ones.
+	MetricKeys []attribute.Key
+	// ObserverOptions configure the evidence observer, in the order given.
+	ObserverOptions []metrics.Option
+	// ObserverGauges register gauges on the evidence observer once it is created.
+	ObserverGauges []func(*metrics.EvidenceObserver) error
 }
 
 type OptionFunc func(*config)
@@ -43,3 +67,131 @@ func WithTracerProvider(provider trace.TracerProvider) OptionFunc {
 		}
 	})
 }
+
+// WithDeadLetterSink specifies a sink that receives evidence dropped by Instrumentation,
+// together with the drop reason and error. If none is specified, dropped evidence is
+// only counted.
+func WithDeadLetterSink(sink DeadLetterSink) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if sink != nil {
+			cfg.DeadLetterSink = sink
+		}
+	})
+}
+
+// WithValidator specifies a validator evidence is checked against before it is
+// processed. Evidence failing validation is dropped with reason validation_failed.
+// If none is specified, evidence is not validated.
+func WithValidator(validator *Validator) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if validator != nil {
+			cfg.Validator = validator
+		}
+	})
+}
+
+// WithProvenanceKeys specifies the public keys trusted to sign attestations carried by
+// AttestedEvidence. Evidence whose attestation does not verify, or whose subject digests
+// do not match the evidence, is dropped with reason provenance_invalid. If none are specified, attestations are not verified.
+func WithProvenanceKeys(keys provenance.KeySet) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if len(keys) > 0 {
+			cfg.ProvenanceKeys = keys
+		}
+	})
+}
+
+// WithRateLimiter specifies a rate limiter evidence must pass before it is processed.
+// Evidence it rejects is dropped with reason rate_limited, and the tokens it has
+// available are reported by the evidence_rate_limit_tokens gauge.
+// If none is specified, evidence is not rate limited.
+func WithRateLimiter(limiter RateLimiter) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if limiter != nil {
+			cfg.RateLimiter = limiter
+		}
+	})
+}
+
+// WithStore specifies a store every processed and dropped evidence item is written to.
+// Dropped evidence is stored with status error. Failures to write to the store are
+// reported through the OpenTelemetry error handler.
+// If none is specified, evidence is not stored.
+func WithStore(store Store) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if store != nil {
+			cfg.Store = store
+		}
+	})
+}
+
+// WithAggregator specifies an aggregator fed with the policy ID and status of processed
+// evidence, whose pass rates are reported by the evidence_pass_rate gauge.
+// If none is specified, pass rates are not aggregated.
+func WithAggregator(aggregator *Aggregator) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if aggregator != nil {
+			cfg.Aggregator = aggregator
+		}
+	})
+}
+
+// WithRedactor specifies a redactor masking evidence before it is logged, stored or
+// written to the dead letter sink. Span attributes, and the errors of dropped evidence
+// that are returned, logged or recorded, are masked too. Evaluation and processing see
+// the original evidence. If none is specified, evidence is not redacted.
+func WithRedactor(redactor *Redactor) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if redactor != nil {
+			cfg.Redactor = redactor
+		}
+	})
+}
+
+// WithSlogLogger specifies a logger Instrumentation writes a structured entry to for
+// each evidence item: at warn or error level, with the reason, policy ID and error, when
+// it is dropped, and at debug level when it is processed.
+// If none is specified, nothing is logged.
+func WithSlogLogger(logger *slog.Logger) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if logger != nil {
+			cfg.SlogLogger = logger
+		}
+	})
+}
+
+// WithSampling specifies the fraction of processed evidence, in (0, 1], recorded in
+// evidence_processed_count. Each recorded item counts for 1/rate items, so the counter
+// still reports an estimate of the total processed. Dropped evidence is always recorded.
+// If none is specified, or rate is not positive, all processed evidence is recorded.
+// The last of WithSampling and WithSampler given decides how evidence is sampled.
+func WithSampling(rate float64) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if rate > 0 {
+			cfg.ObserverOptions = append(cfg.ObserverOptions, metrics.WithSampling(rate))
+		}
+	})
+}
+
+// WithMaxAge specifies how long after its event time evidence may still be processed.
+// Older evidence is dropped with reason expired and the bucket of its age, without
+// being processed. Evidence without a timestamp is never expired.
+// If none is specified, or maxAge is not positive, evidence does not expire.
+func WithMaxAge(maxAge time.Duration) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if maxAge > 0 {
+			cfg.MaxAge = maxAge
+		}
+	})
+}
+
+// WithClockSkew specifies how far the clock of evidence producers may run behind,
+// extending the maximum age set with WithMaxAge so evidence is not expired early.
+// If none is specified, or tolerance is not positive, no skew is tolerated.
+func WithClockSkew(tolerance time.Duration) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if tolerance > 0 {
+			cfg.ClockSkew = tolerance
+		}
+	})
+}
@@ -2,6 +2,7 @@ package proofwatch
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,3 +134,31 @@ func TestOptionFunc_ModifyAllFields(t *testing.T) {
 	assert.Equal(t, logger, cfg.LoggerProvider)
 	assert.Equal(t, tracer, cfg.TracerProvider)
 }
+
+func TestWithSampling(t *testing.T) {
+	t.Run("configures the observer on positive rate", func(t *testing.T) {
+		cfg := &config{}
+		WithSampling(0.1)(cfg)
+		assert.Len(t, cfg.ObserverOptions, 1)
+	})
+
+	t.Run("no-op on non-positive rate", func(t *testing.T) {
+		cfg := &config{}
+		WithSampling(0)(cfg)
+		WithSampling(-1)(cfg)
+		assert.Empty(t, cfg.ObserverOptions)
+	})
+}
+
+func TestWithMaxAge(t *testing.T) {
+	cfg := &config{}
+	WithMaxAge(time.Hour)(cfg)
+	WithClockSkew(time.Minute)(cfg)
+	assert.Equal(t, time.Hour, cfg.MaxAge)
+	assert.Equal(t, time.Minute, cfg.ClockSkew)
+
+	WithMaxAge(0)(cfg)
+	WithClockSkew(-time.Minute)(cfg)
+	assert.Equal(t, time.Hour, cfg.MaxAge)
+	assert.Equal(t, time.Minute, cfg.ClockSkew)
+}
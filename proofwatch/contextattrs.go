@@ -0,0 +1,17 @@
+package proofwatch
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// ContextWithAttributes returns a copy of ctx carrying attrs, such as the tenant or
+// request, which are added to every processed and dropped evidence metric recorded with
+// the returned context. Attributes given for the evidence itself win on key conflicts.
+// Like other metric attributes, attrs should come from a bounded set of values.
+func ContextWithAttributes(ctx context.Context, attrs ...attribute.KeyValue) context.Context {
+	return metrics.ContextWithAttributes(ctx, attrs...)
+}
@@ -0,0 +1,37 @@
+package proofwatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestContextWithAttributes(t *testing.T) {
+	inst, _, reader := setupInstrumentationTest(t)
+	ctx := ContextWithAttributes(context.Background(), attribute.String("tenant", "acme"), attribute.String(POLICY_RULE_ID, "scoped"))
+
+	require.NoError(t, inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return nil }))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var found bool
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "evidence_processed_count" {
+				continue
+			}
+			dps := m.Data.(metricdata.Sum[int64]).DataPoints
+			require.Len(t, dps, 1)
+			tenant, _ := dps[0].Attributes.Value("tenant")
+			ruleID, _ := dps[0].Attributes.Value(POLICY_RULE_ID)
+			assert.Equal(t, "acme", tenant.AsString())
+			assert.Equal(t, "test-policy", ruleID.AsString())
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,112 @@
+package proofwatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DeadLetter is an evidence item that was dropped, along with why.
+type DeadLetter struct {
+	Evidence  Evidence
+	Reason    DropReason
+	Err       error
+	DroppedAt time.Time
+}
+
+// DeadLetterSink persists dropped evidence so it can be investigated later.
+// Implementations must be safe for concurrent use.
+type DeadLetterSink interface {
+	Write(ctx context.Context, letter DeadLetter) error
+}
+
+var (
+	_ DeadLetterSink = (*MemoryDeadLetterSink)(nil)
+	_ DeadLetterSink = (*FileDeadLetterSink)(nil)
+)
+
+// MemoryDeadLetterSink keeps dead letters in memory.
+type MemoryDeadLetterSink struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+// NewMemoryDeadLetterSink creates an empty in-memory sink.
+func NewMemoryDeadLetterSink() *MemoryDeadLetterSink {
+	return &MemoryDeadLetterSink{}
+}
+
+func (m *MemoryDeadLetterSink) Write(_ context.Context, letter DeadLetter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.letters = append(m.letters, letter)
+	return nil
+}
+
+// Letters returns the dead letters written so far, oldest first.
+func (m *MemoryDeadLetterSink) Letters() []DeadLetter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]DeadLetter(nil), m.letters...)
+}
+
+// fileDeadLetter is the JSON line written by FileDeadLetterSink.
+type fileDeadLetter struct {
+	DroppedAt time.Time       `json:"dropped_at"`
+	Reason    DropReason      `json:"reason"`
+	Error     string          `json:"error,omitempty"`
+	Evidence  json.RawMessage `json:"evidence"`
+}
+
+// FileDeadLetterSink appends dead letters to a file as JSON lines, each holding the
+// drop time, reason, error message and evidence JSON.
+type FileDeadLetterSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileDeadLetterSink opens path for appending, creating it if needed.
+// Call Close to release the file.
+func NewFileDeadLetterSink(path string) (*FileDeadLetterSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dead letter file: %w", err)
+	}
+	return &FileDeadLetterSink{file: file}, nil
+}
+
+func (f *FileDeadLetterSink) Write(_ context.Context, letter DeadLetter) error {
+	evidence, err := letter.Evidence.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter evidence: %w", err)
+	}
+	line := fileDeadLetter{
+		DroppedAt: letter.DroppedAt,
+		Reason:    letter.Reason,
+		Evidence:  evidence,
+	}
+	if letter.Err != nil {
+		line.Error = letter.Err.Error()
+	}
+	data, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying file.
+func (f *FileDeadLetterSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
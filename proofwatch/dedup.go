@@ -0,0 +1,116 @@
+package proofwatch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// ErrDuplicate is returned by a ProcessFunc wrapped with Instrumentation.Dedup for
+// evidence that was already processed.
+var ErrDuplicate = errors.New("duplicate evidence")
+
+// defaultDedupCacheSize is the number of evidence keys remembered unless
+// WithDedupCacheSize is set.
+const defaultDedupCacheSize = 10000
+
+type dedupConfig struct {
+	cacheSize int
+	key       func(Evidence) (string, error)
+	strategy  metrics.DedupStrategy
+}
+
+// DedupOption configures Instrumentation.Dedup.
+type DedupOption func(*dedupConfig)
+
+// WithDedupCacheSize sets the number of evidence keys remembered. Once full, the least
+// recently seen key is forgotten. Non-positive values keep the default of 10000.
+func WithDedupCacheSize(n int) DedupOption {
+	return func(cfg *dedupConfig) {
+		if n > 0 {
+			cfg.cacheSize = n
+		}
+	}
+}
+
+// WithEvidenceID identifies duplicates by the ID id returns for the evidence rather
+// than by ContentHash. Evidence for which id returns "" is never deduplicated.
+func WithEvidenceID(id func(Evidence) string) DedupOption {
+	return func(cfg *dedupConfig) {
+		if id != nil {
+			cfg.key = func(e Evidence) (string, error) { return id(e), nil }
+			cfg.strategy = metrics.DedupStrategyID
+		}
+	}
+}
+
+// ContentHash returns the SHA-256 of the evidence JSON in canonical form, with object
+// keys sorted and insignificant whitespace removed, so equivalent encodings hash alike.
+// Numbers keep their literal form, so large integers that do not fit a float64 still
+// hash apart.
+func ContentHash(e Evidence) (string, error) {
+	data, err := e.ToJSON()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal evidence: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("failed to decode evidence: %w", err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return "", errors.New("failed to decode evidence: unexpected data after the top-level value")
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize evidence: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Dedup wraps fn so evidence already processed successfully is rejected with
+// ErrDuplicate, which Process records as dropped with reason duplicate. Evidence is
+// keyed by ContentHash unless WithEvidenceID is set, and keys are held in a bounded LRU
+// cache whose occupancy is recorded in evidence_dedup_cache_entries. Keys are only
+// remembered once fn succeeds, so evidence that fn fails on is processed again when
+// redelivered. Deliveries of the same evidence processed concurrently may therefore
+// both reach fn.
+func (i *Instrumentation) Dedup(fn ProcessFunc, opts ...DedupOption) ProcessFunc {
+	cfg := dedupConfig{
+		cacheSize: defaultDedupCacheSize,
+		key:       ContentHash,
+		strategy:  metrics.DedupStrategyContentHash,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cache := metrics.NewLRU[struct{}](cfg.cacheSize)
+
+	return func(ctx context.Context, evidence Evidence) error {
+		key, err := cfg.key(evidence)
+		if err != nil {
+			return err
+		}
+		if key == "" {
+			return fn(ctx, evidence)
+		}
+		if _, ok := cache.Get(key); ok {
+			i.observer.DedupHit(ctx, cfg.strategy)
+			return ErrDuplicate
+		}
+		if err := fn(ctx, evidence); err != nil {
+			return err
+		}
+		i.observer.RecordDedupCacheEntries(ctx, cfg.strategy, cache.Put(key, struct{}{}))
+		return nil
+	}
+}
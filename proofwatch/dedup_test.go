@@ -0,0 +1,138 @@
+package proofwatch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// jsonEvidence is evidence whose JSON is the raw document it holds.
+type jsonEvidence string
+
+func (e jsonEvidence) ToJSON() ([]byte, error)          { return []byte(e), nil }
+func (e jsonEvidence) Attributes() []attribute.KeyValue { return nil }
+func (e jsonEvidence) Timestamp() time.Time             { return time.Time{} }
+
+func TestInstrumentationDedup(t *testing.T) {
+	noop := func(context.Context, Evidence) error { return nil }
+
+	t.Run("drops the same evidence submitted twice", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		ctx := context.Background()
+		evidence := createTestEvidence()
+		fn := inst.Dedup(noop)
+
+		require.NoError(t, inst.Process(ctx, evidence, fn))
+		require.ErrorIs(t, inst.Process(ctx, evidence, fn), ErrDuplicate)
+
+		assert.Equal(t, int64(1), int64Sums(t, reader, "evidence_processed_count")[""])
+		assert.Equal(t, map[string]int64{"duplicate": 1}, int64Sums(t, reader, "evidence_dropped_count"))
+		assert.True(t, metricNames(t, reader)["evidence_dedup_hit_count"])
+	})
+
+	t.Run("matches equivalent encodings", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		ctx := context.Background()
+		fn := inst.Dedup(noop)
+
+		require.NoError(t, inst.Process(ctx, jsonEvidence(`{"id": "e1", "status": "pass"}`), fn))
+		require.ErrorIs(t, inst.Process(ctx, jsonEvidence(`{"status":"pass","id":"e1"}`), fn), ErrDuplicate)
+		require.NoError(t, inst.Process(ctx, jsonEvidence(`{"status":"fail","id":"e1"}`), fn))
+
+		assert.Equal(t, int64(2), int64Sums(t, reader, "evidence_processed_count")[""])
+	})
+
+	t.Run("keys by evidence ID", func(t *testing.T) {
+		inst, _, _ := setupInstrumentationTest(t)
+		ctx := context.Background()
+		byID := WithEvidenceID(func(e Evidence) string {
+			var doc struct{ ID string }
+			data, _ := e.ToJSON()
+			_ = json.Unmarshal(data, &doc)
+			return doc.ID
+		})
+		fn := inst.Dedup(noop, byID)
+
+		require.NoError(t, inst.Process(ctx, jsonEvidence(`{"id":"e1","status":"pass"}`), fn))
+		assert.ErrorIs(t, inst.Process(ctx, jsonEvidence(`{"id":"e1","status":"fail"}`), fn), ErrDuplicate)
+	})
+
+	t.Run("skips evidence without an ID", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		ctx := context.Background()
+		fn := inst.Dedup(noop, WithEvidenceID(func(Evidence) string { return "" }))
+
+		require.NoError(t, inst.Process(ctx, jsonEvidence(`{"status":"pass"}`), fn))
+		require.NoError(t, inst.Process(ctx, jsonEvidence(`{"status":"fail"}`), fn))
+
+		assert.Equal(t, int64(2), int64Sums(t, reader, "evidence_processed_count")[""])
+		assert.False(t, metricNames(t, reader)["evidence_dedup_cache_entries"])
+	})
+
+	t.Run("evicts the least recently seen evidence", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		ctx := context.Background()
+		fn := inst.Dedup(noop, WithDedupCacheSize(2))
+		a, b, c := jsonEvidence(`{"id":"a"}`), jsonEvidence(`{"id":"b"}`), jsonEvidence(`{"id":"c"}`)
+
+		require.NoError(t, inst.Process(ctx, a, fn))
+		require.NoError(t, inst.Process(ctx, b, fn))
+		require.ErrorIs(t, inst.Process(ctx, a, fn), ErrDuplicate)
+		require.NoError(t, inst.Process(ctx, c, fn))
+
+		assert.ErrorIs(t, inst.Process(ctx, a, fn), ErrDuplicate)
+		assert.NoError(t, inst.Process(ctx, b, fn))
+		assert.Equal(t, int64(2), int64Gauge(t, reader, "evidence_dedup_cache_entries"))
+	})
+
+	t.Run("remembers evidence only once processed", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		ctx := context.Background()
+		calls := 0
+		fn := inst.Dedup(func(context.Context, Evidence) error {
+			calls++
+			if calls == 1 {
+				return assert.AnError
+			}
+			return nil
+		})
+		evidence := createTestEvidence()
+
+		require.ErrorIs(t, inst.Process(ctx, evidence, fn), assert.AnError)
+		assert.False(t, metricNames(t, reader)["evidence_dedup_cache_entries"])
+
+		require.NoError(t, inst.Process(ctx, evidence, fn))
+		require.ErrorIs(t, inst.Process(ctx, evidence, fn), ErrDuplicate)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, int64(1), int64Gauge(t, reader, "evidence_dedup_cache_entries"))
+	})
+}
+
+func TestContentHash(t *testing.T) {
+	first, err := ContentHash(jsonEvidence(`{"b": [1, 2], "a": {"y": 1, "x": 2}}`))
+	require.NoError(t, err)
+	second, err := ContentHash(jsonEvidence(`{"a":{"x":2,"y":1},"b":[1,2]}`))
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Len(t, first, 64)
+
+	reordered, err := ContentHash(jsonEvidence(`{"a":{"x":2,"y":1},"b":[2,1]}`))
+	require.NoError(t, err)
+	assert.NotEqual(t, first, reordered)
+
+	large, err := ContentHash(jsonEvidence(`{"n": 9007199254740993}`))
+	require.NoError(t, err)
+	rounded, err := ContentHash(jsonEvidence(`{"n": 9007199254740992}`))
+	require.NoError(t, err)
+	assert.NotEqual(t, large, rounded, "integers beyond float64 precision hash apart")
+
+	_, err = ContentHash(jsonEvidence(`{`))
+	assert.Error(t, err)
+	_, err = ContentHash(jsonEvidence(`{"a":1} {"a":2}`))
+	assert.Error(t, err)
+}
@@ -26,10 +26,19 @@
 //	)
 //
 // Metrics:
-//   - evidence_processed_count: Total number of evidence items processed successfully
-//   - evidence_dropped_count: Total number of evidence items dropped due to failures
+//   - evidence_processed_count: Total number of evidence items processed successfully, estimated from a sample when set up with WithSampling
+//   - evidence_dropped_count: Total number of evidence items dropped due to failures, by reason
+//   - evidence_processing_duration_seconds: Time taken to evaluate an evidence item
+//   - evidence_in_flight: Number of evidence items currently being processed
+//   - evidence_queue_depth: Number of evidence items waiting for a Pool worker
+//   - evidence_active_workers: Number of Pool workers currently processing evidence
+//   - evidence_queue_wait_seconds: Time evidence items wait in a Pool queue
+//   - evidence_rate_limit_tokens: Tokens available to the rate limiter set with WithRateLimiter
+//   - evidence_pass_rate: Percentage of evaluations passing per policy, set up with WithAggregator
 //
 // Traces:
 //   - evidence.log_evidence: Tracks the complete evidence logging process
 //   - evidence.logged: Event marker for successful evidence logging
+//   - evidence.process: Tracks evidence processing run through Instrumentation.Process
+//   - evidence.evaluate: Tracks policy evaluation run through Instrumentation.Evaluate
 package proofwatch
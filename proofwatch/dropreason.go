@@ -0,0 +1,93 @@
+package proofwatch
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+	"github.com/complytime/complybeacon/proofwatch/provenance"
+)
+
+// DropReason describes why an evidence item was dropped.
+type DropReason = metrics.DropReason
+
+const (
+	DropReasonValidationFailed  = metrics.DropReasonValidationFailed
+	DropReasonProcessingError   = metrics.DropReasonProcessingError
+	DropReasonTimeout           = metrics.DropReasonTimeout
+	DropReasonQueueFull         = metrics.DropReasonQueueFull
+	DropReasonDuplicate         = metrics.DropReasonDuplicate
+	DropReasonProvenanceInvalid = metrics.DropReasonProvenanceInvalid
+	DropReasonRateLimited       = metrics.DropReasonRateLimited
+	DropReasonBackpressure      = metrics.DropReasonBackpressure
+	DropReasonExpired           = metrics.DropReasonExpired
+	DropReasonUnknown           = metrics.DropReasonUnknown
+)
+
+// Drop reasons recorded by the EvidenceObserver methods returned by
+// Instrumentation.Observer.
+const (
+	DropReasonPlaintextTransport   = metrics.DropReasonPlaintextTransport
+	DropReasonBundleTimeout        = metrics.DropReasonBundleTimeout
+	DropReasonCustodyBroken        = metrics.DropReasonCustodyBroken
+	DropReasonDependencyUnresolved = metrics.DropReasonDependencyUnresolved
+	DropReasonResourceCeiling      = metrics.DropReasonResourceCeiling
+	DropReasonRevokedKey           = metrics.DropReasonRevokedKey
+)
+
+// Sentinel errors classifying why evidence could not be processed. Wrap them, for
+// example with fmt.Errorf and %w, so DropReasonForError can map the error to a drop
+// reason.
+var (
+	// ErrValidation marks evidence failing validation. A *ValidationError matches it.
+	ErrValidation = errors.New("evidence validation failed")
+	// ErrProcessing marks evidence whose processing failed.
+	ErrProcessing = errors.New("evidence processing failed")
+	// ErrTimeout marks evidence whose processing timed out.
+	ErrTimeout = errors.New("evidence processing timed out")
+	// ErrProvenance marks evidence whose provenance could not be verified.
+	// provenance.ErrVerificationFailed is treated the same way.
+	ErrProvenance = errors.New("evidence provenance invalid")
+	// ErrBackpressure marks evidence turned away because the pipeline is saturated.
+	ErrBackpressure = errors.New("evidence pipeline is saturated")
+)
+
+// DropReasonForError returns the reason evidence failing with err is dropped for:
+// validation_failed for ErrValidation, provenance_invalid for ErrProvenance and
+// attestations failing verification, timeout for ErrTimeout, deadlines and network
+// timeouts, processing_error for ErrProcessing, backpressure for ErrBackpressure, expired
+// for ErrExpired, and the matching reason for ErrDuplicate, ErrRateLimited and
+// ErrQueueFull. Other errors, including nil, map to unknown.
+func DropReasonForError(err error) DropReason {
+	switch {
+	case err == nil:
+		return DropReasonUnknown
+	case errors.Is(err, ErrValidation):
+		return DropReasonValidationFailed
+	case errors.Is(err, ErrProvenance), errors.Is(err, provenance.ErrVerificationFailed):
+		return DropReasonProvenanceInvalid
+	case errors.Is(err, ErrDuplicate):
+		return DropReasonDuplicate
+	case errors.Is(err, ErrRateLimited):
+		return DropReasonRateLimited
+	case errors.Is(err, ErrQueueFull):
+		return DropReasonQueueFull
+	case errors.Is(err, ErrBackpressure):
+		return DropReasonBackpressure
+	case errors.Is(err, ErrExpired):
+		return DropReasonExpired
+	case errors.Is(err, ErrTimeout), isTimeout(err):
+		return DropReasonTimeout
+	case errors.Is(err, ErrProcessing):
+		return DropReasonProcessingError
+	default:
+		return DropReasonUnknown
+	}
+}
+
+// isTimeout reports whether err is a deadline exceeded or a network timeout.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout()
+}
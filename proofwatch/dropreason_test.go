@@ -0,0 +1,45 @@
+package proofwatch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/complytime/complybeacon/proofwatch/provenance"
+)
+
+func TestDropReasonForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want DropReason
+	}{
+		{"validation", fmt.Errorf("decode: %w", ErrValidation), DropReasonValidationFailed},
+		{"validation error", fmt.Errorf("decode: %w", &ValidationError{Fields: []FieldError{{Field: "/policy"}}}), DropReasonValidationFailed},
+		{"processing", fmt.Errorf("evaluate: %w", ErrProcessing), DropReasonProcessingError},
+		{"timeout", fmt.Errorf("evaluate: %w", ErrTimeout), DropReasonTimeout},
+		{"deadline", fmt.Errorf("evaluate: %w", context.DeadlineExceeded), DropReasonTimeout},
+		{"provenance", fmt.Errorf("verify: %w", ErrProvenance), DropReasonProvenanceInvalid},
+		{"attestation", fmt.Errorf("verify: %w", provenance.ErrVerificationFailed), DropReasonProvenanceInvalid},
+		{"duplicate", fmt.Errorf("dedup: %w", ErrDuplicate), DropReasonDuplicate},
+		{"rate limited", ErrRateLimited, DropReasonRateLimited},
+		{"queue full", ErrQueueFull, DropReasonQueueFull},
+		{"expired", &ExpiredError{Age: time.Hour}, DropReasonExpired},
+		{"backpressure", fmt.Errorf("ingest: %w", ErrBackpressure), DropReasonBackpressure},
+		{"unknown", assert.AnError, DropReasonUnknown},
+		{"nil", nil, DropReasonUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DropReasonForError(tt.err))
+		})
+	}
+}
+
+func TestValidationErrorIs(t *testing.T) {
+	assert.ErrorIs(t, &ValidationError{}, ErrValidation)
+	assert.NotErrorIs(t, &ValidationError{}, ErrProcessing)
+}
@@ -0,0 +1,23 @@
+package proofwatch
+
+import (
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// ExponentialDurationView is a view recording evidence_processing_duration_seconds as a
+// base-2 exponential histogram instead of over explicit buckets, so latency keeps its
+// resolution without tuning buckets per deployment. Pass it to the MeterProvider given
+// to WithMeterProvider with sdkmetric.WithView, for example through the opts of
+// NewMeterProvider or NewPrometheusMeterProvider.
+func ExponentialDurationView() sdkmetric.View {
+	return metrics.ExponentialDurationView()
+}
+
+// WithExponentialDurationHistogram makes NewOTLPMeterProvider export
+// evidence_processing_duration_seconds as a base-2 exponential histogram, using
+// ExponentialDurationView.
+func WithExponentialDurationHistogram() OTLPOption {
+	return metrics.WithExponentialDurationHistogram()
+}
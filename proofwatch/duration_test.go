@@ -0,0 +1,49 @@
+package proofwatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestExponentialDurationView(t *testing.T) {
+	durationData := func(t *testing.T, opts ...sdkmetric.Option) metricdata.Aggregation {
+		t.Helper()
+		ctx := context.Background()
+
+		reader := sdkmetric.NewManualReader()
+		provider, err := NewMeterProvider(ctx, ResourceConfig{}, append(opts, sdkmetric.WithReader(reader))...)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+		inst, err := NewInstrumentation(WithMeterProvider(provider))
+		require.NoError(t, err)
+		require.NoError(t, inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return nil }))
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name == "evidence_processing_duration_seconds" {
+					return m.Data
+				}
+			}
+		}
+		t.Fatal("evidence_processing_duration_seconds not collected")
+		return nil
+	}
+
+	t.Run("explicit buckets by default", func(t *testing.T) {
+		assert.IsType(t, metricdata.Histogram[float64]{}, durationData(t))
+	})
+
+	t.Run("exponential with the view", func(t *testing.T) {
+		data := durationData(t, sdkmetric.WithView(ExponentialDurationView()))
+		require.IsType(t, metricdata.ExponentialHistogram[float64]{}, data)
+		assert.Len(t, data.(metricdata.ExponentialHistogram[float64]).DataPoints, 1)
+	})
+}
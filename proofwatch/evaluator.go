@@ -0,0 +1,23 @@
+package proofwatch
+
+import (
+	"context"
+)
+
+// EvaluationResult is the outcome of evaluating evidence against a policy.
+type EvaluationResult struct {
+	// PolicyID identifies the policy the evidence was evaluated against.
+	PolicyID string
+	// Status is the outcome of the evaluation.
+	Status EvaluationStatus
+	// Location identifies where the evaluated evidence came from, such as a file path,
+	// URI or resource name, when the evaluator knows it.
+	Location string
+}
+
+// PolicyEvaluator evaluates evidence against policies.
+type PolicyEvaluator interface {
+	// Evaluate returns the outcome of evaluating evidence. An error means the
+	// evidence could not be evaluated, not that it failed the policy.
+	Evaluate(ctx context.Context, evidence Evidence) (EvaluationResult, error)
+}
@@ -19,3 +19,17 @@ type Evidence interface {
 	// Timestamp returns the time when the evidence was generated or collected
 	Timestamp() time.Time
 }
+
+// ProvenancePredicateTypeKey is the predicate type of the verified attestation recorded
+// on processed AttestedEvidence.
+const ProvenancePredicateTypeKey = attribute.Key("provenance.predicate_type")
+
+// AttestedEvidence is evidence carrying an in-toto attestation, which Instrumentation
+// verifies against the keys set with WithProvenanceKeys before processing.
+type AttestedEvidence interface {
+	Evidence
+
+	// Attestation returns the DSSE envelope holding the in-toto statement. One of the
+	// statement subjects must carry the digest of the evidence JSON returned by ToJSON.
+	Attestation() []byte
+}
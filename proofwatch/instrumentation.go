@@ -0,0 +1,311 @@
+package proofwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+	"github.com/complytime/complybeacon/proofwatch/provenance"
+)
+
+// ProcessFunc processes a single piece of evidence.
+type ProcessFunc func(ctx context.Context, evidence Evidence) error
+
+// Instrumentation bundles an evidence observer and a tracer so evidence
+// processing can be instrumented with a single call.
+type Instrumentation struct {
+	// cfg is kept to rebuild the observer on Reset.
+	cfg        config
+	tracer     trace.Tracer
+	observer   *metrics.EvidenceObserver
+	deadLetter DeadLetterSink
+	validator  *Validator
+	provenance provenance.KeySet
+	limiter    RateLimiter
+	store      Store
+	aggregator *Aggregator
+	redactor   *Redactor
+	logger     *slog.Logger
+	metricKeys map[attribute.Key]struct{}
+}
+
+// NewInstrumentation creates a new Instrumentation facade from the configured providers.
+func NewInstrumentation(opts ...OptionFunc) (*Instrumentation, error) {
+	cfg := config{
+		MeterProvider:  otel.GetMeterProvider(),
+		LoggerProvider: global.GetLoggerProvider(),
+		TracerProvider: otel.GetTracerProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	observer, err := newObserver(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Instrumentation{
+		cfg:        cfg,
+		tracer:     cfg.TracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(Version())),
+		observer:   observer,
+		deadLetter: cfg.DeadLetterSink,
+		validator:  cfg.Validator,
+		provenance: cfg.ProvenanceKeys,
+		limiter:    cfg.RateLimiter,
+		store:      cfg.Store,
+		aggregator: cfg.Aggregator,
+		redactor:   cfg.Redactor,
+		logger:     cfg.SlogLogger,
+		metricKeys: newMetricKeys(cfg.MetricKeys),
+	}, nil
+}
+
+// newObserver creates the evidence observer of an Instrumentation or ProofWatch from the configured
+// meter provider and observer options, along with the gauges reporting the configured rate limiter and
+// aggregator and any other configured observer gauges.
+func newObserver(cfg config) (*metrics.EvidenceObserver, error) {
+	var observerOpts []metrics.Option
+	if flusher, ok := cfg.MeterProvider.(metrics.Flusher); ok {
+		observerOpts = append(observerOpts, metrics.WithFlusher(flusher))
+	}
+	if cfg.RecordingSpans {
+		tracer := cfg.TracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(Version()))
+		observerOpts = append(observerOpts, metrics.WithTracer(tracer))
+	}
+	observerOpts = append(observerOpts, cfg.ObserverOptions...)
+	meter := cfg.MeterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(Version()))
+	observer, err := metrics.NewEvidenceObserver(meter, observerOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RateLimiter != nil {
+		if err := observer.RegisterRateLimitTokens(cfg.RateLimiter.Tokens); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Aggregator != nil {
+		if err := observer.RegisterPassRateGauge(cfg.Aggregator.PassRates); err != nil {
+			return nil, err
+		}
+	}
+	for _, register := range cfg.ObserverGauges {
+		if err := register(observer); err != nil {
+			return nil, err
+		}
+	}
+	return observer, nil
+}
+
+// Process runs fn inside an "evidence.process" span carrying the evidence attributes
+// and records how long fn took. A nil error from fn records the evidence as processed;
+// otherwise it is recorded as dropped, with the reason given by the error, and the span
+// status is set to error. ErrDuplicate drops with reason duplicate, deadline and network
+// timeout errors with reason timeout, and other errors with reason processing_error.
+// When a validator is configured, evidence failing validation is dropped with reason
+// validation_failed and the first failing field, without calling fn. Likewise, when
+// provenance keys are configured, AttestedEvidence whose attestation does not verify is
+// dropped with reason provenance_invalid, and verified evidence is recorded with the
+// predicate type of its attestation. When a rate limiter is configured, evidence it
+// rejects is dropped with reason rate_limited and ErrRateLimited, without a span. When a
+// maximum age is configured, older evidence is dropped with reason expired and its age
+// bucket, without calling fn. When a redactor is configured, the span attributes and the
+// returned error are redacted. The span carries every evidence attribute, while metrics
+// only carry those with bounded values, as extended with WithMetricAttributes.
+func (i *Instrumentation) Process(ctx context.Context, evidence Evidence, fn ProcessFunc) error {
+	return i.run(ctx, "evidence.process", evidence, func(ctx context.Context, attrs []attribute.KeyValue) error {
+		if err := fn(ctx, evidence); err != nil {
+			return err
+		}
+		i.observer.Processed(ctx, attrs...)
+		result := attributeResult(attrs)
+		i.persist(ctx, evidence, result)
+		i.aggregate(result)
+		i.logProcessed(ctx, result)
+		return nil
+	})
+}
+
+// Evaluate evaluates evidence with evaluator inside an "evidence.evaluate" span and
+// records how long the evaluation took. A successful evaluation records the evidence as
+// processed with the resulting policy ID and status; an evaluation error records it as
+// dropped, as for Process, and is returned.
+func (i *Instrumentation) Evaluate(ctx context.Context, evidence Evidence, evaluator PolicyEvaluator) (EvaluationResult, error) {
+	var result EvaluationResult
+	err := i.run(ctx, "evidence.evaluate", evidence, func(ctx context.Context, attrs []attribute.KeyValue) error {
+		var err error
+		result, err = evaluator.Evaluate(ctx, evidence)
+		if err != nil {
+			return err
+		}
+		if result.PolicyID != "" {
+			attrs = append(attrs[:len(attrs):len(attrs)], metrics.PolicyIDKey.String(result.PolicyID))
+		}
+		i.observer.ProcessedWithStatus(ctx, result.Status, attrs...)
+		recorded := result
+		if recorded.PolicyID == "" {
+			recorded.PolicyID = attributeResult(attrs).PolicyID
+		}
+		i.persist(ctx, evidence, recorded)
+		i.aggregate(recorded)
+		i.logProcessed(ctx, recorded)
+		return nil
+	})
+	return result, err
+}
+
+// Reject records evidence that could not be processed at all, such as a payload that
+// failed to decode, as dropped with the reason given by err, as for Process, and returns
+// err, redacted when a redactor is configured.
+func (i *Instrumentation) Reject(ctx context.Context, evidence Evidence, err error) error {
+	err = redactError(i.redactor, evidence, err)
+	attrs := metricAttributes(i.metricKeys, evidence.Attributes())
+	i.drop(ctx, evidence, dropReason(err), err, append(attrs[:len(attrs):len(attrs)], dropAttributes(err)...))
+	return err
+}
+
+// run runs fn inside a span named name carrying the evidence attributes, tracking the
+// evidence as in flight and recording how long fn took. fn records the evidence as
+// processed itself; an error from fn records it as dropped and sets the span status
+// to error.
+func (i *Instrumentation) run(ctx context.Context, name string, evidence Evidence, fn func(context.Context, []attribute.KeyValue) error) error {
+	if i.limiter != nil && !i.limiter.Allow() {
+		return i.Reject(ctx, evidence, ErrRateLimited)
+	}
+	ctx, span := i.tracer.Start(ctx, name, trace.WithAttributes(redactAttributes(i.redactor, evidence.Attributes())...))
+	defer span.End()
+
+	i.observer.Begin(ctx)
+	defer i.observer.End(ctx)
+
+	attrs := metricAttributes(i.metricKeys, evidence.Attributes())
+	err := i.checkAge(evidence, time.Now())
+	var verified []attribute.KeyValue
+	if err == nil {
+		verified, err = i.verifyProvenance(ctx, evidence)
+	}
+	if err == nil {
+		span.SetAttributes(redactAttributes(i.redactor, verified)...)
+		attrs = append(attrs, verified...)
+		err = i.validate(evidence)
+	}
+	if err == nil {
+		start := time.Now()
+		err = fn(ctx, attrs)
+		i.observer.ObserveDuration(ctx, time.Since(start), attrs...)
+	}
+	if err != nil {
+		err = redactError(i.redactor, evidence, err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		i.drop(ctx, evidence, dropReason(err), err, append(attrs[:len(attrs):len(attrs)], dropAttributes(err)...))
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// verifyProvenance verifies the attestation of AttestedEvidence against the configured
+// provenance keys, if any, and checks that it was issued for the evidence itself by
+// matching the evidence JSON against the statement subject digests. It returns the
+// attributes describing the verified attestation.
+func (i *Instrumentation) verifyProvenance(ctx context.Context, evidence Evidence) ([]attribute.KeyValue, error) {
+	attested, ok := evidence.(AttestedEvidence)
+	if !ok || i.provenance == nil {
+		return nil, nil
+	}
+	raw, err := evidence.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to marshal evidence: %w", provenance.ErrVerificationFailed, err)
+	}
+	subject, err := provenance.VerifyArtifact(ctx, attested.Attestation(), raw, i.provenance)
+	if err != nil {
+		return nil, err
+	}
+	return []attribute.KeyValue{ProvenancePredicateTypeKey.String(subject.PredicateType)}, nil
+}
+
+// validate checks evidence against the configured validator, if any.
+func (i *Instrumentation) validate(evidence Evidence) error {
+	if i.validator == nil {
+		return nil
+	}
+	raw, err := evidence.ToJSON()
+	if err != nil {
+		return &ValidationError{Fields: []FieldError{{Message: "failed to marshal evidence: " + err.Error()}}}
+	}
+	return i.validator.Validate(raw)
+}
+
+// dropReason returns the reason evidence failing with err is dropped for, as given by
+// DropReasonForError, with errors of no known kind dropped as processing_error.
+func dropReason(err error) DropReason {
+	if reason := DropReasonForError(err); reason != DropReasonUnknown {
+		return reason
+	}
+	return DropReasonProcessingError
+}
+
+// dropAttributes returns the attributes describing err recorded on dropped evidence:
+// the attempt count of a *RetryError, the schema keyword of the first field failing a
+// *ValidationError and the age bucket of an *ExpiredError.
+func dropAttributes(err error) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		attrs = append(attrs, metrics.RetryAttempt(retryErr.Attempts))
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) && len(validationErr.Fields) > 0 && validationErr.Fields[0].Keyword != "" {
+		attrs = append(attrs, ValidationKeywordKey.String(validationErr.Fields[0].Keyword))
+	}
+	var expiredErr *ExpiredError
+	if errors.As(err, &expiredErr) {
+		attrs = append(attrs, AgeBucketKey.String(ageBucket(expiredErr.Age)))
+	}
+	return attrs
+}
+
+// drop records evidence as dropped with reason and hands it to the dead letter sink,
+// if one is configured. With a redactor, the dead letter holds the redacted evidence and
+// only the redacted error message. Sink failures are reported to the OpenTelemetry error
+// handler.
+func (i *Instrumentation) drop(ctx context.Context, evidence Evidence, reason DropReason, err error, attrs []attribute.KeyValue) {
+	policyID := attributeResult(attrs).PolicyID
+	i.observer.DroppedWithReason(ctx, reason, attrs...)
+	i.persist(ctx, evidence, EvaluationResult{PolicyID: policyID, Status: EvaluationStatusError})
+	i.logDropped(ctx, reason, policyID, err)
+	if i.deadLetter == nil {
+		return
+	}
+	redacted, redactErr := redact(i.redactor, evidence)
+	if redactErr != nil {
+		otel.Handle(fmt.Errorf("failed to redact dead letter: %w", redactErr))
+		return
+	}
+	if i.redactor != nil && err != nil {
+		// Keep only the redacted message: the wrapped error, such as a *ValidationError,
+		// may quote the original values.
+		err = &redactedError{msg: i.redactor.RedactError(evidence, err).Error()}
+	}
+	letter := DeadLetter{Evidence: redacted, Reason: reason, Err: err, DroppedAt: time.Now()}
+	if err := i.deadLetter.Write(ctx, letter); err != nil {
+		otel.Handle(err)
+	}
+}
+
+// Shutdown flushes pending metrics when the configured MeterProvider supports it and
+// stops recording evidence. Calling Shutdown again returns an error.
+func (i *Instrumentation) Shutdown(ctx context.Context) error {
+	return i.observer.Shutdown(ctx)
+}
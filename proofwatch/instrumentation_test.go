@@ -0,0 +1,336 @@
+package proofwatch
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/complytime/complybeacon/proofwatch/provenance"
+)
+
+func setupInstrumentationTest(t *testing.T) (*Instrumentation, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() {
+		_ = tracerProvider.Shutdown(context.Background())
+		_ = meterProvider.Shutdown(context.Background())
+	})
+
+	inst, err := NewInstrumentation(
+		WithTracerProvider(tracerProvider),
+		WithMeterProvider(meterProvider),
+	)
+	require.NoError(t, err)
+	return inst, exporter, reader
+}
+
+func metricNames(t *testing.T, reader *sdkmetric.ManualReader) map[string]bool {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	found := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+		}
+	}
+	return found
+}
+
+// int64Gauge returns the value of the single data point of an int64 gauge.
+func int64Gauge(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				data, ok := m.Data.(metricdata.Gauge[int64])
+				require.True(t, ok, "metric %s is not an int64 gauge", name)
+				require.Len(t, data.DataPoints, 1)
+				return data.DataPoints[0].Value
+			}
+		}
+	}
+	require.Failf(t, "metric not found", "expected metric %q to be collected", name)
+	return 0
+}
+
+func TestInstrumentationProcess(t *testing.T) {
+	t.Run("success produces span and processed metric", func(t *testing.T) {
+		inst, exporter, reader := setupInstrumentationTest(t)
+
+		err := inst.Process(context.Background(), createTestEvidence(), func(ctx context.Context, _ Evidence) error {
+			return nil
+		})
+		require.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "evidence.process", spans[0].Name)
+		assert.Equal(t, codes.Ok, spans[0].Status.Code)
+		assert.NotEmpty(t, spans[0].Attributes)
+
+		found := metricNames(t, reader)
+		assert.True(t, found["evidence_processed_count"])
+		assert.True(t, found["evidence_processing_duration_seconds"])
+	})
+
+	t.Run("error produces error span and dropped metric", func(t *testing.T) {
+		inst, exporter, reader := setupInstrumentationTest(t)
+
+		err := inst.Process(context.Background(), createTestEvidence(), func(ctx context.Context, _ Evidence) error {
+			return assert.AnError
+		})
+		require.ErrorIs(t, err, assert.AnError)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+
+		found := metricNames(t, reader)
+		assert.True(t, found["evidence_dropped_count"])
+		assert.False(t, found["evidence_processed_count"])
+	})
+}
+
+func TestInstrumentationShutdown(t *testing.T) {
+	inst, _, reader := setupInstrumentationTest(t)
+	ctx := context.Background()
+
+	require.NoError(t, inst.Shutdown(ctx))
+	assert.Error(t, inst.Shutdown(ctx))
+
+	err := inst.Process(ctx, createTestEvidence(), func(ctx context.Context, _ Evidence) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, metricNames(t, reader)["evidence_processed_count"])
+}
+
+type evaluatorFunc func(ctx context.Context, evidence Evidence) (EvaluationResult, error)
+
+func (f evaluatorFunc) Evaluate(ctx context.Context, evidence Evidence) (EvaluationResult, error) {
+	return f(ctx, evidence)
+}
+
+func TestInstrumentationEvaluate(t *testing.T) {
+	t.Run("records processed evidence with the evaluation status", func(t *testing.T) {
+		inst, exporter, reader := setupInstrumentationTest(t)
+		want := EvaluationResult{PolicyID: "policy-1", Status: EvaluationStatusFail}
+
+		got, err := inst.Evaluate(context.Background(), createTestEvidence(), evaluatorFunc(func(context.Context, Evidence) (EvaluationResult, error) {
+			return want, nil
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "evidence.evaluate", spans[0].Name)
+		assert.Equal(t, codes.Ok, spans[0].Status.Code)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		var found bool
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != "evidence_processed_count" {
+					continue
+				}
+				dps := m.Data.(metricdata.Sum[int64]).DataPoints
+				require.Len(t, dps, 1)
+				status, _ := dps[0].Attributes.Value("policy.evaluation.status")
+				policyID, _ := dps[0].Attributes.Value("policy.id")
+				assert.Equal(t, "fail", status.AsString())
+				assert.Equal(t, "policy-1", policyID.AsString())
+				found = true
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("records evaluation errors as dropped", func(t *testing.T) {
+		inst, exporter, reader := setupInstrumentationTest(t)
+
+		_, err := inst.Evaluate(context.Background(), createTestEvidence(), evaluatorFunc(func(context.Context, Evidence) (EvaluationResult, error) {
+			return EvaluationResult{}, assert.AnError
+		}))
+		require.ErrorIs(t, err, assert.AnError)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, map[string]int64{"processing_error": 1}, int64Sums(t, reader, "evidence_dropped_count"))
+		assert.False(t, metricNames(t, reader)["evidence_processed_count"])
+	})
+}
+
+// attestedEvidence attaches a DSSE envelope to OCSF evidence.
+type attestedEvidence struct {
+	OCSFEvidence
+	attestation []byte
+}
+
+func (a attestedEvidence) Attestation() []byte {
+	return a.attestation
+}
+
+// signedAttestation returns a DSSE envelope of an in-toto statement for evidence with
+// the given predicate type, signed with priv.
+func signedAttestation(t *testing.T, priv ed25519.PrivateKey, evidence Evidence, predicateType string) []byte {
+	t.Helper()
+
+	raw, err := evidence.ToJSON()
+	require.NoError(t, err)
+	digest := sha256.Sum256(raw)
+	stmt, err := json.Marshal(map[string]any{
+		"_type":         provenance.StatementType,
+		"subject":       []map[string]any{{"name": "evidence.json", "digest": map[string]string{"sha256": hex.EncodeToString(digest[:])}}},
+		"predicateType": predicateType,
+	})
+	require.NoError(t, err)
+	env, err := json.Marshal(map[string]any{
+		"payloadType": provenance.PayloadType,
+		"payload":     base64.StdEncoding.EncodeToString(stmt),
+		"signatures": []map[string]string{{
+			"keyid": "builder",
+			"sig":   base64.StdEncoding.EncodeToString(ed25519.Sign(priv, provenance.PAE(provenance.PayloadType, stmt))),
+		}},
+	})
+	require.NoError(t, err)
+	return env
+}
+
+func TestInstrumentationProvenance(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, untrusted, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	setup := func(t *testing.T) (*Instrumentation, *sdkmetric.ManualReader) {
+		reader := sdkmetric.NewManualReader()
+		meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		t.Cleanup(func() { _ = meterProvider.Shutdown(context.Background()) })
+		inst, err := NewInstrumentation(
+			WithMeterProvider(meterProvider),
+			WithProvenanceKeys(provenance.KeySet{"builder": pub}),
+		)
+		require.NoError(t, err)
+		return inst, reader
+	}
+	process := func(context.Context, Evidence) error { return nil }
+
+	t.Run("verified attestation records the predicate type", func(t *testing.T) {
+		inst, reader := setup(t)
+		ev := createTestEvidence()
+		evidence := attestedEvidence{ev, signedAttestation(t, priv, ev, "https://slsa.dev/provenance/v1")}
+
+		require.NoError(t, inst.Process(context.Background(), evidence, process))
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		var found bool
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != "evidence_processed_count" {
+					continue
+				}
+				dps := m.Data.(metricdata.Sum[int64]).DataPoints
+				require.Len(t, dps, 1)
+				predicateType, _ := dps[0].Attributes.Value(ProvenancePredicateTypeKey)
+				assert.Equal(t, "https://slsa.dev/provenance/v1", predicateType.AsString())
+				found = true
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("invalid attestation is dropped", func(t *testing.T) {
+		inst, reader := setup(t)
+		ev := createTestEvidence()
+		evidence := attestedEvidence{ev, signedAttestation(t, untrusted, ev, "https://slsa.dev/provenance/v1")}
+
+		err := inst.Process(context.Background(), evidence, func(context.Context, Evidence) error {
+			t.Fatal("process called for evidence with an invalid attestation")
+			return nil
+		})
+		require.ErrorIs(t, err, provenance.ErrVerificationFailed)
+		assert.Equal(t, map[string]int64{"provenance_invalid": 1}, int64Sums(t, reader, "evidence_dropped_count"))
+		assert.False(t, metricNames(t, reader)["evidence_processed_count"])
+	})
+
+	t.Run("valid attestation for tampered evidence is dropped", func(t *testing.T) {
+		inst, reader := setup(t)
+		ev := createTestEvidence()
+		attestation := signedAttestation(t, priv, ev, "https://slsa.dev/provenance/v1")
+		tampered := ev
+		tampered.Status = stringPtr("failure")
+		evidence := attestedEvidence{tampered, attestation}
+
+		err := inst.Process(context.Background(), evidence, func(context.Context, Evidence) error {
+			t.Fatal("process called for evidence not matching its attestation")
+			return nil
+		})
+		require.ErrorIs(t, err, provenance.ErrVerificationFailed)
+		assert.Equal(t, map[string]int64{"provenance_invalid": 1}, int64Sums(t, reader, "evidence_dropped_count"))
+		assert.False(t, metricNames(t, reader)["evidence_processed_count"])
+	})
+
+	t.Run("evidence without attestation is not verified", func(t *testing.T) {
+		inst, reader := setup(t)
+
+		require.NoError(t, inst.Process(context.Background(), createTestEvidence(), process))
+		assert.True(t, metricNames(t, reader)["evidence_processed_count"])
+	})
+}
+
+func TestInstrumentationReject(t *testing.T) {
+	inst, exporter, reader := setupInstrumentationTest(t)
+	verr := &ValidationError{Fields: []FieldError{{Field: "/policy", Message: "missing required property"}}}
+
+	err := inst.Reject(context.Background(), createTestEvidence(), verr)
+	assert.Equal(t, verr, err)
+
+	assert.Empty(t, exporter.GetSpans())
+	assert.Equal(t, map[string]int64{"validation_failed": 1}, int64Sums(t, reader, "evidence_dropped_count"))
+}
+
+func TestInstrumentationSampling(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = meterProvider.Shutdown(ctx) })
+	inst, err := NewInstrumentation(WithMeterProvider(meterProvider), WithSampling(0.5))
+	require.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return nil }))
+		require.Error(t, inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return assert.AnError }))
+	}
+
+	// Every sampled item counts for two, and drops are never sampled.
+	processed := int64Sums(t, reader, "evidence_processed_count")[""]
+	assert.Zero(t, processed%2)
+	assert.Equal(t, map[string]int64{"processing_error": 50}, int64Sums(t, reader, "evidence_dropped_count"))
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func (e *EvidenceObserver) initAccepted(meter metric.Meter) error {
+	var err error
+	e.acceptedCounter, err = meter.Int64Counter(
+		"evidence_accepted_count",
+		metric.WithDescription("The total number of evidence items acknowledged for asynchronous processing."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create accepted counter: %w", err)
+	}
+	return nil
+}
+
+// RecordAccepted records evidence acknowledged synchronously and queued for
+// asynchronous processing. Processed is recorded separately once processing
+// completes, so the gap between the two counters reveals the async backlog.
+func (e *EvidenceObserver) RecordAccepted(ctx context.Context, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.acceptedCounter.Add(ctx, 1, e.measurementAttrs(attrs))
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAccepted(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		fixture.observer.RecordAccepted(ctx)
+	}
+	fixture.observer.Processed(ctx)
+	fixture.observer.Processed(ctx)
+
+	accepted := fixture.int64Points(ctx, "evidence_accepted_count")
+	require.Len(t, accepted, 1)
+	assert.Equal(t, int64(5), accepted[0].Value)
+
+	processed := fixture.int64Points(ctx, "evidence_processed_count")
+	require.Len(t, processed, 1)
+	assert.Equal(t, int64(2), processed[0].Value)
+}
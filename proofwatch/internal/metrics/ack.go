@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ackLatencyBuckets span one minute to three days, in seconds.
+var ackLatencyBuckets = []float64{
+	(1 * time.Minute).Seconds(),
+	(5 * time.Minute).Seconds(),
+	(15 * time.Minute).Seconds(),
+	(30 * time.Minute).Seconds(),
+	(1 * time.Hour).Seconds(),
+	(4 * time.Hour).Seconds(),
+	(12 * time.Hour).Seconds(),
+	(24 * time.Hour).Seconds(),
+	(72 * time.Hour).Seconds(),
+}
+
+func (e *EvidenceObserver) initAckLatency(meter metric.Meter) error {
+	var err error
+	e.ackLatency, err = meter.Float64Histogram(
+		"alert_ack_latency_seconds",
+		metric.WithDescription("The time until an alert raised by evidence was acknowledged by an operator, by severity."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(ackLatencyBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create ack latency histogram: %w", err)
+	}
+	return nil
+}
+
+// RecordAckLatency records how long an alert of the given severity took to be
+// acknowledged. A negative latency is recorded as zero.
+func (e *EvidenceObserver) RecordAckLatency(ctx context.Context, latency time.Duration, severity Severity, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.ackLatency.Record(ctx, max(latency, 0).Seconds(), e.measurementAttrs(attrs, severity.attribute()))
+}
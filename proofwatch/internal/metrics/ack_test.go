@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAckLatency(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordAckLatency(ctx, 3*time.Minute, SeverityCritical)
+	fixture.observer.RecordAckLatency(ctx, 10*time.Minute, SeverityCritical)
+	fixture.observer.RecordAckLatency(ctx, 6*time.Hour, SeverityLow)
+	fixture.observer.RecordAckLatency(ctx, time.Hour, Severity("urgent"))
+
+	buckets := map[string][]uint64{}
+	for _, dp := range fixture.float64Histogram(ctx, "alert_ack_latency_seconds") {
+		severity, _ := attrValue(dp.Attributes, SeverityKey)
+		buckets[severity] = dp.BucketCounts
+	}
+	require.Len(t, buckets, 3)
+
+	// Buckets: (-inf,1m] (1m,5m] (5m,15m] (15m,30m] (30m,1h] (1h,4h] (4h,12h] (12h,24h] (24h,72h] (72h,+inf)
+	assert.Equal(t, []uint64{0, 1, 1, 0, 0, 0, 0, 0, 0, 0}, buckets["critical"])
+	assert.Equal(t, []uint64{0, 0, 0, 0, 0, 0, 1, 0, 0, 0}, buckets["low"])
+	assert.Equal(t, []uint64{0, 0, 0, 0, 1, 0, 0, 0, 0, 0}, buckets["unknown"])
+}
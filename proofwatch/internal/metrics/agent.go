@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// AgentVersionKey is the bounded collector agent version attribute. Versions are
+// registered with WithAgentVersions.
+const AgentVersionKey = attribute.Key("agent.version")
+
+// WithAgentVersions registers the collector agent versions accepted by
+// ProcessedByAgentVersion.
+func WithAgentVersions(versions ...string) Option {
+	return WithAllowedValues(AgentVersionKey, versions...)
+}
+
+// ProcessedByAgentVersion records processed evidence along with the version of the
+// collector agent that produced it. Unregistered versions are recorded as "other".
+func (e *EvidenceObserver) ProcessedByAgentVersion(ctx context.Context, version string, attrs ...attribute.KeyValue) {
+	e.Processed(ctx, append(attrs[:len(attrs):len(attrs)], e.bounded(AgentVersionKey, version, nil))...)
+}
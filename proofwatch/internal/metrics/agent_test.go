@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessedByAgentVersion(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithAgentVersions("1.4.0", "1.5.2"))
+	ctx := context.Background()
+
+	fixture.observer.ProcessedByAgentVersion(ctx, "1.4.0")
+	fixture.observer.ProcessedByAgentVersion(ctx, "1.5.2")
+	fixture.observer.ProcessedByAgentVersion(ctx, "1.5.2")
+	fixture.observer.ProcessedByAgentVersion(ctx, "0.9.0-dev")
+	fixture.observer.ProcessedByAgentVersion(ctx, "")
+
+	got := sumByAttr(fixture.int64Points(ctx, "evidence_processed_count"), AgentVersionKey)
+	assert.Equal(t, map[string]int64{"1.4.0": 1, "1.5.2": 2, "other": 2}, got)
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// CloudProviderKey is the bounded cloud provider attribute.
+	CloudProviderKey = attribute.Key("cloud.provider")
+	// CloudAccountIDKey is the raw cloud account attribute callers may supply.
+	// It is never recorded as-is when a cloud context is configured.
+	CloudAccountIDKey = attribute.Key("cloud.account.id")
+	// CloudAccountKey is the bucketed cloud account attribute.
+	CloudAccountKey = attribute.Key("cloud.account")
+	// PolicyIDKey identifies the policy a recording relates to.
+	PolicyIDKey = attribute.Key("policy.id")
+	// SourceKey identifies the source evidence was received from.
+	SourceKey = attribute.Key("policy.source")
+)
+
+// unknownValue is the value enum attributes collapse to when the input is not a known member.
+const unknownValue = "unknown"
+
+// otherValue is the value bounded attributes collapse to when the input is not recognized.
+const otherValue = "other"
+
+// cloudProviders holds the cloud.provider values defined by the OpenTelemetry semantic conventions.
+var cloudProviders = newBoundedSet("alibaba_cloud", "aws", "azure", "gcp", "heroku", "ibm_cloud", "tencent_cloud")
+
+// boundedSet is the set of allowed values for a bounded attribute.
+type boundedSet map[string]struct{}
+
+func newBoundedSet(values ...string) boundedSet {
+	s := make(boundedSet, len(values))
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+// normalize returns value when it is part of the set and fallback otherwise.
+func (s boundedSet) normalize(value, fallback string) string {
+	if _, ok := s[value]; ok {
+		return value
+	}
+	return fallback
+}
+
+// bounded returns key set to value when value is registered for key through
+// WithAllowedValues or is one of defaults, and to "other" otherwise.
+func (e *EvidenceObserver) bounded(key attribute.Key, value string, defaults boundedSet) attribute.KeyValue {
+	return key.String(e.allowedValue(key, value, defaults))
+}
+
+// allowedValue returns value when it is registered for key through WithAllowedValues
+// or is one of defaults, and "other" otherwise.
+func (e *EvidenceObserver) allowedValue(key attribute.Key, value string, defaults boundedSet) string {
+	return e.allowedValueOr(key, value, defaults, otherValue)
+}
+
+// allowedValueOr is like allowedValue but falls back to fallback.
+func (e *EvidenceObserver) allowedValueOr(key attribute.Key, value string, defaults boundedSet, fallback string) string {
+	if _, ok := e.cfg.allowed[key][value]; ok {
+		return value
+	}
+	return defaults.normalize(value, fallback)
+}
+
+func normalizeCloudProvider(provider string) string {
+	return cloudProviders.normalize(strings.ToLower(strings.TrimSpace(provider)), otherValue)
+}
+
+// bucketOf deterministically maps value onto one of n buckets.
+func bucketOf(value string, n int) string {
+	if n <= 1 {
+		return "0"
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(value))
+	return strconv.FormatUint(h.Sum64()%uint64(n), 10) // #nosec G115 -- n is positive
+}
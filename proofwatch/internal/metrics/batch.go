@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Outcome is the outcome of processing an evidence item.
+type Outcome int
+
+const (
+	OutcomeProcessed Outcome = iota
+	OutcomeDropped
+)
+
+// EvidenceResult is the outcome of processing a single evidence item, as recorded by
+// RecordBatch.
+type EvidenceResult struct {
+	Outcome Outcome
+	// Reason is the reason a dropped item was dropped. It is ignored for processed items.
+	Reason     DropReason
+	Attributes []attribute.KeyValue
+}
+
+// batchKey identifies one aggregated increment of a batch.
+type batchKey struct {
+	dropped bool
+	set     attribute.Distinct
+}
+
+type batchCount struct {
+	set attribute.Set
+	n   int64
+}
+
+// RecordBatch records the outcomes of many evidence items at once. Results with the
+// same outcome and attributes are aggregated into a single counter increment, so the
+// counts match recording each result with Processed or DroppedWithReason while making
+// one meter call per distinct attribute set. Unlike Processed and Dropped, RecordBatch
+// does not start spans for WithTracer. It is safe to call concurrently with the
+// single-record methods.
+func (e *EvidenceObserver) RecordBatch(ctx context.Context, results []EvidenceResult) {
+	if !e.recording() {
+		return
+	}
+
+	counts := make(map[batchKey]*batchCount)
+	var order []batchKey
+	for _, r := range results {
+		dropped := r.Outcome == OutcomeDropped
+		attrs := r.Attributes
+		if dropped {
+			attrs = append(attrs[:len(attrs):len(attrs)], r.Reason.attribute())
+		}
+		recorded, ok := e.admit(ctx, dropped, attrs)
+		if !ok {
+			continue
+		}
+
+		set := attribute.NewSet(recorded...)
+		key := batchKey{dropped: dropped, set: set.Equivalent()}
+		c, ok := counts[key]
+		if !ok {
+			c = &batchCount{set: set}
+			counts[key] = c
+			order = append(order, key)
+		}
+		c.n++
+	}
+
+	for _, key := range order {
+		c := counts[key]
+		e.count(ctx, key.dropped, c.n, metric.WithAttributeSet(c.set))
+	}
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func testBatch() []EvidenceResult {
+	var results []EvidenceResult
+	for i := 0; i < 30; i++ {
+		policy := attribute.String("policy.id", "policy-"+strconv.Itoa(i%3))
+		switch i % 5 {
+		case 0:
+			results = append(results, EvidenceResult{Outcome: OutcomeDropped, Reason: DropReasonTimeout, Attributes: []attribute.KeyValue{policy}})
+		case 1:
+			results = append(results, EvidenceResult{Outcome: OutcomeDropped, Reason: "bogus", Attributes: []attribute.KeyValue{policy}})
+		default:
+			results = append(results, EvidenceResult{Outcome: OutcomeProcessed, Attributes: []attribute.KeyValue{policy}})
+		}
+	}
+	return results
+}
+
+// seriesCounts returns the collected value of every series of the named sum, keyed by
+// its encoded attribute set.
+func (f *evidenceObserverTestFixture) seriesCounts(ctx context.Context, name string) map[string]int64 {
+	counts := map[string]int64{}
+	for _, dp := range f.int64Points(ctx, name) {
+		counts[dp.Attributes.Encoded(attribute.DefaultEncoder())] = dp.Value
+	}
+	return counts
+}
+
+func TestRecordBatch(t *testing.T) {
+	ctx := context.Background()
+	results := testBatch()
+
+	batched := setupEvidenceObserverTest(t)
+	batched.observer.RecordBatch(ctx, results)
+
+	individual := setupEvidenceObserverTest(t)
+	for _, r := range results {
+		if r.Outcome == OutcomeDropped {
+			individual.observer.DroppedWithReason(ctx, r.Reason, r.Attributes...)
+		} else {
+			individual.observer.Processed(ctx, r.Attributes...)
+		}
+	}
+
+	for _, name := range []string{"evidence_processed_count", "evidence_dropped_count"} {
+		assert.Equal(t, individual.seriesCounts(ctx, name), batched.seriesCounts(ctx, name), name)
+	}
+	assert.Equal(t, map[string]int64{"timeout": 6, "unknown": 6}, sumByAttr(batched.int64Points(ctx, "evidence_dropped_count"), DropReasonKey))
+}
+
+func TestRecordBatchConcurrent(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+	results := testBatch()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			fixture.observer.RecordBatch(ctx, results)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 300; i++ {
+			fixture.observer.Processed(ctx, attribute.String("policy.id", "policy-0"))
+		}
+	}()
+	wg.Wait()
+
+	var total int64
+	for _, dp := range fixture.int64Points(ctx, "evidence_processed_count") {
+		total += dp.Value
+	}
+	require.Equal(t, int64(10*18+300), total)
+}
+
+func benchmarkObserver(b *testing.B) *EvidenceObserver {
+	b.Helper()
+
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	b.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+	observer, err := NewEvidenceObserver(mp.Meter("bench-meter"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return observer
+}
+
+func BenchmarkRecordBatch(b *testing.B) {
+	observer := benchmarkObserver(b)
+	ctx := context.Background()
+	results := testBatch()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		observer.RecordBatch(ctx, results)
+	}
+}
+
+func BenchmarkRecordIndividually(b *testing.B) {
+	observer := benchmarkObserver(b)
+	ctx := context.Background()
+	results := testBatch()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, r := range results {
+			if r.Outcome == OutcomeDropped {
+				observer.DroppedWithReason(ctx, r.Reason, r.Attributes...)
+			} else {
+				observer.Processed(ctx, r.Attributes...)
+			}
+		}
+	}
+}
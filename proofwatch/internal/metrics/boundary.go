@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// BoundaryKey is the bounded compliance boundary attribute, such as a FedRAMP
+// authorization boundary. Boundaries are registered with WithBoundaries.
+const BoundaryKey = attribute.Key("compliance.boundary")
+
+// WithBoundaries registers the compliance boundaries accepted by WithBoundary.
+func WithBoundaries(ids ...string) Option {
+	return WithAllowedValues(BoundaryKey, ids...)
+}
+
+// WithBoundary tags every recording with the compliance boundary evidence belongs to.
+// The boundary must be registered with WithBoundaries, in any option order;
+// otherwise NewEvidenceObserver returns an error.
+func WithBoundary(id string) Option {
+	return func(cfg *observerConfig) {
+		cfg.boundary = id
+	}
+}
+
+// initBoundary validates the configured boundary once all options, including the
+// boundary registrations, have been applied.
+func (e *EvidenceObserver) initBoundary(_ metric.Meter) error {
+	if e.cfg.boundary == "" {
+		return nil
+	}
+	if _, ok := e.cfg.allowed[BoundaryKey][e.cfg.boundary]; !ok {
+		return fmt.Errorf("compliance boundary %q is not registered", e.cfg.boundary)
+	}
+	e.cfg.addStatic(BoundaryKey.String(e.cfg.boundary))
+	return nil
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestWithBoundary(t *testing.T) {
+	t.Run("records per-boundary counts", func(t *testing.T) {
+		ctx := context.Background()
+		reader := sdkmetric.NewManualReader()
+		meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test-meter")
+		registered := WithBoundaries("fedramp-moderate", "fedramp-high")
+
+		moderate, err := NewEvidenceObserver(meter, WithBoundary("fedramp-moderate"), registered)
+		require.NoError(t, err)
+		high, err := NewEvidenceObserver(meter, registered, WithBoundary("fedramp-high"))
+		require.NoError(t, err)
+
+		moderate.Processed(ctx)
+		moderate.Processed(ctx)
+		high.Processed(ctx)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		var points []metricdata.DataPoint[int64]
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			if m.Name == "evidence_processed_count" {
+				points = m.Data.(metricdata.Sum[int64]).DataPoints
+			}
+		}
+		assert.Equal(t, map[string]int64{"fedramp-moderate": 2, "fedramp-high": 1}, sumByAttr(points, BoundaryKey))
+	})
+
+	t.Run("rejects unregistered boundaries", func(t *testing.T) {
+		meter := sdkmetric.NewMeterProvider().Meter("test-meter")
+
+		observer, err := NewEvidenceObserver(meter, WithBoundaries("fedramp-moderate"), WithBoundary("fedramp-low"))
+		assert.Error(t, err)
+		assert.Nil(t, observer)
+	})
+}
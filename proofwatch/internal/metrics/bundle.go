@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// BundleVersionKey is the bounded policy bundle version attribute. Versions must be
+// registered with WithAllowedValues to be recorded under their own value.
+const BundleVersionKey = attribute.Key("policy.bundle.version")
+
+// DroppedBundleTimeout records evidence dropped because evaluating the whole policy
+// bundle timed out, as opposed to a single policy timing out.
+func (e *EvidenceObserver) DroppedBundleTimeout(ctx context.Context, bundleVersion string, attrs ...attribute.KeyValue) {
+	e.droppedWithReason(ctx, DropReasonBundleTimeout, attrs, e.bounded(BundleVersionKey, bundleVersion, nil))
+}
+
+// RegisterBundleVersionSkew registers the policy_bundle_version_skew gauge. At each
+// collection, versions is called for the bundle versions active across replicas and
+// the gauge reports how many distinct versions are in use; anything above one means
+// replicas may produce diverging results.
+func (e *EvidenceObserver) RegisterBundleVersionSkew(versions func(ctx context.Context) []string) error {
+	_, err := (*e.meter).Int64ObservableGauge(
+		"policy_bundle_version_skew",
+		metric.WithDescription("The number of distinct policy bundle versions active across replicas."),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			distinct := make(map[string]struct{})
+			for _, v := range versions(ctx) {
+				if v != "" {
+					distinct[v] = struct{}{}
+				}
+			}
+			o.Observe(int64(len(distinct)), metric.WithAttributes(e.cfg.static...))
+			return nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle version skew gauge: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDroppedBundleTimeout(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithAllowedValues(BundleVersionKey, "2024.10"))
+	ctx := context.Background()
+
+	fixture.observer.DroppedBundleTimeout(ctx, "2024.10")
+	fixture.observer.DroppedBundleTimeout(ctx, "2023.01-unregistered")
+
+	points := fixture.int64Points(ctx, "evidence_dropped_count")
+	require.Len(t, points, 2)
+	assert.Equal(t, map[string]int64{"bundle_timeout": 2}, sumByAttr(points, DropReasonKey))
+	assert.Equal(t, map[string]int64{"2024.10": 1, "other": 1}, sumByAttr(points, BundleVersionKey))
+}
+
+func TestRegisterBundleVersionSkew(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	active := []string{"2024.10", "2024.10", "2024.11", "2024.09", ""}
+	require.NoError(t, fixture.observer.RegisterBundleVersionSkew(func(context.Context) []string {
+		return active
+	}))
+
+	points := fixture.int64Gauge(ctx, "policy_bundle_version_skew")
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(3), points[0].Value)
+
+	active = []string{"2024.11", "2024.11"}
+	points = fixture.int64Gauge(ctx, "policy_bundle_version_skew")
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(1), points[0].Value)
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// fastBurnThreshold is the burn rate that exhausts a 30 day error budget in roughly
+// two days, the usual page-worthy threshold for multi-window burn-rate alerts.
+const fastBurnThreshold = 14.4
+
+// burnRateSlotsPerShortWindow sets the resolution of the burn-rate tracker.
+const burnRateSlotsPerShortWindow = 12
+
+// WithBurnRateAlert alerts on the drop rate burning through the error budget of slo.
+// The burn rate over a window is the drop ratio divided by the error budget (1 - slo).
+// fn is called with the long-window burn rate when both the short and the long window
+// burn faster than the fast-burn threshold, and is not called again until the burn
+// rate recovers. Invalid parameters disable the alert.
+func WithBurnRateAlert(slo float64, shortWindow, longWindow time.Duration, fn func(burnRate float64)) Option {
+	return func(cfg *observerConfig) {
+		if slo <= 0 || slo >= 1 || shortWindow <= 0 || longWindow < shortWindow || fn == nil {
+			return
+		}
+		cfg.burnRate = newBurnRateTracker(slo, shortWindow, longWindow, fn)
+	}
+}
+
+// burnRateTracker keeps processed/dropped counts covering the long window.
+type burnRateTracker struct {
+	mu          sync.Mutex
+	budget      float64
+	shortWindow time.Duration
+	longWindow  time.Duration
+	window      *RatioWindow
+	alerting    bool
+	fn          func(float64)
+	now         func() time.Time
+}
+
+func newBurnRateTracker(slo float64, shortWindow, longWindow time.Duration, fn func(float64)) *burnRateTracker {
+	width := max(shortWindow/burnRateSlotsPerShortWindow, time.Millisecond)
+	return &burnRateTracker{
+		budget:      1 - slo,
+		shortWindow: shortWindow,
+		longWindow:  longWindow,
+		window:      NewRatioWindow(width, int(longWindow/width)+1),
+		fn:          fn,
+		now:         time.Now,
+	}
+}
+
+// observe records one outcome and fires the alert callback when the burn rate
+// crosses the fast-burn threshold in both windows.
+func (b *burnRateTracker) observe(dropped bool) {
+	b.mu.Lock()
+	now := b.now()
+	b.window.Observe(now, dropped)
+
+	short := b.rate(now, b.shortWindow)
+	long := b.rate(now, b.longWindow)
+	firing := short >= fastBurnThreshold && long >= fastBurnThreshold
+	fire := firing && !b.alerting
+	b.alerting = firing
+	b.mu.Unlock()
+
+	if fire {
+		b.fn(long)
+	}
+}
+
+// rate returns the burn rate over the window ending at now. Callers hold b.mu.
+func (b *burnRateTracker) rate(now time.Time, window time.Duration) float64 {
+	dropped, total := b.window.Counts(now, window)
+	if total == 0 {
+		return 0
+	}
+	return float64(dropped) / float64(total) / b.budget
+}
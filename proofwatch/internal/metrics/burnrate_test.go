@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBurnRateAlert(t *testing.T) {
+	t.Run("fast burn fires once", func(t *testing.T) {
+		var alerts []float64
+		fixture := setupEvidenceObserverTest(t,
+			WithBurnRateAlert(0.99, 5*time.Minute, time.Hour, func(burnRate float64) {
+				alerts = append(alerts, burnRate)
+			}),
+		)
+		ctx := context.Background()
+
+		now := time.Unix(1_700_000_000, 0)
+		fixture.observer.cfg.burnRate.now = func() time.Time { return now }
+
+		// A healthy hour of traffic keeps the burn rate low.
+		for i := 0; i < 60; i++ {
+			now = now.Add(time.Minute)
+			fixture.observer.Processed(ctx)
+		}
+		assert.Empty(t, alerts)
+
+		// Dropping everything burns budget 100x faster than allowed.
+		for i := 0; i < 20; i++ {
+			now = now.Add(10 * time.Second)
+			fixture.observer.Dropped(ctx)
+		}
+
+		require.Len(t, alerts, 1)
+		assert.GreaterOrEqual(t, alerts[0], fastBurnThreshold)
+	})
+
+	t.Run("invalid slo disables alert", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithBurnRateAlert(1.5, time.Minute, time.Hour, func(float64) {}))
+		assert.Nil(t, fixture.observer.cfg.burnRate)
+	})
+}
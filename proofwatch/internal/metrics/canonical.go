@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func (e *EvidenceObserver) initCanonicalCache(meter metric.Meter) error {
+	var err error
+	e.canonicalHitCounter, err = meter.Int64Counter(
+		"normalization_canonical_cache_hit_count",
+		metric.WithDescription("The total number of canonical-form normalizations served from the cache."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create canonical cache hit counter: %w", err)
+	}
+
+	e.canonicalMissCounter, err = meter.Int64Counter(
+		"normalization_canonical_cache_miss_count",
+		metric.WithDescription("The total number of canonical-form normalizations that missed the cache."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create canonical cache miss counter: %w", err)
+	}
+	return nil
+}
+
+// RecordCanonicalCacheHit records a canonical-form normalization served from the
+// normalization cache. Evaluation and dedup caches are measured separately.
+func (e *EvidenceObserver) RecordCanonicalCacheHit(ctx context.Context, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.canonicalHitCounter.Add(ctx, 1, e.measurementAttrs(attrs))
+}
+
+// RecordCanonicalCacheMiss records a canonical-form normalization that missed the
+// normalization cache and was computed.
+func (e *EvidenceObserver) RecordCanonicalCacheMiss(ctx context.Context, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.canonicalMissCounter.Add(ctx, 1, e.measurementAttrs(attrs))
+}
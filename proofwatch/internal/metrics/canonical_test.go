@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordCanonicalCache(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		fixture.observer.RecordCanonicalCacheHit(ctx)
+	}
+	fixture.observer.RecordCanonicalCacheMiss(ctx)
+
+	hits := fixture.int64Points(ctx, "normalization_canonical_cache_hit_count")
+	misses := fixture.int64Points(ctx, "normalization_canonical_cache_miss_count")
+	require.Len(t, hits, 1)
+	require.Len(t, misses, 1)
+	assert.Equal(t, int64(3), hits[0].Value)
+	assert.Equal(t, int64(1), misses[0].Value)
+
+	ratio := float64(hits[0].Value) / float64(hits[0].Value+misses[0].Value)
+	assert.InDelta(t, 0.75, ratio, 1e-9)
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// overflowValue replaces attribute values beyond a key's cardinality limit.
+const overflowValue = "__overflow__"
+
+// cardinalityLimiter admits up to max distinct values for an attribute key.
+type cardinalityLimiter struct {
+	mu   sync.Mutex
+	max  int
+	seen map[string]struct{}
+}
+
+// admit returns value when it was already seen or the limit has room for it, and
+// overflowValue otherwise.
+func (l *cardinalityLimiter) admit(value string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.seen[value]; ok {
+		return value
+	}
+	if len(l.seen) >= l.max {
+		return overflowValue
+	}
+	l.seen[value] = struct{}{}
+	return value
+}
+
+// WithCardinalityLimit caps the number of distinct values recorded for key at max.
+// The first max distinct values are recorded as-is; any further value collapses into
+// the "__overflow__" bucket. A non-positive max makes NewEvidenceObserver return an error.
+func WithCardinalityLimit(key string, max int) Option {
+	return func(cfg *observerConfig) {
+		if max <= 0 {
+			cfg.fail(fmt.Errorf("invalid cardinality limit %d for %q: must be positive", max, key))
+			return
+		}
+		if cfg.cardinality == nil {
+			cfg.cardinality = make(map[attribute.Key]*cardinalityLimiter)
+		}
+		cfg.cardinality[attribute.Key(key)] = &cardinalityLimiter{max: max, seen: make(map[string]struct{})}
+	}
+}
+
+// limitCardinality applies the cardinality limit configured for kv's key, if any.
+func (e *EvidenceObserver) limitCardinality(kv attribute.KeyValue) attribute.KeyValue {
+	limiter, ok := e.cfg.cardinality[kv.Key]
+	if !ok {
+		return kv
+	}
+	if admitted := limiter.admit(kv.Value.Emit()); admitted == overflowValue {
+		return kv.Key.String(overflowValue)
+	}
+	return kv
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestWithCardinalityLimit(t *testing.T) {
+	t.Run("collapses values beyond the limit", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithCardinalityLimit("iteration", 3))
+		ctx := context.Background()
+
+		for i := 0; i < 10; i++ {
+			fixture.observer.Processed(ctx, attribute.String("iteration", strconv.Itoa(i)), attribute.String("policy.id", strconv.Itoa(i)))
+		}
+		fixture.observer.Processed(ctx, attribute.String("iteration", "1"), attribute.String("policy.id", "1"))
+
+		points := fixture.int64Points(ctx, "evidence_processed_count")
+		got := sumByAttr(points, "iteration")
+		assert.Equal(t, map[string]int64{"0": 1, "1": 2, "2": 1, overflowValue: 7}, got)
+
+		policies := sumByAttr(points, PolicyIDKey)
+		assert.Len(t, policies, 10, "keys without a limit are not capped")
+	})
+
+	t.Run("rejects non-positive limits", func(t *testing.T) {
+		meter := sdkmetric.NewMeterProvider().Meter("test-meter")
+
+		observer, err := NewEvidenceObserver(meter, WithCardinalityLimit("iteration", 0))
+		require.Error(t, err)
+		assert.Nil(t, observer)
+	})
+}
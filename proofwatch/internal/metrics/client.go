@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ClientBucketKey is the bucketed client attribute. Raw client identifiers are never recorded.
+const ClientBucketKey = attribute.Key("client.bucket")
+
+// defaultClientBuckets is the number of client buckets used when WithClientBuckets is
+// given a non-positive bucket count.
+const defaultClientBuckets = 16
+
+// WithClientBuckets configures how ProcessedByClient buckets client identifiers.
+// Identifiers are keyed-hashed with salt before bucketing, so buckets cannot be
+// reversed to client identities without the salt. The salt must not be empty and
+// should be the same across replicas and restarts, so a client keeps its bucket.
+// Without this option ProcessedByClient does not bucket clients.
+func WithClientBuckets(salt []byte, buckets int) Option {
+	return func(cfg *observerConfig) {
+		if len(salt) == 0 {
+			cfg.fail(errors.New("client bucketing salt must not be empty"))
+			return
+		}
+		if buckets <= 0 {
+			buckets = defaultClientBuckets
+		}
+		cfg.clientSalt = salt
+		cfg.clientBuckets = buckets
+	}
+}
+
+// ProcessedByClient records processed evidence attributed to the bucket of clientID.
+// Without WithClientBuckets the evidence is recorded as processed without a client
+// bucket.
+func (e *EvidenceObserver) ProcessedByClient(ctx context.Context, clientID string, attrs ...attribute.KeyValue) {
+	if len(e.cfg.clientSalt) == 0 {
+		e.Processed(ctx, attrs...)
+		return
+	}
+	e.Processed(ctx, append(attrs[:len(attrs):len(attrs)], ClientBucketKey.String(e.clientBucket(clientID)))...)
+}
+
+// clientBucket maps clientID onto a bucket using HMAC-SHA256 keyed with the configured salt.
+func (e *EvidenceObserver) clientBucket(clientID string) string {
+	mac := hmac.New(sha256.New, e.cfg.clientSalt)
+	_, _ = mac.Write([]byte(clientID))
+	sum := binary.BigEndian.Uint64(mac.Sum(nil))
+	return strconv.FormatUint(sum%uint64(e.cfg.clientBuckets), 10) // #nosec G115 -- buckets is positive
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestProcessedByClient(t *testing.T) {
+	const buckets = 4
+
+	t.Run("buckets without leaking identity", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithClientBuckets([]byte("test-salt"), buckets))
+		ctx := context.Background()
+
+		ids := make(map[string]bool)
+		for i := 0; i < 40; i++ {
+			id := "client-" + strconv.Itoa(i)
+			ids[id] = true
+			fixture.observer.ProcessedByClient(ctx, id)
+		}
+
+		points := fixture.int64Points(ctx, "evidence_processed_count")
+		assert.LessOrEqual(t, len(points), buckets)
+
+		var total int64
+		for _, dp := range points {
+			bucket, ok := attrValue(dp.Attributes, ClientBucketKey)
+			require.True(t, ok)
+			n, err := strconv.Atoi(bucket)
+			require.NoError(t, err)
+			assert.True(t, n >= 0 && n < buckets)
+
+			for _, kv := range dp.Attributes.ToSlice() {
+				assert.False(t, ids[kv.Value.Emit()], "raw client id %q recorded", kv.Value.Emit())
+			}
+			total += dp.Value
+		}
+		assert.Equal(t, int64(40), total)
+	})
+
+	t.Run("same client maps to same bucket", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithClientBuckets([]byte("test-salt"), buckets))
+		assert.Equal(t, fixture.observer.clientBucket("client-1"), fixture.observer.clientBucket("client-1"))
+	})
+
+	t.Run("does not bucket without a salt", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+		fixture.observer.ProcessedByClient(ctx, "client-1")
+
+		points := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, points, 1)
+		_, ok := attrValue(points[0].Attributes, ClientBucketKey)
+		assert.False(t, ok)
+		assert.Equal(t, int64(1), points[0].Value)
+	})
+
+	t.Run("rejects empty salt", func(t *testing.T) {
+		meter := sdkmetric.NewMeterProvider().Meter("test-meter")
+		_, err := NewEvidenceObserver(meter, WithClientBuckets(nil, buckets))
+		assert.Error(t, err)
+	})
+}
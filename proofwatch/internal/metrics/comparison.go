@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// WithComparisonMeter duplicates processed and dropped recordings into a secondary
+// meter so two differently-configured meter providers can be compared side by side.
+func WithComparisonMeter(meter metric.Meter) Option {
+	return func(cfg *observerConfig) {
+		cfg.comparisonMeter = meter
+	}
+}
+
+// comparisonCounters mirror the primary evidence counters on the comparison meter.
+type comparisonCounters struct {
+	dropped   metric.Int64Counter
+	processed metric.Int64Counter
+}
+
+func (e *EvidenceObserver) initComparison(_ metric.Meter) error {
+	meter := withNamePrefix(e.cfg.comparisonMeter, e.cfg.namePrefix)
+	if meter == nil {
+		return nil
+	}
+
+	var err error
+	e.comparison = &comparisonCounters{}
+	e.comparison.dropped, err = meter.Int64Counter(
+		"evidence_dropped_count",
+		metric.WithDescription("The total number of evidence items dropped due to processing failures."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create comparison dropped counter: %w", err)
+	}
+
+	e.comparison.processed, err = meter.Int64Counter(
+		"evidence_processed_count",
+		metric.WithDescription("The total number of evidence items processed successfully."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create comparison processed counter: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestWithComparisonMeter(t *testing.T) {
+	secondaryReader := sdkmetric.NewManualReader()
+	secondary := sdkmetric.NewMeterProvider(sdkmetric.WithReader(secondaryReader))
+	t.Cleanup(func() { _ = secondary.Shutdown(context.Background()) })
+
+	fixture := setupEvidenceObserverTest(t, WithComparisonMeter(secondary.Meter("comparison-meter")))
+	ctx := context.Background()
+
+	fixture.observer.Processed(ctx, attribute.String("policy.id", "policy-1"))
+	fixture.observer.Dropped(ctx, attribute.String("reason", "timeout"))
+
+	primary := fixture.int64Points(ctx, "evidence_processed_count")
+	require.Len(t, primary, 1)
+	assert.Equal(t, int64(1), primary[0].Value)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, secondaryReader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	got := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		for _, dp := range sum.DataPoints {
+			got[m.Name] += dp.Value
+		}
+	}
+	assert.Equal(t, map[string]int64{"evidence_processed_count": 1, "evidence_dropped_count": 1}, got)
+}
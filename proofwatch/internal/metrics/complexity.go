@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// complexityBuckets cover rule complexity scores from trivial to very complex rules.
+var complexityBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500}
+
+func (e *EvidenceObserver) initRuleComplexity(meter metric.Meter) error {
+	var err error
+	e.ruleComplexity, err = meter.Int64Histogram(
+		"rule_complexity",
+		metric.WithDescription("The complexity score of the rules evaluated against evidence."),
+		metric.WithExplicitBucketBoundaries(complexityBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create rule complexity histogram: %w", err)
+	}
+	return nil
+}
+
+// RecordRuleComplexity records the complexity score of an evaluated rule. A negative
+// score is recorded as zero.
+func (e *EvidenceObserver) RecordRuleComplexity(ctx context.Context, score int, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.ruleComplexity.Record(ctx, int64(max(score, 0)), e.measurementAttrs(attrs))
+}
+
+// ObserveDurationWithComplexity records the evaluation duration and rule complexity
+// score of a single evaluation with the same attributes, so the two can be correlated.
+func (e *EvidenceObserver) ObserveDurationWithComplexity(ctx context.Context, d time.Duration, score int, attrs ...attribute.KeyValue) {
+	e.ObserveDuration(ctx, d, attrs...)
+	e.RecordRuleComplexity(ctx, score, attrs...)
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestRecordRuleComplexity(t *testing.T) {
+	t.Run("buckets scores", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		for _, score := range []int{1, 3, 7, 7, 150, 1000, -4} {
+			fixture.observer.RecordRuleComplexity(ctx, score)
+		}
+
+		points := fixture.int64Histogram(ctx, "rule_complexity")
+		require.Len(t, points, 1)
+		// Buckets: (-inf,1] (1,2] (2,5] (5,10] (10,20] (20,50] (50,100] (100,200] (200,500] (500,+inf)
+		assert.Equal(t, []uint64{2, 0, 1, 2, 0, 0, 0, 1, 0, 1}, points[0].BucketCounts)
+	})
+
+	t.Run("shares attributes with duration", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+		policy := PolicyIDKey.String("AC-2")
+
+		fixture.observer.ObserveDurationWithComplexity(ctx, 20*time.Millisecond, 12, policy)
+
+		complexity := fixture.int64Histogram(ctx, "rule_complexity")
+		require.Len(t, complexity, 1)
+		assert.Equal(t, attribute.NewSet(policy), complexity[0].Attributes)
+
+		durations := fixture.float64Histogram(ctx, "evidence_processing_duration_seconds")
+		require.Len(t, durations, 1)
+		got, ok := attrValue(durations[0].Attributes, PolicyIDKey)
+		assert.True(t, ok)
+		assert.Equal(t, "AC-2", got)
+	})
+}
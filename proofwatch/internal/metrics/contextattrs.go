@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// contextAttributesKey is the context key of the attributes set with
+// ContextWithAttributes.
+type contextAttributesKey struct{}
+
+// ContextWithAttributes returns a copy of ctx carrying attrs, such as the tenant or
+// request, which Processed and Dropped add to every recording made with the returned
+// context. Attributes already carried by ctx are kept unless attrs sets the same key.
+// Attributes given at the call site take precedence over those carried by ctx.
+func ContextWithAttributes(ctx context.Context, attrs ...attribute.KeyValue) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, contextAttributesKey{}, mergeAttributes(attributesFromContext(ctx), attrs))
+}
+
+// attributesFromContext returns the attributes set on ctx with ContextWithAttributes.
+func attributesFromContext(ctx context.Context) []attribute.KeyValue {
+	attrs, _ := ctx.Value(contextAttributesKey{}).([]attribute.KeyValue)
+	return attrs
+}
+
+// withContextAttributes returns the attributes carried by ctx merged with the call-site
+// attrs, which win on key conflicts. attrs is returned as is when ctx carries none.
+func withContextAttributes(ctx context.Context, attrs []attribute.KeyValue) []attribute.KeyValue {
+	scoped := attributesFromContext(ctx)
+	if len(scoped) == 0 {
+		return attrs
+	}
+	return mergeAttributes(scoped, attrs)
+}
+
+// mergeAttributes returns base followed by override, dropping the attributes of base
+// whose key override sets. Neither input slice is modified.
+func mergeAttributes(base, override []attribute.KeyValue) []attribute.KeyValue {
+	overridden := make(map[attribute.Key]bool, len(override))
+	for _, kv := range override {
+		overridden[kv.Key] = true
+	}
+	out := make([]attribute.KeyValue, 0, len(base)+len(override))
+	for _, kv := range base {
+		if !overridden[kv.Key] {
+			out = append(out, kv)
+		}
+	}
+	return append(out, override...)
+}
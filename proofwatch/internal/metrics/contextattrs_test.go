@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestContextWithAttributes(t *testing.T) {
+	t.Run("merges context attributes into recordings", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := ContextWithAttributes(context.Background(), attribute.String("tenant", "acme"))
+
+		fixture.observer.Processed(ctx, attribute.String("policy.id", "p-1"))
+		fixture.observer.Dropped(ctx)
+
+		processed := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		tenant, _ := attrValue(processed[0].Attributes, "tenant")
+		policyID, _ := attrValue(processed[0].Attributes, "policy.id")
+		assert.Equal(t, "acme", tenant)
+		assert.Equal(t, "p-1", policyID)
+
+		dropped := fixture.int64Points(ctx, "evidence_dropped_count")
+		require.Len(t, dropped, 1)
+		tenant, _ = attrValue(dropped[0].Attributes, "tenant")
+		assert.Equal(t, "acme", tenant)
+	})
+
+	t.Run("call-site attributes win on conflict", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := ContextWithAttributes(context.Background(), attribute.String("tenant", "acme"), attribute.String("region", "eu"))
+
+		fixture.observer.Processed(ctx, attribute.String("tenant", "globex"))
+
+		processed := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		tenant, _ := attrValue(processed[0].Attributes, "tenant")
+		region, _ := attrValue(processed[0].Attributes, "region")
+		assert.Equal(t, "globex", tenant)
+		assert.Equal(t, "eu", region)
+	})
+
+	t.Run("nested contexts override outer attributes", func(t *testing.T) {
+		outer := ContextWithAttributes(context.Background(), attribute.String("tenant", "acme"), attribute.String("region", "eu"))
+		inner := ContextWithAttributes(outer, attribute.String("tenant", "globex"))
+
+		assert.Equal(t, []attribute.KeyValue{attribute.String("region", "eu"), attribute.String("tenant", "globex")}, attributesFromContext(inner))
+		assert.Equal(t, []attribute.KeyValue{attribute.String("tenant", "acme"), attribute.String("region", "eu")}, attributesFromContext(outer))
+	})
+
+	t.Run("no attributes returns ctx unchanged", func(t *testing.T) {
+		ctx := context.Background()
+		assert.Equal(t, ctx, ContextWithAttributes(ctx))
+	})
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ControlCharPolicy decides how Processed and Dropped handle string attribute values
+// containing control characters, which make for unreadable label values.
+type ControlCharPolicy int
+
+const (
+	// ControlCharsSanitize removes control characters from attribute values. It is the default.
+	ControlCharsSanitize ControlCharPolicy = iota
+	// ControlCharsReject skips the recording and counts it in
+	// evidence_rejected_attributes_count instead.
+	ControlCharsReject
+)
+
+// WithControlCharPolicy sets how attribute values containing control characters are
+// handled by Processed and Dropped.
+func WithControlCharPolicy(policy ControlCharPolicy) Option {
+	return func(cfg *observerConfig) {
+		cfg.controlChars = policy
+	}
+}
+
+func (e *EvidenceObserver) initControlChars(meter metric.Meter) error {
+	var err error
+	e.rejectedAttrsCounter, err = meter.Int64Counter(
+		"evidence_rejected_attributes_count",
+		metric.WithDescription("The total number of recordings skipped because an attribute value contained control characters."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create rejected attributes counter: %w", err)
+	}
+	return nil
+}
+
+// checkControlChars applies the control character policy to attrs. It returns the
+// attributes to record, copying attrs only when a value had to be sanitized, and
+// false when the recording must be skipped.
+func (e *EvidenceObserver) checkControlChars(ctx context.Context, attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	out, copied := attrs, false
+	for i, kv := range attrs {
+		if kv.Value.Type() != attribute.STRING || !strings.ContainsFunc(kv.Value.AsString(), unicode.IsControl) {
+			continue
+		}
+		if e.cfg.controlChars == ControlCharsReject {
+			e.rejectedAttrsCounter.Add(ctx, 1, metric.WithAttributes(e.cfg.static...))
+			return nil, false
+		}
+		if !copied {
+			out, copied = append([]attribute.KeyValue(nil), attrs...), true
+		}
+		out[i] = kv.Key.String(strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, kv.Value.AsString()))
+	}
+	return out, true
+}
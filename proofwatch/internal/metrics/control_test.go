@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestControlCharPolicy(t *testing.T) {
+	t.Run("sanitizes by default", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+		attrs := []attribute.KeyValue{attribute.String("iteration", "\x01run\n1"), attribute.Int("count", 1)}
+
+		fixture.observer.Processed(ctx, attrs...)
+		fixture.observer.Dropped(ctx, attribute.String("iteration", "\x00run1"))
+
+		for _, name := range []string{"evidence_processed_count", "evidence_dropped_count"} {
+			points := fixture.int64Points(ctx, name)
+			require.Len(t, points, 1)
+			got, _ := attrValue(points[0].Attributes, "iteration")
+			assert.Equal(t, "run1", got, name)
+		}
+		assert.Equal(t, "\x01run\n1", attrs[0].Value.AsString(), "caller attributes are not modified")
+	})
+
+	t.Run("rejects when configured", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithControlCharPolicy(ControlCharsReject))
+		ctx := context.Background()
+
+		fixture.observer.Processed(ctx, attribute.String("iteration", "\x07"))
+		fixture.observer.Dropped(ctx, attribute.String("iteration", "\x1b[31m"))
+		fixture.observer.Processed(ctx, attribute.String("iteration", "1"))
+
+		processed := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		assert.Equal(t, int64(1), processed[0].Value)
+		assert.False(t, fixture.collected(ctx, "evidence_dropped_count"))
+
+		rejected := fixture.int64Points(ctx, "evidence_rejected_attributes_count")
+		require.Len(t, rejected, 1)
+		assert.Equal(t, int64(2), rejected[0].Value)
+	})
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// CustodyIntactKey records whether the chain of custody was verified intact.
+const CustodyIntactKey = attribute.Key("custody.intact")
+
+func (e *EvidenceObserver) initCustody(meter metric.Meter) error {
+	var err error
+	e.custodyCounter, err = meter.Int64Counter(
+		"evidence_custody_verification_count",
+		metric.WithDescription("The total number of chain-of-custody verifications, by whether the chain was intact."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create custody verification counter: %w", err)
+	}
+	return nil
+}
+
+// RecordChainOfCustody records the outcome of verifying an evidence item's chain of
+// custody. A broken chain also drops the evidence with DropReasonCustodyBroken.
+func (e *EvidenceObserver) RecordChainOfCustody(ctx context.Context, intact bool, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.custodyCounter.Add(ctx, 1, e.measurementAttrs(attrs, CustodyIntactKey.Bool(intact)))
+	if !intact {
+		e.droppedWithReason(ctx, DropReasonCustodyBroken, attrs)
+	}
+}
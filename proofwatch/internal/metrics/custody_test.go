@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordChainOfCustody(t *testing.T) {
+	t.Run("intact chain", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		fixture.observer.RecordChainOfCustody(ctx, true)
+
+		got := sumByAttr(fixture.int64Points(ctx, "evidence_custody_verification_count"), CustodyIntactKey)
+		assert.Equal(t, map[string]int64{"true": 1}, got)
+
+		rm := fixture.collectMetrics(ctx)
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			assert.NotEqual(t, "evidence_dropped_count", m.Name)
+		}
+	})
+
+	t.Run("broken chain drops evidence", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		fixture.observer.RecordChainOfCustody(ctx, false)
+
+		got := sumByAttr(fixture.int64Points(ctx, "evidence_custody_verification_count"), CustodyIntactKey)
+		assert.Equal(t, map[string]int64{"false": 1}, got)
+
+		dropped := fixture.int64Points(ctx, "evidence_dropped_count")
+		require.Len(t, dropped, 1)
+		reason, _ := attrValue(dropped[0].Attributes, DropReasonKey)
+		assert.Equal(t, string(DropReasonCustodyBroken), reason)
+	})
+}
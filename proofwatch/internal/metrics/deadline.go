@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func (e *EvidenceObserver) initDeadlineBudget(meter metric.Meter) error {
+	var err error
+	e.deadlineBudget, err = meter.Float64Histogram(
+		"evidence_deadline_budget_remaining_seconds",
+		metric.WithDescription("The deadline budget left when evidence processing completed."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create deadline budget histogram: %w", err)
+	}
+
+	e.deadlineOverrunCounter, err = meter.Int64Counter(
+		"evidence_deadline_overrun_count",
+		metric.WithDescription("The total number of evidence items whose processing completed after its deadline."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create deadline overrun counter: %w", err)
+	}
+	return nil
+}
+
+// RecordDeadlineBudget records the deadline budget remaining when processing
+// completed. A negative remaining budget is an overrun: it is recorded as zero in
+// the budget histogram and counted in evidence_deadline_overrun_count.
+func (e *EvidenceObserver) RecordDeadlineBudget(ctx context.Context, remaining time.Duration, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	opt := e.measurementAttrs(attrs)
+	if remaining < 0 {
+		e.deadlineOverrunCounter.Add(ctx, 1, opt)
+	}
+	e.deadlineBudget.Record(ctx, max(remaining, 0).Seconds(), opt)
+}
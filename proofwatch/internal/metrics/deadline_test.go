@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordDeadlineBudget(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordDeadlineBudget(ctx, 2*time.Second)
+	fixture.observer.RecordDeadlineBudget(ctx, 3*time.Millisecond)
+	fixture.observer.RecordDeadlineBudget(ctx, -50*time.Millisecond)
+
+	points := fixture.float64Histogram(ctx, "evidence_deadline_budget_remaining_seconds")
+	require.Len(t, points, 1)
+	assert.Equal(t, uint64(3), points[0].Count)
+	assert.InDelta(t, 2.003, points[0].Sum, 1e-9)
+	// Buckets: (-inf,0.001] (0.001,0.0025] (0.0025,0.005] ... (1,2.5] ...
+	assert.Equal(t, uint64(1), points[0].BucketCounts[0])
+	assert.Equal(t, uint64(1), points[0].BucketCounts[2])
+	assert.Equal(t, uint64(1), points[0].BucketCounts[10])
+
+	overruns := fixture.int64Points(ctx, "evidence_deadline_overrun_count")
+	require.Len(t, overruns, 1)
+	assert.Equal(t, int64(1), overruns[0].Value)
+}
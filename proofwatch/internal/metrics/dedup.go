@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DedupStrategy is the deduplication strategy used to detect duplicate evidence.
+type DedupStrategy string
+
+const (
+	DedupStrategyContentHash DedupStrategy = "content_hash"
+	DedupStrategyID          DedupStrategy = "id"
+	DedupStrategySemantic    DedupStrategy = "semantic"
+	DedupStrategyNone        DedupStrategy = "none"
+)
+
+// DedupStrategyKey is the bounded deduplication strategy attribute.
+const DedupStrategyKey = attribute.Key("dedup.strategy")
+
+var dedupStrategies = newBoundedSet(
+	string(DedupStrategyContentHash),
+	string(DedupStrategyID),
+	string(DedupStrategySemantic),
+	string(DedupStrategyNone),
+)
+
+func (e *EvidenceObserver) initDedup(meter metric.Meter) error {
+	var err error
+	e.dedupHitCounter, err = meter.Int64Counter(
+		"evidence_dedup_hit_count",
+		metric.WithDescription("The total number of duplicate evidence items detected at ingestion, by deduplication strategy."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create dedup hit counter: %w", err)
+	}
+
+	e.dedupCacheEntries, err = meter.Int64Gauge(
+		"evidence_dedup_cache_entries",
+		metric.WithDescription("The number of evidence keys held in the deduplication cache, by deduplication strategy."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create dedup cache gauge: %w", err)
+	}
+	return nil
+}
+
+// DedupHit records a duplicate evidence item detected by strategy. Strategies outside
+// the known set are recorded as "unknown".
+func (e *EvidenceObserver) DedupHit(ctx context.Context, strategy DedupStrategy, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.dedupHitCounter.Add(ctx, 1, e.measurementAttrs(attrs,
+		DedupStrategyKey.String(dedupStrategies.normalize(string(strategy), unknownValue)),
+	))
+}
+
+// RecordDedupCacheEntries records the number of keys held in the deduplication cache
+// used by strategy.
+func (e *EvidenceObserver) RecordDedupCacheEntries(ctx context.Context, strategy DedupStrategy, entries int, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.dedupCacheEntries.Record(ctx, int64(entries), e.measurementAttrs(attrs,
+		DedupStrategyKey.String(dedupStrategies.normalize(string(strategy), unknownValue)),
+	))
+}
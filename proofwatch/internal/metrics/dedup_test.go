@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupHit(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.DedupHit(ctx, DedupStrategyContentHash)
+	fixture.observer.DedupHit(ctx, DedupStrategyContentHash)
+	fixture.observer.DedupHit(ctx, DedupStrategyID)
+	fixture.observer.DedupHit(ctx, DedupStrategySemantic)
+	fixture.observer.DedupHit(ctx, "bloom")
+
+	got := sumByAttr(fixture.int64Points(ctx, "evidence_dedup_hit_count"), DedupStrategyKey)
+	assert.Equal(t, map[string]int64{"content_hash": 2, "id": 1, "semantic": 1, "unknown": 1}, got)
+}
+
+func TestRecordDedupCacheEntries(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordDedupCacheEntries(ctx, DedupStrategyContentHash, 3)
+	fixture.observer.RecordDedupCacheEntries(ctx, DedupStrategyContentHash, 2)
+	fixture.observer.RecordDedupCacheEntries(ctx, DedupStrategyID, 7)
+
+	got := map[string]int64{}
+	for _, dp := range fixture.int64Gauge(ctx, "evidence_dedup_cache_entries") {
+		strategy, _ := attrValue(dp.Attributes, DedupStrategyKey)
+		got[strategy] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{"content_hash": 2, "id": 7}, got)
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DegradedKey records the mode entered by a degraded-mode transition.
+const DegradedKey = attribute.Key("degraded")
+
+// WithDegradedMode registers the evaluation_degraded_mode gauge, reporting whether
+// evaluation currently runs in degraded mode as set with SetDegraded. Without it only
+// the mode transitions are counted.
+func WithDegradedMode() Option {
+	return func(cfg *observerConfig) {
+		cfg.degradedMode = true
+	}
+}
+
+func (e *EvidenceObserver) initDegraded(meter metric.Meter) error {
+	var err error
+	e.degradedTransitions, err = meter.Int64Counter(
+		"evaluation_degraded_mode_transitions_count",
+		metric.WithDescription("The total number of transitions into and out of degraded mode, by the mode entered."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create degraded mode transitions counter: %w", err)
+	}
+	if !e.cfg.degradedMode {
+		return nil
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		"evaluation_degraded_mode",
+		metric.WithDescription("Whether evaluation is running in degraded mode with reduced checks (1) or not (0)."),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			var v int64
+			if e.degraded.Load() {
+				v = 1
+			}
+			o.Observe(v, metric.WithAttributes(e.cfg.static...))
+			return nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create degraded mode gauge: %w", err)
+	}
+	return nil
+}
+
+// SetDegraded sets whether evaluation is running in degraded mode. Only changes of
+// mode are counted as transitions; setting the current mode again is a no-op.
+func (e *EvidenceObserver) SetDegraded(ctx context.Context, degraded bool) {
+	if !e.recording() {
+		return
+	}
+	if e.degraded.Swap(degraded) == degraded {
+		return
+	}
+	e.degradedTransitions.Add(ctx, 1, e.measurementAttrs(nil, DegradedKey.Bool(degraded)))
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDegraded(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithDegradedMode())
+	ctx := context.Background()
+
+	gauge := func() int64 {
+		points := fixture.int64Gauge(ctx, "evaluation_degraded_mode")
+		require.Len(t, points, 1)
+		return points[0].Value
+	}
+
+	assert.Equal(t, int64(0), gauge())
+
+	fixture.observer.SetDegraded(ctx, true)
+	assert.Equal(t, int64(1), gauge())
+
+	fixture.observer.SetDegraded(ctx, true)
+	fixture.observer.SetDegraded(ctx, false)
+	assert.Equal(t, int64(0), gauge())
+
+	fixture.observer.SetDegraded(ctx, true)
+	assert.Equal(t, int64(1), gauge())
+
+	transitions := sumByAttr(fixture.int64Points(ctx, "evaluation_degraded_mode_transitions_count"), DegradedKey)
+	assert.Equal(t, map[string]int64{"true": 2, "false": 1}, transitions)
+}
+
+func TestSetDegradedWithoutDegradedMode(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	assert.Empty(t, fixture.collectMetrics(ctx).ScopeMetrics)
+
+	fixture.observer.SetDegraded(ctx, true)
+	assert.False(t, fixture.collected(ctx, "evaluation_degraded_mode"))
+	transitions := sumByAttr(fixture.int64Points(ctx, "evaluation_degraded_mode_transitions_count"), DegradedKey)
+	assert.Equal(t, map[string]int64{"true": 1}, transitions)
+}
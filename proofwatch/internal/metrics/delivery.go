@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DeliveryGuarantee is the delivery guarantee offered by an evidence source.
+type DeliveryGuarantee string
+
+const (
+	DeliveryAtMostOnce  DeliveryGuarantee = "at-most-once"
+	DeliveryAtLeastOnce DeliveryGuarantee = "at-least-once"
+	DeliveryExactlyOnce DeliveryGuarantee = "exactly-once"
+)
+
+// DeliveryGuaranteeKey is the bounded delivery guarantee attribute.
+const DeliveryGuaranteeKey = attribute.Key("delivery.guarantee")
+
+var deliveryGuarantees = newBoundedSet(
+	string(DeliveryAtMostOnce),
+	string(DeliveryAtLeastOnce),
+	string(DeliveryExactlyOnce),
+)
+
+// WithDeliveryGuarantee tags every recording with the delivery guarantee of the
+// evidence source. Levels are matched case-insensitively; levels outside the known
+// set are recorded as "unknown".
+func WithDeliveryGuarantee(level DeliveryGuarantee) Option {
+	return func(cfg *observerConfig) {
+		normalized := strings.ToLower(strings.TrimSpace(string(level)))
+		cfg.addStatic(DeliveryGuaranteeKey.String(deliveryGuarantees.normalize(normalized, unknownValue)))
+	}
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDeliveryGuarantee(t *testing.T) {
+	tests := []struct {
+		level DeliveryGuarantee
+		want  string
+	}{
+		{DeliveryAtMostOnce, "at-most-once"},
+		{DeliveryAtLeastOnce, "at-least-once"},
+		{"Exactly-Once", "exactly-once"},
+		{"best-effort", "unknown"},
+		{"", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.level), func(t *testing.T) {
+			fixture := setupEvidenceObserverTest(t, WithDeliveryGuarantee(tt.level))
+			ctx := context.Background()
+
+			fixture.observer.Processed(ctx)
+
+			points := fixture.int64Points(ctx, "evidence_processed_count")
+			require.Len(t, points, 1)
+			got, ok := attrValue(points[0].Attributes, DeliveryGuaranteeKey)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
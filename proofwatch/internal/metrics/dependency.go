@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DependencyResolvedKey records whether a policy's data dependencies were resolved.
+const DependencyResolvedKey = attribute.Key("dependency.resolved")
+
+func (e *EvidenceObserver) initDependency(meter metric.Meter) error {
+	var err error
+	e.dependencyCounter, err = meter.Int64Counter(
+		"policy_dependency_resolution_count",
+		metric.WithDescription("The total number of policy dependency resolutions, by whether they resolved."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create dependency resolution counter: %w", err)
+	}
+	return nil
+}
+
+// RecordDependencyResolution records the outcome of resolving the data a policy
+// depends on from other policies. An unresolved dependency also drops the evidence
+// with DropReasonDependencyUnresolved.
+func (e *EvidenceObserver) RecordDependencyResolution(ctx context.Context, resolved bool, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.dependencyCounter.Add(ctx, 1, e.measurementAttrs(attrs, DependencyResolvedKey.Bool(resolved)))
+	if !resolved {
+		e.droppedWithReason(ctx, DropReasonDependencyUnresolved, attrs)
+	}
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordDependencyResolution(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordDependencyResolution(ctx, true, PolicyIDKey.String("policy-1"))
+	fixture.observer.RecordDependencyResolution(ctx, true, PolicyIDKey.String("policy-1"))
+	fixture.observer.RecordDependencyResolution(ctx, false, PolicyIDKey.String("policy-2"))
+
+	got := sumByAttr(fixture.int64Points(ctx, "policy_dependency_resolution_count"), DependencyResolvedKey)
+	assert.Equal(t, map[string]int64{"true": 2, "false": 1}, got)
+
+	dropped := fixture.int64Points(ctx, "evidence_dropped_count")
+	require.Len(t, dropped, 1)
+	reason, _ := attrValue(dropped[0].Attributes, DropReasonKey)
+	assert.Equal(t, string(DropReasonDependencyUnresolved), reason)
+	policy, _ := attrValue(dropped[0].Attributes, PolicyIDKey)
+	assert.Equal(t, "policy-2", policy)
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DriftedKey records whether evidence drifted from its baseline.
+const DriftedKey = attribute.Key("drifted")
+
+// driftMagnitudeBuckets suit relative drift magnitudes, where 1 means a 100% deviation.
+var driftMagnitudeBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10}
+
+func (e *EvidenceObserver) initDrift(meter metric.Meter) error {
+	var err error
+	e.driftCounter, err = meter.Int64Counter(
+		"evidence_drift_count",
+		metric.WithDescription("The total number of baseline comparisons, by whether drift was detected."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create drift counter: %w", err)
+	}
+
+	e.driftMagnitude, err = meter.Float64Histogram(
+		"evidence_drift_magnitude",
+		metric.WithDescription("The magnitude of detected drift from the baseline."),
+		metric.WithExplicitBucketBoundaries(driftMagnitudeBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create drift magnitude histogram: %w", err)
+	}
+	return nil
+}
+
+// RecordDrift records the outcome of comparing evidence to its baseline.
+func (e *EvidenceObserver) RecordDrift(ctx context.Context, drifted bool, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.driftCounter.Add(ctx, 1, e.measurementAttrs(attrs, DriftedKey.Bool(drifted)))
+}
+
+// RecordDriftMagnitude records how far drifted evidence deviates from its baseline.
+// The sign of magnitude is ignored.
+func (e *EvidenceObserver) RecordDriftMagnitude(ctx context.Context, magnitude float64, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.driftMagnitude.Record(ctx, math.Abs(magnitude), e.measurementAttrs(attrs))
+}
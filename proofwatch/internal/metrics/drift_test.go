@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordDrift(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordDrift(ctx, false)
+	fixture.observer.RecordDrift(ctx, false)
+	fixture.observer.RecordDrift(ctx, true)
+	fixture.observer.RecordDriftMagnitude(ctx, 0.03)
+	fixture.observer.RecordDriftMagnitude(ctx, -0.3)
+
+	got := sumByAttr(fixture.int64Points(ctx, "evidence_drift_count"), DriftedKey)
+	assert.Equal(t, map[string]int64{"true": 1, "false": 2}, got)
+
+	hist := fixture.float64Histogram(ctx, "evidence_drift_magnitude")
+	require.Len(t, hist, 1)
+	assert.Equal(t, uint64(2), hist[0].Count)
+	assert.InDelta(t, 0.33, hist[0].Sum, 1e-9)
+	// 0.03 falls in (0.01,0.05], 0.3 in (0.25,0.5].
+	assert.Equal(t, uint64(1), hist[0].BucketCounts[1])
+	assert.Equal(t, uint64(1), hist[0].BucketCounts[4])
+}
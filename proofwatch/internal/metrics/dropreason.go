@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DropReason describes why an evidence item was dropped.
+type DropReason string
+
+const (
+	DropReasonValidationFailed     DropReason = "validation_failed"
+	DropReasonProcessingError      DropReason = "processing_error"
+	DropReasonTimeout              DropReason = "timeout"
+	DropReasonUnknown              DropReason = "unknown"
+	DropReasonPlaintextTransport   DropReason = "plaintext_transport"
+	DropReasonBundleTimeout        DropReason = "bundle_timeout"
+	DropReasonCustodyBroken        DropReason = "custody_broken"
+	DropReasonDependencyUnresolved DropReason = "dependency_unresolved"
+	DropReasonResourceCeiling      DropReason = "resource_ceiling"
+	DropReasonRevokedKey           DropReason = "revoked_key"
+	DropReasonQueueFull            DropReason = "queue_full"
+	DropReasonDuplicate            DropReason = "duplicate"
+	DropReasonProvenanceInvalid    DropReason = "provenance_invalid"
+	DropReasonRateLimited          DropReason = "rate_limited"
+	DropReasonBackpressure         DropReason = "backpressure"
+	DropReasonExpired              DropReason = "expired"
+)
+
+// DropReasonKey is the attribute carrying the DropReason on dropped evidence.
+const DropReasonKey = attribute.Key("reason")
+
+var dropReasons = newBoundedSet(
+	string(DropReasonValidationFailed),
+	string(DropReasonProcessingError),
+	string(DropReasonTimeout),
+	string(DropReasonUnknown),
+	string(DropReasonPlaintextTransport),
+	string(DropReasonBundleTimeout),
+	string(DropReasonCustodyBroken),
+	string(DropReasonDependencyUnresolved),
+	string(DropReasonResourceCeiling),
+	string(DropReasonRevokedKey),
+	string(DropReasonQueueFull),
+	string(DropReasonDuplicate),
+	string(DropReasonProvenanceInvalid),
+	string(DropReasonRateLimited),
+	string(DropReasonBackpressure),
+	string(DropReasonExpired),
+)
+
+// attribute returns the reason attribute, recording reasons outside the known set,
+// including the empty reason, as DropReasonUnknown.
+func (r DropReason) attribute() attribute.KeyValue {
+	return DropReasonKey.String(dropReasons.normalize(string(r), string(DropReasonUnknown)))
+}
+
+// DroppedWithReason records a dropped evidence item with the reason attribute set to
+// reason. Unknown and empty reasons are recorded as DropReasonUnknown.
+func (e *EvidenceObserver) DroppedWithReason(ctx context.Context, reason DropReason, attrs ...attribute.KeyValue) {
+	e.Dropped(ctx, append(attrs[:len(attrs):len(attrs)], reason.attribute())...)
+}
+
+// droppedWithReason records a dropped evidence item with the reason attribute set to
+// reason, after the method-specific extra attributes.
+func (e *EvidenceObserver) droppedWithReason(ctx context.Context, reason DropReason, attrs []attribute.KeyValue, extra ...attribute.KeyValue) {
+	e.DroppedWithReason(ctx, reason, append(attrs[:len(attrs):len(attrs)], extra...)...)
+}
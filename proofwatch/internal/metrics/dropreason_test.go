@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestDroppedWithReason(t *testing.T) {
+	tests := []struct {
+		reason DropReason
+		want   string
+	}{
+		{DropReasonValidationFailed, "validation_failed"},
+		{DropReasonProcessingError, "processing_error"},
+		{DropReasonTimeout, "timeout"},
+		{DropReasonUnknown, "unknown"},
+		{"valdiation_failed", "unknown"},
+		{"", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.reason), func(t *testing.T) {
+			fixture := setupEvidenceObserverTest(t)
+			ctx := context.Background()
+
+			fixture.observer.DroppedWithReason(ctx, tt.reason, attribute.String("policy.id", "AC-1"))
+
+			points := fixture.int64Points(ctx, "evidence_dropped_count")
+			require.Len(t, points, 1)
+			reason, ok := attrValue(points[0].Attributes, DropReasonKey)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, reason)
+			policy, _ := attrValue(points[0].Attributes, PolicyIDKey)
+			assert.Equal(t, "AC-1", policy)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// ColdStartKey marks the first evaluation of a newly loaded policy bundle version.
+const ColdStartKey = attribute.Key("cold_start")
+
+// durationBuckets are tuned for sub-second policy evaluation, in seconds.
+var durationBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// exponentialDurationMaxSize and exponentialDurationMaxScale are the SDK defaults for
+// base-2 exponential histograms; the scale is lowered automatically to fit the range of
+// recorded durations into MaxSize buckets.
+const (
+	exponentialDurationMaxSize  = 160
+	exponentialDurationMaxScale = 20
+)
+
+// coldStarts tracks which bundle versions have been evaluated since they were loaded.
+type coldStarts struct {
+	mu   sync.Mutex
+	warm map[string]bool
+}
+
+// observe reports whether this is the first evaluation of version since it was loaded.
+func (c *coldStarts) observe(version string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.warm[version] {
+		return false
+	}
+	c.warm[version] = true
+	return true
+}
+
+func (c *coldStarts) reset(version string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.warm, version)
+}
+
+// WithDurationHistogram sets whether the evidence_processing_duration_seconds
+// histogram is registered. It is registered by default; when disabled, ObserveDuration
+// still feeds the compliance summary.
+func WithDurationHistogram(enabled bool) Option {
+	return func(cfg *observerConfig) {
+		cfg.noDuration = !enabled
+	}
+}
+
+// ExponentialDurationView returns a View aggregating the
+// evidence_processing_duration_seconds histogram, with or without a name prefix, as a
+// base-2 exponential histogram instead of over the explicit duration buckets. Pass it to
+// the MeterProvider the observer's meter is obtained from, for example with
+// sdkmetric.WithView, to get good resolution without tuning buckets per deployment.
+func ExponentialDurationView() sdkmetric.View {
+	return sdkmetric.NewView(
+		sdkmetric.Instrument{Name: "*evidence_processing_duration_seconds", Kind: sdkmetric.InstrumentKindHistogram},
+		sdkmetric.Stream{Aggregation: sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  exponentialDurationMaxSize,
+			MaxScale: exponentialDurationMaxScale,
+		}},
+	)
+}
+
+func (e *EvidenceObserver) initDuration(meter metric.Meter) error {
+	e.coldStarts = &coldStarts{warm: make(map[string]bool)}
+	if e.cfg.noDuration {
+		e.processingDuration = noop.Float64Histogram{}
+		return nil
+	}
+
+	var err error
+	e.processingDuration, err = meter.Float64Histogram(
+		"evidence_processing_duration_seconds",
+		metric.WithDescription("The time taken to evaluate an evidence item."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create processing duration histogram: %w", err)
+	}
+	return nil
+}
+
+// BundleLoaded marks version as newly loaded, so its next evaluation is tagged as a cold start.
+func (e *EvidenceObserver) BundleLoaded(version string) {
+	e.coldStarts.reset(version)
+}
+
+// ObserveDuration records the time taken to evaluate an evidence item. When attrs
+// carry a policy bundle version, the first evaluation of that version since it was
+// loaded is tagged cold_start=true; all other evaluations are tagged cold_start=false.
+func (e *EvidenceObserver) ObserveDuration(ctx context.Context, d time.Duration, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	cold := false
+	for _, kv := range attrs {
+		if kv.Key == BundleVersionKey {
+			cold = e.coldStarts.observe(kv.Value.Emit())
+			break
+		}
+	}
+	d = max(d, 0)
+	e.summary.duration(d)
+	e.processingDuration.Record(ctx, d.Seconds(), e.measurementAttrs(attrs, ColdStartKey.Bool(cold)))
+}
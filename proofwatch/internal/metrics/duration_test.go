@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestObserveDurationColdStart(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithAllowedValues(BundleVersionKey, "v1"))
+	ctx := context.Background()
+	bundle := BundleVersionKey.String("v1")
+
+	fixture.observer.ObserveDuration(ctx, 400*time.Millisecond, bundle)
+	fixture.observer.ObserveDuration(ctx, 20*time.Millisecond, bundle)
+	fixture.observer.ObserveDuration(ctx, 20*time.Millisecond, bundle)
+
+	fixture.observer.BundleLoaded("v1")
+	fixture.observer.ObserveDuration(ctx, 300*time.Millisecond, bundle)
+	fixture.observer.ObserveDuration(ctx, 10*time.Millisecond, attribute.String("policy.id", "no-bundle"))
+
+	counts := map[string]uint64{}
+	for _, dp := range fixture.float64Histogram(ctx, "evidence_processing_duration_seconds") {
+		cold, ok := attrValue(dp.Attributes, ColdStartKey)
+		require.True(t, ok)
+		counts[cold] += dp.Count
+	}
+	assert.Equal(t, map[string]uint64{"true": 2, "false": 3}, counts)
+}
+
+func TestObserveDuration(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.ObserveDuration(ctx, 30*time.Millisecond)
+	fixture.observer.ObserveDuration(ctx, -time.Second)
+
+	m := fixture.metric(ctx, "evidence_processing_duration_seconds")
+	assert.Equal(t, "s", m.Unit)
+	histogram, ok := m.Data.(metricdata.Histogram[float64])
+	require.True(t, ok, "expected a float64 histogram, got %T", m.Data)
+	require.Len(t, histogram.DataPoints, 1)
+
+	dp := histogram.DataPoints[0]
+	assert.Equal(t, durationBuckets, dp.Bounds)
+	assert.Equal(t, uint64(2), dp.Count)
+	// Buckets: (-inf,0.001] ... (0.025,0.05] ...
+	assert.Equal(t, uint64(1), dp.BucketCounts[0])
+	assert.Equal(t, uint64(1), dp.BucketCounts[5])
+}
+
+func TestExponentialDurationView(t *testing.T) {
+	for _, prefix := range []string{"", "acme"} {
+		t.Run("prefix "+prefix, func(t *testing.T) {
+			name := "evidence_processing_duration_seconds"
+			sizeName := "evidence_size_bytes"
+			if prefix != "" {
+				name, sizeName = prefix+"_"+name, prefix+"_"+sizeName
+			}
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithView(ExponentialDurationView()))
+			t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+			observer, err := NewEvidenceObserver(mp.Meter("test-meter"), WithNamePrefix(prefix))
+			require.NoError(t, err)
+			fixture := &evidenceObserverTestFixture{observer: observer, reader: reader, t: t}
+			ctx := context.Background()
+
+			fixture.observer.ObserveDuration(ctx, 30*time.Millisecond)
+			fixture.observer.ObserveDuration(ctx, 2*time.Second)
+
+			m := fixture.metric(ctx, name)
+			histogram, ok := m.Data.(metricdata.ExponentialHistogram[float64])
+			require.True(t, ok, "expected a float64 exponential histogram, got %T", m.Data)
+			require.Len(t, histogram.DataPoints, 1)
+			assert.Equal(t, uint64(2), histogram.DataPoints[0].Count)
+
+			// Other histograms keep their explicit buckets.
+			fixture.observer.ObserveSize(ctx, 512)
+			_, ok = fixture.metric(ctx, sizeName).Data.(metricdata.Histogram[int64])
+			assert.True(t, ok)
+		})
+	}
+}
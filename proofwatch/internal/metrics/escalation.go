@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// EscalationReasonKey is the bounded escalation reason attribute. Reasons beyond the
+// defaults can be registered with WithAllowedValues.
+const EscalationReasonKey = attribute.Key("escalation.reason")
+
+var escalationReasons = newBoundedSet(
+	"manual_control",
+	"ambiguous_evidence",
+	"low_confidence",
+	"policy_exception",
+)
+
+func (e *EvidenceObserver) initEscalation(meter metric.Meter) error {
+	var err error
+	e.escalationCounter, err = meter.Int64Counter(
+		"evaluation_escalation_count",
+		metric.WithDescription("The total number of evaluations escalated to a human reviewer, by reason."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create escalation counter: %w", err)
+	}
+	return nil
+}
+
+// RecordEscalation records an evaluation that could not be fully automated and was
+// escalated to a human reviewer. Unknown reasons are recorded as "other".
+func (e *EvidenceObserver) RecordEscalation(ctx context.Context, reason string, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.escalationCounter.Add(ctx, 1, e.measurementAttrs(attrs, e.bounded(EscalationReasonKey, reason, escalationReasons)))
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordEscalation(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithAllowedValues(EscalationReasonKey, "legal_review"))
+	ctx := context.Background()
+
+	fixture.observer.RecordEscalation(ctx, "manual_control")
+	fixture.observer.RecordEscalation(ctx, "manual_control")
+	fixture.observer.RecordEscalation(ctx, "low_confidence")
+	fixture.observer.RecordEscalation(ctx, "legal_review")
+	fixture.observer.RecordEscalation(ctx, "operator asked nicely")
+
+	got := sumByAttr(fixture.int64Points(ctx, "evaluation_escalation_count"), EscalationReasonKey)
+	assert.Equal(t, map[string]int64{"manual_control": 2, "low_confidence": 1, "legal_review": 1, "other": 1}, got)
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// MaturityLevelKey is the targeted compliance maturity level attribute.
+const MaturityLevelKey = attribute.Key("maturity.level")
+
+const (
+	// MinMaturityLevel is the lowest valid maturity level.
+	MinMaturityLevel = 1
+	// MaxMaturityLevel is the highest valid maturity level.
+	MaxMaturityLevel = 5
+)
+
+// Evaluation describes the outcome of evaluating a policy against evidence.
+type Evaluation struct {
+	PolicyID string
+	Status   EvaluationStatus
+	// MaturityLevel is the compliance maturity level targeted by the evaluation,
+	// or zero when no level is targeted.
+	MaturityLevel int
+}
+
+func (e *EvidenceObserver) initEvaluation(meter metric.Meter) error {
+	var err error
+	e.evaluationCounter, err = meter.Int64Counter(
+		"policy_evaluation_count",
+		metric.WithDescription("The total number of policy evaluations, by policy, status and targeted maturity level."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create evaluation counter: %w", err)
+	}
+	return nil
+}
+
+// RecordEvaluation records a policy evaluation outcome. Statuses are normalized as in
+// ProcessedWithStatus, and policy IDs not registered with WithPolicyIDs are recorded as
+// "other". It returns an error and records nothing when the targeted maturity level is
+// outside [1, 5].
+func (e *EvidenceObserver) RecordEvaluation(ctx context.Context, eval Evaluation, attrs ...attribute.KeyValue) error {
+	extra := []attribute.KeyValue{
+		e.bounded(PolicyIDKey, eval.PolicyID, nil),
+	}
+	if eval.MaturityLevel != 0 {
+		if eval.MaturityLevel < MinMaturityLevel || eval.MaturityLevel > MaxMaturityLevel {
+			return fmt.Errorf("maturity level %d out of range [%d, %d]", eval.MaturityLevel, MinMaturityLevel, MaxMaturityLevel)
+		}
+		extra = append(extra, MaturityLevelKey.Int(eval.MaturityLevel))
+	}
+	if !e.recording() {
+		return nil
+	}
+	extra = append(extra, e.evaluationStatus(ctx, eval.Status, attrs))
+	e.evaluationCounter.Add(ctx, 1, e.measurementAttrs(attrs, extra...))
+	return nil
+}
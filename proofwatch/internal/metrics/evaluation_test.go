@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEvaluationMaturityLevel(t *testing.T) {
+	t.Run("records level", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		require.NoError(t, fixture.observer.RecordEvaluation(ctx, Evaluation{PolicyID: "AC-1", Status: "pass", MaturityLevel: 2}))
+		require.NoError(t, fixture.observer.RecordEvaluation(ctx, Evaluation{PolicyID: "AC-1", Status: "pass", MaturityLevel: 5}))
+		require.NoError(t, fixture.observer.RecordEvaluation(ctx, Evaluation{PolicyID: "AC-1", Status: "pass"}))
+
+		got := sumByAttr(fixture.int64Points(ctx, "policy_evaluation_count"), MaturityLevelKey)
+		assert.Equal(t, map[string]int64{"2": 1, "5": 1, "": 1}, got)
+	})
+
+	t.Run("rejects out of range levels", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		for _, level := range []int{-1, 6, 42} {
+			err := fixture.observer.RecordEvaluation(ctx, Evaluation{PolicyID: "AC-1", Status: "pass", MaturityLevel: level})
+			assert.Error(t, err, level)
+		}
+		assert.Empty(t, fixture.collectMetrics(ctx).ScopeMetrics)
+	})
+}
+
+func TestRecordEvaluationBoundsPolicyIDs(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithPolicyIDs("AC-1"))
+	ctx := context.Background()
+
+	require.NoError(t, fixture.observer.RecordEvaluation(ctx, Evaluation{PolicyID: "AC-1", Status: "pass"}))
+	require.NoError(t, fixture.observer.RecordEvaluation(ctx, Evaluation{PolicyID: "AC-2", Status: "pass"}))
+
+	got := sumByAttr(fixture.int64Points(ctx, "policy_evaluation_count"), PolicyIDKey)
+	assert.Equal(t, map[string]int64{"AC-1": 1, "other": 1}, got)
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DestinationKey is the bounded export destination attribute. Destinations beyond
+// the built-in ones can be registered with WithAllowedValues.
+const DestinationKey = attribute.Key("destination")
+
+var exportDestinations = newBoundedSet("ticketing", "siem", "dashboard", "storage", "webhook")
+
+func (e *EvidenceObserver) initExport(meter metric.Meter) error {
+	var err error
+	e.exportCounter, err = meter.Int64Counter(
+		"result_export_count",
+		metric.WithDescription("The total number of evaluation result exports, by destination and outcome."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create export counter: %w", err)
+	}
+	return nil
+}
+
+// RecordExport records an attempt to export an evaluation result to destination.
+func (e *EvidenceObserver) RecordExport(ctx context.Context, destination string, success bool, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.exportCounter.Add(ctx, 1, e.measurementAttrs(attrs,
+		e.bounded(DestinationKey, destination, exportDestinations),
+		SuccessKey.Bool(success),
+	))
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordExport(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithAllowedValues(DestinationKey, "grc-platform"))
+	ctx := context.Background()
+
+	fixture.observer.RecordExport(ctx, "siem", true)
+	fixture.observer.RecordExport(ctx, "siem", false)
+	fixture.observer.RecordExport(ctx, "ticketing", true)
+	fixture.observer.RecordExport(ctx, "dashboard", true)
+	fixture.observer.RecordExport(ctx, "grc-platform", true)
+	fixture.observer.RecordExport(ctx, "pager", false)
+
+	got := map[string]int64{}
+	for _, dp := range fixture.int64Points(ctx, "result_export_count") {
+		destination, _ := attrValue(dp.Attributes, DestinationKey)
+		success, _ := attrValue(dp.Attributes, SuccessKey)
+		got[destination+"/"+success] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{
+		"siem/true":         1,
+		"siem/false":        1,
+		"ticketing/true":    1,
+		"dashboard/true":    1,
+		"grc-platform/true": 1,
+		"other/false":       1,
+	}, got)
+}
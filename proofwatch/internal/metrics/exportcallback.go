@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"context"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// callbackExporter reports the outcome of each export of the wrapped exporter.
+type callbackExporter struct {
+	sdkmetric.Exporter
+	fn func(error)
+}
+
+// WrapExporter returns an exporter delegating to exporter that calls fn with the
+// outcome of every export: nil when it succeeded, and the export error otherwise.
+func WrapExporter(exporter sdkmetric.Exporter, fn func(error)) sdkmetric.Exporter {
+	return &callbackExporter{Exporter: exporter, fn: fn}
+}
+
+func (c *callbackExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	err := c.Exporter.Export(ctx, rm)
+	c.fn(err)
+	return err
+}
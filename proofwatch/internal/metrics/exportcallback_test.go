@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// failingExporter is an exporter whose exports fail with err.
+type failingExporter struct {
+	sdkmetric.Exporter
+	err error
+}
+
+func (f *failingExporter) Export(context.Context, *metricdata.ResourceMetrics) error {
+	return f.err
+}
+
+func TestWrapExporter(t *testing.T) {
+	ctx := context.Background()
+	exporter := &failingExporter{err: assert.AnError}
+
+	var outcomes []error
+	wrapped := WrapExporter(exporter, func(err error) { outcomes = append(outcomes, err) })
+
+	require.ErrorIs(t, wrapped.Export(ctx, &metricdata.ResourceMetrics{}), assert.AnError)
+	exporter.err = nil
+	require.NoError(t, wrapped.Export(ctx, &metricdata.ResourceMetrics{}))
+
+	assert.Equal(t, []error{assert.AnError, nil}, outcomes)
+}
+
+func TestWithExportCallback(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+
+	var outcomes []error
+	mp, err := NewOTLPMeterProvider(context.Background(), OTLPConfig{Endpoint: "127.0.0.1:1", Insecure: true},
+		WithExportCallback(func(err error) { outcomes = append(outcomes, err) }))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_ = mp.ForceFlush(ctx)
+	_ = mp.Shutdown(ctx)
+
+	require.NotEmpty(t, outcomes)
+	assert.Error(t, outcomes[0])
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// FeatureKey is the bounded feature flag attribute. Features must be registered
+// with WithAllowedValues to be recorded under their own name.
+const FeatureKey = attribute.Key("feature")
+
+func (e *EvidenceObserver) initFeatures(meter metric.Meter) error {
+	var err error
+	e.featureDisabledCounter, err = meter.Int64Counter(
+		"evidence_feature_disabled_count",
+		metric.WithDescription("The total number of evidence items skipped because a feature flag disabled their processing path."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create feature disabled counter: %w", err)
+	}
+	return nil
+}
+
+// RecordFeatureDisabled records evidence skipped because feature is disabled.
+// Skips are tracked separately from drops since the evidence was not faulty.
+func (e *EvidenceObserver) RecordFeatureDisabled(ctx context.Context, feature string, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.featureDisabledCounter.Add(ctx, 1, e.measurementAttrs(attrs, e.bounded(FeatureKey, feature, nil)))
+}
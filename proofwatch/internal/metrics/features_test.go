@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordFeatureDisabled(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithAllowedValues(FeatureKey, "oscal_export", "rego_eval"))
+	ctx := context.Background()
+
+	fixture.observer.RecordFeatureDisabled(ctx, "oscal_export")
+	fixture.observer.RecordFeatureDisabled(ctx, "oscal_export")
+	fixture.observer.RecordFeatureDisabled(ctx, "rego_eval")
+	fixture.observer.RecordFeatureDisabled(ctx, "unregistered")
+
+	got := sumByAttr(fixture.int64Points(ctx, "evidence_feature_disabled_count"), FeatureKey)
+	assert.Equal(t, map[string]int64{"oscal_export": 2, "rego_eval": 1, "other": 1}, got)
+}
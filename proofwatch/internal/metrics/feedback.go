@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// FeedbackVerdict is an analyst verdict on a reported finding.
+type FeedbackVerdict string
+
+const (
+	FeedbackTruePositive  FeedbackVerdict = "true_positive"
+	FeedbackFalsePositive FeedbackVerdict = "false_positive"
+	FeedbackBenign        FeedbackVerdict = "benign"
+)
+
+// FeedbackVerdictKey is the bounded verdict attribute.
+const FeedbackVerdictKey = attribute.Key("feedback.verdict")
+
+var feedbackVerdicts = newBoundedSet(
+	string(FeedbackTruePositive),
+	string(FeedbackFalsePositive),
+	string(FeedbackBenign),
+)
+
+func (e *EvidenceObserver) initFeedback(meter metric.Meter) error {
+	var err error
+	e.feedbackCounter, err = meter.Int64Counter(
+		"finding_feedback_count",
+		metric.WithDescription("The total number of analyst verdicts recorded on findings, by verdict and policy."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create feedback counter: %w", err)
+	}
+	return nil
+}
+
+// RecordFeedback records an analyst verdict on a finding produced by policyID.
+// Verdicts outside the known set are recorded as "unknown", and policy IDs not
+// registered with WithPolicyIDs as "other".
+func (e *EvidenceObserver) RecordFeedback(ctx context.Context, verdict FeedbackVerdict, policyID string, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.feedbackCounter.Add(ctx, 1, e.measurementAttrs(attrs,
+		FeedbackVerdictKey.String(feedbackVerdicts.normalize(string(verdict), unknownValue)),
+		e.bounded(PolicyIDKey, policyID, nil),
+	))
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordFeedback(t *testing.T) {
+	tests := []struct {
+		name    string
+		verdict FeedbackVerdict
+		want    string
+	}{
+		{name: "true positive", verdict: FeedbackTruePositive, want: "true_positive"},
+		{name: "false positive", verdict: FeedbackFalsePositive, want: "false_positive"},
+		{name: "benign", verdict: FeedbackBenign, want: "benign"},
+		{name: "unrecognized verdict", verdict: FeedbackVerdict("maybe"), want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := setupEvidenceObserverTest(t, WithPolicyIDs("policy-1"))
+			ctx := context.Background()
+
+			fixture.observer.RecordFeedback(ctx, tt.verdict, "policy-1")
+
+			points := fixture.int64Points(ctx, "finding_feedback_count")
+			require.Len(t, points, 1)
+			assert.Equal(t, int64(1), points[0].Value)
+
+			verdict, _ := attrValue(points[0].Attributes, FeedbackVerdictKey)
+			assert.Equal(t, tt.want, verdict)
+			policy, _ := attrValue(points[0].Attributes, PolicyIDKey)
+			assert.Equal(t, "policy-1", policy)
+		})
+	}
+}
+
+func TestRecordFeedbackBoundsPolicyIDs(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithPolicyIDs("policy-1"))
+	ctx := context.Background()
+
+	fixture.observer.RecordFeedback(ctx, FeedbackBenign, "policy-1")
+	fixture.observer.RecordFeedback(ctx, FeedbackBenign, "policy-2")
+	fixture.observer.RecordFeedback(ctx, FeedbackBenign, "policy-3")
+
+	got := sumByAttr(fixture.int64Points(ctx, "finding_feedback_count"), PolicyIDKey)
+	assert.Equal(t, map[string]int64{"policy-1": 1, "other": 2}, got)
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// DetectedFormatKey is the bounded auto-detected evidence format attribute.
+	DetectedFormatKey = attribute.Key("format.detected")
+	// DetectionCorrectKey records whether format auto-detection guessed correctly.
+	DetectionCorrectKey = attribute.Key("format.detection.correct")
+)
+
+var evidenceFormats = newBoundedSet("json", "yaml", "xml", "csv", "protobuf", "ocsf", "oscal", "cloudevents")
+
+func (e *EvidenceObserver) initFormatDetection(meter metric.Meter) error {
+	var err error
+	e.formatDetectionCounter, err = meter.Int64Counter(
+		"evidence_format_detection_count",
+		metric.WithDescription("The total number of evidence format auto-detections, by detected format and correctness."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create format detection counter: %w", err)
+	}
+	return nil
+}
+
+// RecordFormatDetection records the format auto-detected for an evidence payload and
+// whether the guess turned out correct. Formats are matched case-insensitively and
+// unrecognized formats are recorded as "other".
+func (e *EvidenceObserver) RecordFormatDetection(ctx context.Context, detected string, correct bool, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.formatDetectionCounter.Add(ctx, 1, e.measurementAttrs(attrs,
+		e.bounded(DetectedFormatKey, strings.ToLower(detected), evidenceFormats),
+		DetectionCorrectKey.Bool(correct),
+	))
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordFormatDetection(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordFormatDetection(ctx, "JSON", true)
+	fixture.observer.RecordFormatDetection(ctx, "json", true)
+	fixture.observer.RecordFormatDetection(ctx, "yaml", false)
+	fixture.observer.RecordFormatDetection(ctx, "toml", false)
+
+	got := map[string]int64{}
+	for _, dp := range fixture.int64Points(ctx, "evidence_format_detection_count") {
+		format, _ := attrValue(dp.Attributes, DetectedFormatKey)
+		correct, _ := attrValue(dp.Attributes, DetectionCorrectKey)
+		got[format+"/"+correct] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"json/true": 2, "yaml/false": 1, "other/false": 1}, got)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RegisterSourceHealthGauge registers the source_health_score gauge. At each
+// collection, scorer is called for every source registered under SourceKey with
+// WithAllowedValues, and its result is reported for that source.
+func (e *EvidenceObserver) RegisterSourceHealthGauge(ctx context.Context, scorer func(source string) float64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	sources := make([]string, 0, len(e.cfg.allowed[SourceKey]))
+	for source := range e.cfg.allowed[SourceKey] {
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return errors.New("no sources registered for the source health gauge")
+	}
+	slices.Sort(sources)
+
+	_, err := (*e.meter).Float64ObservableGauge(
+		"source_health_score",
+		metric.WithDescription("A consolidated health score per evidence source."),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			for _, source := range sources {
+				o.Observe(scorer(source), e.measurementAttrs(nil, SourceKey.String(source)))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create source health gauge: %w", err)
+	}
+	return nil
+}
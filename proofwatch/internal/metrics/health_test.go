@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterSourceHealthGauge(t *testing.T) {
+	t.Run("reports scorer values per source", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithAllowedValues(SourceKey, "github", "aws-config"))
+		ctx := context.Background()
+
+		scores := map[string]float64{"github": 0.9, "aws-config": 0.4}
+		require.NoError(t, fixture.observer.RegisterSourceHealthGauge(ctx, func(source string) float64 {
+			return scores[source]
+		}))
+
+		got := map[string]float64{}
+		for _, dp := range fixture.float64Gauge(ctx, "source_health_score") {
+			source, _ := attrValue(dp.Attributes, SourceKey)
+			got[source] = dp.Value
+		}
+		assert.Equal(t, scores, got)
+	})
+
+	t.Run("requires registered sources", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		err := fixture.observer.RegisterSourceHealthGauge(context.Background(), func(string) float64 { return 1 })
+		assert.Error(t, err)
+	})
+}
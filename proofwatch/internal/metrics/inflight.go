@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+func (e *EvidenceObserver) initInFlight(meter metric.Meter) error {
+	var err error
+	e.inFlight, err = meter.Int64UpDownCounter(
+		"evidence_in_flight",
+		metric.WithDescription("The number of evidence items currently being processed."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create in-flight counter: %w", err)
+	}
+	return nil
+}
+
+// Begin marks an evidence item as in flight. Every call must be paired with a call
+// to End once the item has been processed or dropped.
+func (e *EvidenceObserver) Begin(ctx context.Context) {
+	if !e.recording() {
+		return
+	}
+	e.inFlight.Add(ctx, 1, e.measurementAttrs(nil))
+}
+
+// End marks an evidence item started with Begin as no longer in flight.
+func (e *EvidenceObserver) End(ctx context.Context) {
+	if !e.recording() {
+		return
+	}
+	e.inFlight.Add(ctx, -1, e.measurementAttrs(nil))
+}
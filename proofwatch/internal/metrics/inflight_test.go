@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFlight(t *testing.T) {
+	t.Run("tracks items in flight", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		fixture.observer.Begin(ctx)
+		fixture.observer.Begin(ctx)
+		fixture.observer.End(ctx)
+
+		points := fixture.int64Points(ctx, "evidence_in_flight")
+		require.Len(t, points, 1)
+		assert.Equal(t, int64(1), points[0].Value)
+	})
+
+	t.Run("returns to zero after concurrent work", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		const workers = 50
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+				fixture.observer.Begin(ctx)
+				defer fixture.observer.End(ctx)
+				fixture.observer.Processed(ctx)
+			}()
+		}
+		wg.Wait()
+
+		points := fixture.int64Points(ctx, "evidence_in_flight")
+		require.Len(t, points, 1)
+		assert.Equal(t, int64(0), points[0].Value)
+		assert.Equal(t, int64(workers), fixture.int64Points(ctx, "evidence_processed_count")[0].Value)
+	})
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// IngestProtocolKey is the bounded ingestion protocol attribute.
+const IngestProtocolKey = attribute.Key("ingest.protocol")
+
+var ingestProtocols = newBoundedSet("http", "grpc", "otlp", "kafka", "file")
+
+// ingestionLagBuckets cover network and queueing delays, in seconds.
+var ingestionLagBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// ingestionLags keeps recent ingestion lags per protocol for percentile queries.
+type ingestionLags struct {
+	mu        sync.Mutex
+	protocols map[string]*durationReservoir
+}
+
+func (e *EvidenceObserver) initIngestionLag(meter metric.Meter) error {
+	var err error
+	e.ingestionLag, err = meter.Float64Histogram(
+		"evidence_ingestion_lag_seconds",
+		metric.WithDescription("The time between evidence generation and ingestion, by protocol."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(ingestionLagBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create ingestion lag histogram: %w", err)
+	}
+	e.ingestionLags = &ingestionLags{protocols: make(map[string]*durationReservoir)}
+	return nil
+}
+
+// RecordIngestionLag records the network and queueing lag of evidence ingested over
+// protocol. Protocols are matched case-insensitively and unrecognized ones are
+// recorded as "other". A negative lag is recorded as zero.
+func (e *EvidenceObserver) RecordIngestionLag(ctx context.Context, protocol string, lag time.Duration, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	protocolAttr := e.bounded(IngestProtocolKey, strings.ToLower(protocol), ingestProtocols)
+	lag = max(lag, 0)
+
+	e.ingestionLags.mu.Lock()
+	reservoir, ok := e.ingestionLags.protocols[protocolAttr.Value.AsString()]
+	if !ok {
+		reservoir = &durationReservoir{}
+		e.ingestionLags.protocols[protocolAttr.Value.AsString()] = reservoir
+	}
+	reservoir.add(lag)
+	e.ingestionLags.mu.Unlock()
+
+	e.ingestionLag.Record(ctx, lag.Seconds(), e.measurementAttrs(attrs, protocolAttr))
+}
+
+// IngestionLagPercentile returns the q-quantile (0..1) of the recent ingestion lags
+// recorded for protocol, or zero when none were recorded.
+func (e *EvidenceObserver) IngestionLagPercentile(protocol string, q float64) time.Duration {
+	key := e.allowedValue(IngestProtocolKey, strings.ToLower(protocol), ingestProtocols)
+
+	e.ingestionLags.mu.Lock()
+	defer e.ingestionLags.mu.Unlock()
+	reservoir, ok := e.ingestionLags.protocols[key]
+	if !ok {
+		return 0
+	}
+	return reservoir.percentile(q)
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngestionLagPercentile(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	for i := 1; i <= 100; i++ {
+		fixture.observer.RecordIngestionLag(ctx, "HTTP", time.Duration(i)*time.Millisecond)
+		fixture.observer.RecordIngestionLag(ctx, "grpc", time.Duration(i)*time.Second)
+	}
+
+	assert.Equal(t, 95*time.Millisecond, fixture.observer.IngestionLagPercentile("http", 0.95))
+	assert.Equal(t, 95*time.Second, fixture.observer.IngestionLagPercentile("grpc", 0.95))
+	assert.Equal(t, 50*time.Second, fixture.observer.IngestionLagPercentile("grpc", 0.5))
+	assert.Equal(t, time.Duration(0), fixture.observer.IngestionLagPercentile("kafka", 0.95))
+
+	counts := map[string]uint64{}
+	for _, dp := range fixture.float64Histogram(ctx, "evidence_ingestion_lag_seconds") {
+		protocol, _ := attrValue(dp.Attributes, IngestProtocolKey)
+		counts[protocol] += dp.Count
+	}
+	assert.Equal(t, map[string]uint64{"http": 100, "grpc": 100}, counts)
+}
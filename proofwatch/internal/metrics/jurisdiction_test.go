@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestWithJurisdiction(t *testing.T) {
+	t.Run("tags all recordings", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithJurisdiction("de"))
+		ctx := context.Background()
+
+		fixture.observer.Processed(ctx)
+		fixture.observer.Dropped(ctx)
+
+		for _, name := range []string{"evidence_processed_count", "evidence_dropped_count"} {
+			points := fixture.int64Points(ctx, name)
+			require.Len(t, points, 1)
+			code, _ := attrValue(points[0].Attributes, JurisdictionKey)
+			assert.Equal(t, "DE", code, name)
+		}
+	})
+
+	t.Run("rejects invalid codes", func(t *testing.T) {
+		meter := sdkmetric.NewMeterProvider().Meter("test-meter")
+
+		for _, code := range []string{"", "XX", "DEU", "Germany"} {
+			observer, err := NewEvidenceObserver(meter, WithJurisdiction(code))
+			assert.Error(t, err, code)
+			assert.Nil(t, observer)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// KeyStatus is the rotation status of the key that signed an evidence item.
+type KeyStatus string
+
+const (
+	KeyStatusActive   KeyStatus = "active"
+	KeyStatusRotating KeyStatus = "rotating"
+	KeyStatusRetired  KeyStatus = "retired"
+	KeyStatusRevoked  KeyStatus = "revoked"
+)
+
+// KeyStatusKey is the bounded signing key status attribute.
+const KeyStatusKey = attribute.Key("signing_key.status")
+
+var keyStatuses = newBoundedSet(
+	string(KeyStatusActive),
+	string(KeyStatusRotating),
+	string(KeyStatusRetired),
+	string(KeyStatusRevoked),
+)
+
+func (e *EvidenceObserver) initKeyStatus(meter metric.Meter) error {
+	var err error
+	e.keyStatusCounter, err = meter.Int64Counter(
+		"signing_key_status_count",
+		metric.WithDescription("The total number of evidence items by the rotation status of their signing key."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create signing key status counter: %w", err)
+	}
+	return nil
+}
+
+// RecordKeyStatus records the rotation status of the key that signed an evidence
+// item. Statuses outside the known set are recorded as "unknown". Evidence signed
+// with a revoked key is also dropped with DropReasonRevokedKey.
+func (e *EvidenceObserver) RecordKeyStatus(ctx context.Context, status KeyStatus, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	normalized := keyStatuses.normalize(string(status), unknownValue)
+	e.keyStatusCounter.Add(ctx, 1, e.measurementAttrs(attrs, KeyStatusKey.String(normalized)))
+	if normalized == string(KeyStatusRevoked) {
+		e.droppedWithReason(ctx, DropReasonRevokedKey, attrs)
+	}
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordKeyStatus(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	for _, status := range []KeyStatus{KeyStatusActive, KeyStatusActive, KeyStatusRotating, KeyStatusRetired, KeyStatusRevoked, "compromised"} {
+		fixture.observer.RecordKeyStatus(ctx, status)
+	}
+
+	got := sumByAttr(fixture.int64Points(ctx, "signing_key_status_count"), KeyStatusKey)
+	assert.Equal(t, map[string]int64{"active": 2, "rotating": 1, "retired": 1, "revoked": 1, "unknown": 1}, got)
+
+	dropped := fixture.int64Points(ctx, "evidence_dropped_count")
+	require.Len(t, dropped, 1)
+	assert.Equal(t, int64(1), dropped[0].Value)
+	reason, _ := attrValue(dropped[0].Attributes, DropReasonKey)
+	assert.Equal(t, string(DropReasonRevokedKey), reason)
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// lastEvaluations tracks when each policy last had evidence processed.
+type lastEvaluations struct {
+	mu       sync.Mutex
+	policies map[string]time.Time
+	now      func() time.Time
+}
+
+// observe records now as the latest evaluation of the policy in recorded, if any.
+// Earlier times never replace later ones, so a clock stepping back does not make a
+// policy look stale.
+func (l *lastEvaluations) observe(recorded []attribute.KeyValue) {
+	var policyID string
+	for _, kv := range recorded {
+		if kv.Key == PolicyIDKey {
+			policyID = kv.Value.Emit()
+		}
+	}
+	if policyID == "" {
+		return
+	}
+
+	now := l.now()
+	l.mu.Lock()
+	if now.After(l.policies[policyID]) {
+		l.policies[policyID] = now
+	}
+	l.mu.Unlock()
+}
+
+func (e *EvidenceObserver) initLastEvaluation(meter metric.Meter) error {
+	e.lastEvaluations = &lastEvaluations{policies: make(map[string]time.Time), now: time.Now}
+	_, err := meter.Float64ObservableGauge(
+		"evidence_last_evaluation_timestamp_seconds",
+		metric.WithDescription("The Unix time evidence was last processed for each policy."),
+		metric.WithUnit("s"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			e.lastEvaluations.mu.Lock()
+			defer e.lastEvaluations.mu.Unlock()
+			for policyID, last := range e.lastEvaluations.policies {
+				o.Observe(float64(last.UnixNano())/float64(time.Second), e.measurementAttrs(nil, PolicyIDKey.String(policyID)))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create last evaluation gauge: %w", err)
+	}
+	return nil
+}
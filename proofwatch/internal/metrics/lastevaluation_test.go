@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestLastEvaluationTimestamp(t *testing.T) {
+	lastByPolicy := func(t *testing.T, fixture *evidenceObserverTestFixture) map[string]float64 {
+		t.Helper()
+		out := map[string]float64{}
+		for _, dp := range fixture.float64Gauge(context.Background(), "evidence_last_evaluation_timestamp_seconds") {
+			policyID, ok := attrValue(dp.Attributes, PolicyIDKey)
+			require.True(t, ok)
+			out[policyID] = dp.Value
+		}
+		return out
+	}
+
+	t.Run("reports the latest timestamp per policy", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		clock := time.Unix(1_700_000_000, 0)
+		fixture.observer.lastEvaluations.now = func() time.Time { return clock }
+
+		fixture.observer.Processed(ctx, attribute.String("policy.id", "policy-a"))
+		clock = clock.Add(10 * time.Second)
+		fixture.observer.Processed(ctx, attribute.String("policy.id", "policy-b"))
+		clock = clock.Add(5 * time.Second)
+		fixture.observer.Processed(ctx, attribute.String("policy.id", "policy-a"))
+
+		assert.Equal(t, map[string]float64{
+			"policy-a": 1_700_000_015,
+			"policy-b": 1_700_000_010,
+		}, lastByPolicy(t, fixture))
+
+		clock = clock.Add(-time.Minute)
+		fixture.observer.Processed(ctx, attribute.String("policy.id", "policy-a"))
+		assert.Equal(t, 1_700_000_015.0, lastByPolicy(t, fixture)["policy-a"])
+	})
+
+	t.Run("ignores dropped evidence and evidence without a policy", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		fixture.observer.Dropped(ctx, attribute.String("policy.id", "policy-a"))
+		fixture.observer.Processed(ctx)
+
+		assert.False(t, fixture.collected(ctx, "evidence_last_evaluation_timestamp_seconds"))
+	})
+
+	t.Run("uses the current time", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		before := time.Now()
+
+		fixture.observer.Processed(context.Background(), attribute.String("policy.id", "policy-a"))
+
+		last := lastByPolicy(t, fixture)["policy-a"]
+		assert.GreaterOrEqual(t, last, float64(before.Unix()))
+		assert.LessOrEqual(t, last, float64(time.Now().Unix()+1))
+	})
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRU is a bounded map from string keys to values. Once full, adding a key evicts the
+// least recently used one. It is safe for concurrent use.
+type LRU[V any] struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+// NewLRU returns an empty LRU holding at most size keys. A non-positive size holds one key.
+func NewLRU[V any](size int) *LRU[V] {
+	return &LRU[V]{
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value of key and whether it is present, marking it as the most
+// recently used if so.
+func (c *LRU[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[V]).value, true
+}
+
+// Put sets the value of key, marking it as the most recently used, and returns the
+// number of keys held afterwards.
+func (c *LRU[V]) Put(key string, value V) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry[V]).value = value
+		c.order.MoveToFront(elem)
+		return len(c.entries)
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[V]).key)
+	}
+	return len(c.entries)
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	lru := NewLRU[int](2)
+
+	assert.Equal(t, 1, lru.Put("a", 1))
+	assert.Equal(t, 2, lru.Put("b", 2))
+	_, _ = lru.Get("a")
+	assert.Equal(t, 2, lru.Put("c", 3), "the least recently used key is evicted")
+
+	_, ok := lru.Get("b")
+	assert.False(t, ok)
+	value, ok := lru.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	assert.Equal(t, 2, lru.Put("c", 4), "updating a key keeps the size")
+	value, _ = lru.Get("c")
+	assert.Equal(t, 4, value)
+}
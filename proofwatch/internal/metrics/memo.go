@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func (e *EvidenceObserver) initMemo(meter metric.Meter) error {
+	var err error
+	e.memoReuseCounter, err = meter.Int64Counter(
+		"evaluation_memo_reuse_count",
+		metric.WithDescription("The total number of evaluation results reused from a previous run's memo."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create memo reuse counter: %w", err)
+	}
+	return nil
+}
+
+// RecordMemoReuse records an evaluation result reused from a memo populated by a
+// previous run, as opposed to a hit in the in-run evaluation cache.
+func (e *EvidenceObserver) RecordMemoReuse(ctx context.Context, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.memoReuseCounter.Add(ctx, 1, e.measurementAttrs(attrs))
+}
@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordMemoReuse(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordMemoReuse(ctx, PolicyIDKey.String("policy-1"))
+	fixture.observer.RecordMemoReuse(ctx, PolicyIDKey.String("policy-1"))
+
+	points := fixture.int64Points(ctx, "evaluation_memo_reuse_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(2), points[0].Value)
+}
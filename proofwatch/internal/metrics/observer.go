@@ -3,23 +3,97 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // EvidenceObserver handles observing and pushing evidence processing metrics.
 type EvidenceObserver struct {
 	meter          *metric.Meter
+	cfg            observerConfig
+	summary        *summaryState
 	droppedCounter metric.Int64Counter
 	processedCount metric.Int64Counter
+
+	feedbackCounter        metric.Int64Counter
+	featureDisabledCounter metric.Int64Counter
+	rollupCounter          metric.Int64Counter
+	rollupInputSize        metric.Int64Histogram
+	schemaMigrationCounter metric.Int64Counter
+	comparison             *comparisonCounters
+	driftCounter           metric.Int64Counter
+	driftMagnitude         metric.Float64Histogram
+	acceptedCounter        metric.Int64Counter
+	retryBackoff           metric.Float64Histogram
+	stageSkipCounter       metric.Int64Counter
+	exportCounter          metric.Int64Counter
+	custodyCounter         metric.Int64Counter
+	parallelism            metric.Int64Histogram
+	policyAge              metric.Float64Histogram
+	memoReuseCounter       metric.Int64Counter
+	waiverAppliedCounter   metric.Int64Counter
+	waiverInactiveCounter  metric.Int64Counter
+	processingDuration     metric.Float64Histogram
+	coldStarts             *coldStarts
+	formatDetectionCounter metric.Int64Counter
+	dependencyCounter      metric.Int64Counter
+	ingestionLag           metric.Float64Histogram
+	ingestionLags          *ingestionLags
+	supersededCounter      metric.Int64Counter
+	thresholdCounter       metric.Int64Counter
+	thresholdDelta         metric.Float64Histogram
+	ackLatency             metric.Float64Histogram
+	evidenceSize           metric.Int64Histogram
+	inputSize              metric.Int64Histogram
+	resultSize             metric.Int64Histogram
+	sizeRatio              metric.Float64Histogram
+	evaluationCounter      metric.Int64Counter
+	deadlineBudget         metric.Float64Histogram
+	deadlineOverrunCounter metric.Int64Counter
+	ruleComplexity         metric.Int64Histogram
+	shortCircuitCounter    metric.Int64Counter
+	remediationSLACounter  metric.Int64Counter
+	escalationCounter      metric.Int64Counter
+	keyStatusCounter       metric.Int64Counter
+	orderingCounter        metric.Int64Counter
+	sequences              *sequences
+	inFlight               metric.Int64UpDownCounter
+	degraded               atomic.Bool
+	degradedTransitions    metric.Int64Counter
+	canonicalHitCounter    metric.Int64Counter
+	canonicalMissCounter   metric.Int64Counter
+	weightedPassed         metric.Float64Counter
+	weightedTotal          metric.Float64Counter
+	unknownStatusCounter   metric.Int64Counter
+	dedupHitCounter        metric.Int64Counter
+	dedupCacheEntries      metric.Int64Gauge
+	closed                 atomic.Bool
+	policyLoadFailures     metric.Int64Counter
+	outOfWindowCounter     metric.Int64Counter
+	rejectedAttrsCounter   metric.Int64Counter
+	queueDepth             metric.Int64UpDownCounter
+	activeWorkers          metric.Int64UpDownCounter
+	queueWait              metric.Float64Histogram
+	lastEvaluations        *lastEvaluations
 }
 
 // NewEvidenceObserver creates a new EvidenceObserver and registers the callback.
-func NewEvidenceObserver(meter metric.Meter) (*EvidenceObserver, error) {
+func NewEvidenceObserver(meter metric.Meter, opts ...Option) (*EvidenceObserver, error) {
 	co := &EvidenceObserver{
-		meter: &meter,
+		summary: newSummaryState(),
+	}
+	for _, opt := range opts {
+		opt(&co.cfg)
+	}
+	if co.cfg.err != nil {
+		return nil, co.cfg.err
 	}
+	meter = withNamePrefix(meter, co.cfg.namePrefix)
+	co.meter = &meter
 
 	var err error
 	// Create and register the new counter.
@@ -39,13 +113,183 @@ func NewEvidenceObserver(meter metric.Meter) (*EvidenceObserver, error) {
 		return nil, fmt.Errorf("failed to create processed counter: %w", err)
 	}
 
+	// Feature instruments are created next to the methods recording them.
+	for _, init := range []func(metric.Meter) error{
+		co.initFeedback,
+		co.initFeatures,
+		co.initRollup,
+		co.initSchemaMigration,
+		co.initComparison,
+		co.initDrift,
+		co.initAccepted,
+		co.initRetry,
+		co.initStageSkip,
+		co.initExport,
+		co.initCustody,
+		co.initParallelism,
+		co.initPolicyAge,
+		co.initMemo,
+		co.initWaiver,
+		co.initDuration,
+		co.initFormatDetection,
+		co.initDependency,
+		co.initIngestionLag,
+		co.initSuperseded,
+		co.initThreshold,
+		co.initAckLatency,
+		co.initSize,
+		co.initEvaluation,
+		co.initDeadlineBudget,
+		co.initRuleComplexity,
+		co.initShortCircuit,
+		co.initRemediationSLA,
+		co.initEscalation,
+		co.initKeyStatus,
+		co.initOrdering,
+		co.initInFlight,
+		co.initBoundary,
+		co.initDegraded,
+		co.initCanonicalCache,
+		co.initWeighted,
+		co.initStatus,
+		co.initDedup,
+		co.initPolicyLoad,
+		co.initWindow,
+		co.initControlChars,
+		co.initPool,
+		co.initLastEvaluation,
+	} {
+		if err := init(meter); err != nil {
+			return nil, err
+		}
+	}
+
 	return co, nil
 }
 
+// Dropped records an evidence item dropped due to a processing failure.
+//
+// Attribute values become metric labels: they should come from a bounded set of
+// printable values, never from free-form input such as IDs or messages. String values
+// containing control characters are handled per WithControlCharPolicy. Attributes set
+// on ctx with ContextWithAttributes are added, with attrs winning on key conflicts.
 func (e *EvidenceObserver) Dropped(ctx context.Context, attrs ...attribute.KeyValue) {
-	e.droppedCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
+	if !e.recording() {
+		return
+	}
+	attrs = withContextAttributes(ctx, attrs)
+	if e.cfg.tracer != nil {
+		var span trace.Span
+		ctx, span = e.startRecordSpan(ctx, "evidence.record.dropped", attrs)
+		defer span.End()
+	}
+	if recorded, ok := e.admit(ctx, true, attrs); ok {
+		e.count(ctx, true, 1, metric.WithAttributes(recorded...))
+	}
 }
 
+// Processed records an evidence item processed successfully. Attributes follow the
+// same contract as for Dropped. With WithSampling, only sampled items are recorded,
+// each counting for 1/rate items.
 func (e *EvidenceObserver) Processed(ctx context.Context, attrs ...attribute.KeyValue) {
-	e.processedCount.Add(ctx, 1, metric.WithAttributes(attrs...))
+	if !e.recording() {
+		return
+	}
+	attrs = withContextAttributes(ctx, attrs)
+	if e.cfg.tracer != nil {
+		var span trace.Span
+		ctx, span = e.startRecordSpan(ctx, "evidence.record.processed", attrs)
+		defer span.End()
+	}
+	if recorded, ok := e.admit(ctx, false, attrs); ok {
+		n := int64(1)
+		if e.cfg.sampleWeight != nil {
+			n = e.cfg.sampleWeight.next()
+		}
+		e.count(ctx, false, n, metric.WithAttributes(recorded...))
+	}
+}
+
+// admit runs a processed or dropped event through the attribute policies, burn rate
+// tracking, compliance summary, last evaluation tracking and sampler. It returns the attributes to count the
+// event under, or false when the event must not be counted.
+func (e *EvidenceObserver) admit(ctx context.Context, dropped bool, attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	attrs, ok := e.checkControlChars(ctx, attrs)
+	if !ok {
+		return nil, false
+	}
+	if e.cfg.burnRate != nil {
+		e.cfg.burnRate.observe(dropped)
+	}
+	recorded := e.attributes(attrs)
+	if dropped {
+		e.summary.dropped(recorded)
+		return recorded, true
+	}
+	e.summary.processed(recorded)
+	e.lastEvaluations.observe(recorded)
+	if e.cfg.sampler != nil && !e.cfg.sampler.ShouldSample(ctx, recorded) {
+		return nil, false
+	}
+	return recorded, true
+}
+
+// count adds n to the dropped or processed counter and its comparison counterpart.
+func (e *EvidenceObserver) count(ctx context.Context, dropped bool, n int64, opt metric.AddOption) {
+	if dropped {
+		e.droppedCounter.Add(ctx, n, opt)
+		if e.comparison != nil {
+			e.comparison.dropped.Add(ctx, n, opt)
+		}
+		return
+	}
+	e.processedCount.Add(ctx, n, opt)
+	if e.comparison != nil {
+		e.comparison.processed.Add(ctx, n, opt)
+	}
+}
+
+// ProcessedMap records processed evidence with attributes built from m.
+func (e *EvidenceObserver) ProcessedMap(ctx context.Context, m map[string]string) {
+	e.Processed(ctx, mapAttributes(m)...)
+}
+
+// DroppedMap records dropped evidence with attributes built from m.
+func (e *EvidenceObserver) DroppedMap(ctx context.Context, m map[string]string) {
+	e.Dropped(ctx, mapAttributes(m)...)
+}
+
+// mapAttributes converts m into string attributes ordered by key.
+func mapAttributes(m map[string]string) []attribute.KeyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, attribute.String(k, m[k]))
+	}
+	return attrs
+}
+
+// measurementAttrs applies the configured attribute rules to attrs and extra.
+func (e *EvidenceObserver) measurementAttrs(attrs []attribute.KeyValue, extra ...attribute.KeyValue) metric.MeasurementOption {
+	return metric.WithAttributes(e.attributes(attrs, extra...)...)
+}
+
+// attributes returns the attribute set recorded for the call-site attrs followed by
+// the method-specific extra attributes, including any attributes contributed by the
+// observer configuration. Neither input slice is modified.
+func (e *EvidenceObserver) attributes(attrs []attribute.KeyValue, extra ...attribute.KeyValue) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, 0, len(e.cfg.static)+len(attrs)+len(extra))
+	out = append(out, e.cfg.static...)
+	for _, kv := range append(attrs[:len(attrs):len(attrs)], extra...) {
+		if kv.Key == CloudAccountIDKey && e.cfg.accountBuckets > 0 {
+			kv = CloudAccountKey.String(bucketOf(kv.Value.Emit(), e.cfg.accountBuckets))
+		}
+		out = append(out, e.limitCardinality(kv))
+	}
+	return out
 }
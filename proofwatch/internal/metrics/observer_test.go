@@ -19,7 +19,7 @@ type evidenceObserverTestFixture struct {
 	t        *testing.T
 }
 
-func setupEvidenceObserverTest(t *testing.T) *evidenceObserverTestFixture {
+func setupEvidenceObserverTest(t *testing.T, opts ...Option) *evidenceObserverTestFixture {
 	t.Helper()
 
 	reader := sdkmetric.NewManualReader()
@@ -27,7 +27,7 @@ func setupEvidenceObserverTest(t *testing.T) *evidenceObserverTestFixture {
 	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
 
 	meter := mp.Meter("test-meter")
-	observer, err := NewEvidenceObserver(meter)
+	observer, err := NewEvidenceObserver(meter, opts...)
 	require.NoError(t, err)
 
 	return &evidenceObserverTestFixture{
@@ -46,6 +46,109 @@ func (f *evidenceObserverTestFixture) collectMetrics(ctx context.Context) metric
 	return rm
 }
 
+// collected reports whether a metric with the given name was collected.
+func (f *evidenceObserverTestFixture) collected(ctx context.Context, name string) bool {
+	f.t.Helper()
+
+	for _, sm := range f.collectMetrics(ctx).ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// metric returns the collected metric with the given name.
+func (f *evidenceObserverTestFixture) metric(ctx context.Context, name string) metricdata.Metrics {
+	f.t.Helper()
+
+	rm := f.collectMetrics(ctx)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+	require.FailNowf(f.t, "metric not found", "expected metric %q to be collected", name)
+	return metricdata.Metrics{}
+}
+
+// int64Points returns the data points of the named int64 sum metric.
+func (f *evidenceObserverTestFixture) int64Points(ctx context.Context, name string) []metricdata.DataPoint[int64] {
+	f.t.Helper()
+
+	sum, ok := f.metric(ctx, name).Data.(metricdata.Sum[int64])
+	require.True(f.t, ok, "expected %q to be an int64 sum", name)
+	return sum.DataPoints
+}
+
+// float64Points returns the data points of the named float64 sum metric.
+func (f *evidenceObserverTestFixture) float64Points(ctx context.Context, name string) []metricdata.DataPoint[float64] {
+	f.t.Helper()
+
+	sum, ok := f.metric(ctx, name).Data.(metricdata.Sum[float64])
+	require.True(f.t, ok, "expected %q to be a float64 sum", name)
+	return sum.DataPoints
+}
+
+// int64Histogram returns the data points of the named int64 histogram metric.
+func (f *evidenceObserverTestFixture) int64Histogram(ctx context.Context, name string) []metricdata.HistogramDataPoint[int64] {
+	f.t.Helper()
+
+	hist, ok := f.metric(ctx, name).Data.(metricdata.Histogram[int64])
+	require.True(f.t, ok, "expected %q to be an int64 histogram", name)
+	return hist.DataPoints
+}
+
+// float64Histogram returns the data points of the named float64 histogram metric.
+func (f *evidenceObserverTestFixture) float64Histogram(ctx context.Context, name string) []metricdata.HistogramDataPoint[float64] {
+	f.t.Helper()
+
+	hist, ok := f.metric(ctx, name).Data.(metricdata.Histogram[float64])
+	require.True(f.t, ok, "expected %q to be a float64 histogram", name)
+	return hist.DataPoints
+}
+
+// int64Gauge returns the data points of the named int64 gauge metric.
+func (f *evidenceObserverTestFixture) int64Gauge(ctx context.Context, name string) []metricdata.DataPoint[int64] {
+	f.t.Helper()
+
+	gauge, ok := f.metric(ctx, name).Data.(metricdata.Gauge[int64])
+	require.True(f.t, ok, "expected %q to be an int64 gauge", name)
+	return gauge.DataPoints
+}
+
+// float64Gauge returns the data points of the named float64 gauge metric.
+func (f *evidenceObserverTestFixture) float64Gauge(ctx context.Context, name string) []metricdata.DataPoint[float64] {
+	f.t.Helper()
+
+	gauge, ok := f.metric(ctx, name).Data.(metricdata.Gauge[float64])
+	require.True(f.t, ok, "expected %q to be a float64 gauge", name)
+	return gauge.DataPoints
+}
+
+// sumByAttr totals the data point values grouped by the value of key.
+func sumByAttr(points []metricdata.DataPoint[int64], key attribute.Key) map[string]int64 {
+	got := map[string]int64{}
+	for _, dp := range points {
+		v, _ := attrValue(dp.Attributes, key)
+		got[v] += dp.Value
+	}
+	return got
+}
+
+// attrValue returns the string value of key on the data point attribute set.
+func attrValue(set attribute.Set, key attribute.Key) (string, bool) {
+	v, ok := set.Value(key)
+	if !ok {
+		return "", false
+	}
+	return v.Emit(), true
+}
+
 func (f *evidenceObserverTestFixture) assertMetricsRecorded(ctx context.Context) {
 	f.t.Helper()
 
@@ -265,3 +368,34 @@ func TestEvidenceObserverWithContext(t *testing.T) {
 		})
 	}
 }
+
+func TestEvidenceObserverMapRecording(t *testing.T) {
+	ctx := context.Background()
+	m := map[string]string{
+		"policy.id":                "test-policy",
+		"policy.evaluation.status": "pass",
+		"reason":                   "timeout",
+	}
+	variadic := []attribute.KeyValue{
+		attribute.String("policy.evaluation.status", "pass"),
+		attribute.String("policy.id", "test-policy"),
+		attribute.String("reason", "timeout"),
+	}
+
+	mapFixture := setupEvidenceObserverTest(t, WithCloudContext("gcp", 4))
+	mapFixture.observer.ProcessedMap(ctx, m)
+	mapFixture.observer.DroppedMap(ctx, m)
+
+	variadicFixture := setupEvidenceObserverTest(t, WithCloudContext("gcp", 4))
+	variadicFixture.observer.Processed(ctx, variadic...)
+	variadicFixture.observer.Dropped(ctx, variadic...)
+
+	for _, name := range []string{"evidence_processed_count", "evidence_dropped_count"} {
+		got := mapFixture.int64Points(ctx, name)
+		want := variadicFixture.int64Points(ctx, name)
+		require.Len(t, got, 1, name)
+		require.Len(t, want, 1, name)
+		assert.True(t, want[0].Attributes.Equals(&got[0].Attributes), name)
+		assert.Equal(t, want[0].Value, got[0].Value, name)
+	}
+}
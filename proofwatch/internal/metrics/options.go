@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures an EvidenceObserver.
+type Option func(*observerConfig)
+
+// defaultAccountBuckets is the number of cloud account buckets used when
+// WithCloudContext is given a non-positive bucket count.
+const defaultAccountBuckets = 16
+
+type observerConfig struct {
+	// err collects option validation failures reported by NewEvidenceObserver.
+	err error
+	// static attributes are added to every recording.
+	static           []attribute.KeyValue
+	accountBuckets   int
+	sampler          Sampler
+	sampleWeight     *sampleWeight
+	allowed          map[attribute.Key]boundedSet
+	strictTransport  bool
+	burnRate         *burnRateTracker
+	workerBuckets    int
+	comparisonMeter  metric.Meter
+	clientSalt       []byte
+	clientBuckets    int
+	waivers          map[string]time.Time
+	subjectBuckets   int
+	sequenceSubjects int
+	boundary         string
+	degradedMode     bool
+	namePrefix       string
+	noDuration       bool
+	flusher          Flusher
+	partitions       int
+	cardinality      map[attribute.Key]*cardinalityLimiter
+	controlChars     ControlCharPolicy
+	tracer           trace.Tracer
+}
+
+// fail records an option validation error.
+func (cfg *observerConfig) fail(err error) {
+	cfg.err = errors.Join(cfg.err, err)
+}
+
+// addStatic adds attrs to the static attributes. An attribute whose key is already
+// present replaces the earlier value in place, so the last value set for a key wins
+// and the attribute order stays deterministic.
+func (cfg *observerConfig) addStatic(attrs ...attribute.KeyValue) {
+	for _, kv := range attrs {
+		i := slices.IndexFunc(cfg.static, func(existing attribute.KeyValue) bool { return existing.Key == kv.Key })
+		if i >= 0 {
+			cfg.static[i] = kv
+			continue
+		}
+		cfg.static = append(cfg.static, kv)
+	}
+}
+
+// WithDefaultAttributes adds attrs, such as the service or tenant, to every recording.
+// Duplicate keys are deduplicated, keeping the last value given for each key.
+func WithDefaultAttributes(attrs ...attribute.KeyValue) Option {
+	return func(cfg *observerConfig) {
+		cfg.addStatic(attrs...)
+	}
+}
+
+// WithCloudContext segments all recordings by cloud provider and account.
+// The provider is normalized against the known cloud providers, and any
+// cloud.account.id attribute is replaced by a cloud.account bucket in the
+// range [0, accountBuckets) so account cardinality stays bounded.
+func WithCloudContext(provider string, accountBuckets int) Option {
+	return func(cfg *observerConfig) {
+		if accountBuckets <= 0 {
+			accountBuckets = defaultAccountBuckets
+		}
+		cfg.addStatic(CloudProviderKey.String(normalizeCloudProvider(provider)))
+		cfg.accountBuckets = accountBuckets
+	}
+}
+
+// WithAllowedValues registers the values accepted for a bounded attribute key.
+// Values recorded for key outside the registered set, and outside any built-in
+// defaults for that key, collapse to "other". Registering a key again extends its set.
+func WithAllowedValues(key attribute.Key, values ...string) Option {
+	return func(cfg *observerConfig) {
+		if cfg.allowed == nil {
+			cfg.allowed = make(map[attribute.Key]boundedSet)
+		}
+		set, ok := cfg.allowed[key]
+		if !ok {
+			set = newBoundedSet()
+			cfg.allowed[key] = set
+		}
+		for _, v := range values {
+			set[v] = struct{}{}
+		}
+	}
+}
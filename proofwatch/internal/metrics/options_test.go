@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestWithCloudContext(t *testing.T) {
+	const buckets = 4
+
+	t.Run("provider and bucketed account", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithCloudContext("AWS", buckets))
+		ctx := context.Background()
+
+		for i := 0; i < 50; i++ {
+			fixture.observer.Processed(ctx, CloudAccountIDKey.String("account-"+strconv.Itoa(i)))
+		}
+		fixture.observer.Dropped(ctx, CloudAccountIDKey.String("account-0"))
+
+		points := fixture.int64Points(ctx, "evidence_processed_count")
+		assert.LessOrEqual(t, len(points), buckets)
+
+		var total int64
+		for _, dp := range points {
+			provider, ok := attrValue(dp.Attributes, CloudProviderKey)
+			require.True(t, ok)
+			assert.Equal(t, "aws", provider)
+
+			account, ok := attrValue(dp.Attributes, CloudAccountKey)
+			require.True(t, ok)
+			n, err := strconv.Atoi(account)
+			require.NoError(t, err)
+			assert.True(t, n >= 0 && n < buckets, "bucket %d out of bounds", n)
+
+			_, raw := dp.Attributes.Value(CloudAccountIDKey)
+			assert.False(t, raw, "raw account id must not be recorded")
+			total += dp.Value
+		}
+		assert.Equal(t, int64(50), total)
+
+		dropped := fixture.int64Points(ctx, "evidence_dropped_count")
+		require.Len(t, dropped, 1)
+		provider, _ := attrValue(dropped[0].Attributes, CloudProviderKey)
+		assert.Equal(t, "aws", provider)
+	})
+
+	t.Run("unknown provider is bounded", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithCloudContext("my-private-cloud", buckets))
+		ctx := context.Background()
+
+		fixture.observer.Processed(ctx, attribute.String("test", "value"))
+
+		points := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, points, 1)
+		provider, _ := attrValue(points[0].Attributes, CloudProviderKey)
+		assert.Equal(t, "other", provider)
+	})
+}
+
+func TestWithDefaultAttributes(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t,
+		WithDefaultAttributes(attribute.String("env", "staging"), attribute.String("tenant", "acme")),
+		WithDefaultAttributes(attribute.String("env", "prod")),
+	)
+	ctx := context.Background()
+
+	fixture.observer.Processed(ctx, attribute.String("policy.id", "AC-1"))
+
+	points := fixture.int64Points(ctx, "evidence_processed_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, attribute.NewSet(
+		attribute.String("env", "prod"),
+		attribute.String("tenant", "acme"),
+		attribute.String("policy.id", "AC-1"),
+	), points[0].Attributes)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("env", "prod"),
+		attribute.String("tenant", "acme"),
+	}, fixture.observer.cfg.static)
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// defaultSequenceSubjects is the number of subjects whose sequence numbers are
+// remembered unless WithSequenceSubjects is set.
+const defaultSequenceSubjects = 10000
+
+// WithSequenceSubjects sets the number of subjects whose highest sequence number is
+// remembered by ObserveSequence. Once full, the least recently seen subject is
+// forgotten, so its next sequence number is accepted as in order. Non-positive values
+// keep the default of 10000.
+func WithSequenceSubjects(n int) Option {
+	return func(cfg *observerConfig) {
+		if n > 0 {
+			cfg.sequenceSubjects = n
+		}
+	}
+}
+
+// sequences tracks the highest sequence number processed per subject, for a bounded
+// number of recently seen subjects.
+type sequences struct {
+	mu   sync.Mutex
+	last *LRU[uint64]
+}
+
+// observe records seq for subject and reports whether it arrived in order, that is
+// after every sequence number previously observed for subject.
+func (s *sequences) observe(subject string, seq uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.last.Get(subject); ok && seq <= last {
+		return false
+	}
+	s.last.Put(subject, seq)
+	return true
+}
+
+func (e *EvidenceObserver) initOrdering(meter metric.Meter) error {
+	var err error
+	e.orderingCounter, err = meter.Int64Counter(
+		"ordering_violation_count",
+		metric.WithDescription("The total number of evidence items processed out of order for their subject."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create ordering violation counter: %w", err)
+	}
+	size := e.cfg.sequenceSubjects
+	if size <= 0 {
+		size = defaultSequenceSubjects
+	}
+	e.sequences = &sequences{last: NewLRU[uint64](size)}
+	return nil
+}
+
+// ObserveSequence compares seq with the sequence numbers previously observed for
+// subject and records an ordering violation when it does not advance them. It
+// reports whether the evidence was processed in order. Only the subjects most recently
+// seen are remembered, as set with WithSequenceSubjects.
+func (e *EvidenceObserver) ObserveSequence(ctx context.Context, subject string, seq uint64, attrs ...attribute.KeyValue) bool {
+	if e.sequences.observe(subject, seq) {
+		return true
+	}
+	e.RecordOrderingViolation(ctx, subject, attrs...)
+	return false
+}
+
+// RecordOrderingViolation records evidence for subject processed out of order.
+// Subjects are recorded as buckets; raw subjects are never recorded.
+func (e *EvidenceObserver) RecordOrderingViolation(ctx context.Context, subject string, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.orderingCounter.Add(ctx, 1, e.measurementAttrs(attrs, e.subjectBucket(subject)))
+}
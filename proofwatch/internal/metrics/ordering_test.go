@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveSequence(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	for _, seq := range []uint64{1, 2, 3, 7} {
+		assert.True(t, fixture.observer.ObserveSequence(ctx, "host-a", seq), seq)
+	}
+	assert.True(t, fixture.observer.ObserveSequence(ctx, "host-b", 1), "sequences are tracked per subject")
+	assert.Empty(t, fixture.collectMetrics(ctx).ScopeMetrics)
+
+	assert.False(t, fixture.observer.ObserveSequence(ctx, "host-a", 5))
+	assert.False(t, fixture.observer.ObserveSequence(ctx, "host-a", 7), "duplicates are out of order")
+	assert.True(t, fixture.observer.ObserveSequence(ctx, "host-a", 8))
+
+	points := fixture.int64Points(ctx, "ordering_violation_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(2), points[0].Value)
+	bucket, _ := attrValue(points[0].Attributes, SubjectBucketKey)
+	assert.Equal(t, bucketOf("host-a", defaultSubjectBuckets), bucket)
+}
+
+func TestObserveSequenceForgetsSubjects(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithSequenceSubjects(1))
+	ctx := context.Background()
+
+	assert.True(t, fixture.observer.ObserveSequence(ctx, "host-a", 5))
+	assert.False(t, fixture.observer.ObserveSequence(ctx, "host-a", 4))
+	assert.True(t, fixture.observer.ObserveSequence(ctx, "host-b", 1))
+	assert.True(t, fixture.observer.ObserveSequence(ctx, "host-a", 4), "host-a was forgotten for host-b")
+}
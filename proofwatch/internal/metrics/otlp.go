@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"google.golang.org/grpc/credentials"
+)
+
+// defaultOTLPEndpoint is the OTLP/gRPC endpoint used when neither the config nor the
+// environment sets one.
+const defaultOTLPEndpoint = "localhost:4317"
+
+// defaultStartupCheckTimeout bounds the startup reachability check.
+const defaultStartupCheckTimeout = 5 * time.Second
+
+// OTLPConfig configures the OTLP/gRPC metric exporter created by NewOTLPMeterProvider.
+// Zero values defer to the standard OTEL_EXPORTER_OTLP_* and OTEL_METRIC_EXPORT_*
+// environment variables, and then to the exporter defaults.
+type OTLPConfig struct {
+	// Endpoint is the collector address, as host:port or a URL.
+	Endpoint string
+	// Headers are sent with every export request.
+	Headers map[string]string
+	// Insecure disables transport security.
+	Insecure bool
+	// TLSConfig is the client TLS configuration used when Insecure is false.
+	TLSConfig *tls.Config
+	// Interval is the time between periodic exports.
+	Interval time.Duration
+}
+
+// OTLPOption configures NewOTLPMeterProvider beyond the exporter settings.
+type OTLPOption func(*otlpOptions)
+
+type otlpOptions struct {
+	startupCheck   bool
+	startupTimeout time.Duration
+	exportCallback func(error)
+	exponential    bool
+}
+
+// WithStartupCheck makes NewOTLPMeterProvider fail fast when the collector endpoint
+// cannot be reached within timeout. A non-positive timeout uses five seconds.
+func WithStartupCheck(timeout time.Duration) OTLPOption {
+	return func(o *otlpOptions) {
+		o.startupCheck = true
+		o.startupTimeout = timeout
+	}
+}
+
+// WithExportCallback calls fn with the outcome of every export: nil when it succeeded,
+// and the export error otherwise.
+func WithExportCallback(fn func(error)) OTLPOption {
+	return func(o *otlpOptions) {
+		o.exportCallback = fn
+	}
+}
+
+// WithExponentialDurationHistogram exports evidence_processing_duration_seconds as a
+// base-2 exponential histogram, using ExponentialDurationView, instead of over explicit
+// buckets.
+func WithExponentialDurationHistogram() OTLPOption {
+	return func(o *otlpOptions) {
+		o.exponential = true
+	}
+}
+
+// NewOTLPMeterProvider creates a MeterProvider that periodically pushes metrics to an
+// OpenTelemetry collector over OTLP/gRPC. Pass provider.Meter(...) to
+// NewEvidenceObserver and call Shutdown on the provider before exiting.
+func NewOTLPMeterProvider(ctx context.Context, cfg OTLPConfig, opts ...OTLPOption) (*sdkmetric.MeterProvider, error) {
+	var o otlpOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.startupCheck {
+		timeout := o.startupTimeout
+		if timeout <= 0 {
+			timeout = defaultStartupCheckTimeout
+		}
+		if err := checkOTLPEndpoint(ctx, otlpEndpoint(cfg.Endpoint), timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	var exporterOpts []otlpmetricgrpc.Option
+	if cfg.Endpoint != "" {
+		if u, err := url.Parse(cfg.Endpoint); err == nil && u.Scheme != "" && u.Host != "" {
+			exporterOpts = append(exporterOpts, otlpmetricgrpc.WithEndpointURL(cfg.Endpoint))
+		} else {
+			exporterOpts = append(exporterOpts, otlpmetricgrpc.WithEndpoint(cfg.Endpoint))
+		}
+	}
+	if len(cfg.Headers) > 0 {
+		exporterOpts = append(exporterOpts, otlpmetricgrpc.WithHeaders(cfg.Headers))
+	}
+	switch {
+	case cfg.Insecure:
+		exporterOpts = append(exporterOpts, otlpmetricgrpc.WithInsecure())
+	case cfg.TLSConfig != nil:
+		exporterOpts = append(exporterOpts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(cfg.TLSConfig)))
+	}
+
+	exporter, err := otlpmetricgrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp metric exporter: %w", err)
+	}
+
+	var readerOpts []sdkmetric.PeriodicReaderOption
+	if cfg.Interval > 0 {
+		readerOpts = append(readerOpts, sdkmetric.WithInterval(cfg.Interval))
+	}
+	var wrapped sdkmetric.Exporter = exporter
+	if o.exportCallback != nil {
+		wrapped = WrapExporter(exporter, o.exportCallback)
+	}
+	providerOpts := []sdkmetric.Option{sdkmetric.WithReader(sdkmetric.NewPeriodicReader(wrapped, readerOpts...))}
+	if o.exponential {
+		providerOpts = append(providerOpts, sdkmetric.WithView(ExponentialDurationView()))
+	}
+	return sdkmetric.NewMeterProvider(providerOpts...), nil
+}
+
+// otlpEndpoint resolves the endpoint the exporter connects to, following the same
+// precedence as the exporter: explicit config, then the metrics-specific and generic
+// environment variables, then the default.
+func otlpEndpoint(endpoint string) string {
+	for _, v := range []string{
+		endpoint,
+		os.Getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"),
+		os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+	} {
+		if v != "" {
+			return v
+		}
+	}
+	return defaultOTLPEndpoint
+}
+
+// checkOTLPEndpoint dials endpoint to verify the collector is reachable.
+func checkOTLPEndpoint(ctx context.Context, endpoint string, timeout time.Duration) error {
+	address := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Scheme != "" && u.Host != "" {
+		address = u.Host
+		if u.Port() == "" {
+			address = net.JoinHostPort(u.Hostname(), "4317")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("otlp endpoint %q is unreachable: %w", endpoint, err)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+)
+
+// fakeOTLPReceiver is an in-process OTLP/gRPC metrics receiver recording exported metric names.
+type fakeOTLPReceiver struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+func (r *fakeOTLPReceiver) Export(_ context.Context, req *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rm := range req.GetResourceMetrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				r.names[m.GetName()] = true
+			}
+		}
+	}
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (r *fakeOTLPReceiver) received(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.names[name]
+}
+
+func startFakeOTLPReceiver(t *testing.T) (*fakeOTLPReceiver, string) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	receiver := &fakeOTLPReceiver{names: make(map[string]bool)}
+	server := grpc.NewServer()
+	collectormetrics.RegisterMetricsServiceServer(server, receiver)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return receiver, lis.Addr().String()
+}
+
+func TestNewOTLPMeterProvider(t *testing.T) {
+	t.Run("exports processed counter", func(t *testing.T) {
+		receiver, endpoint := startFakeOTLPReceiver(t)
+		ctx := context.Background()
+
+		provider, err := NewOTLPMeterProvider(ctx,
+			OTLPConfig{Endpoint: endpoint, Insecure: true, Interval: time.Hour},
+			WithStartupCheck(time.Second),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+		observer, err := NewEvidenceObserver(provider.Meter("test-meter"))
+		require.NoError(t, err)
+		observer.Processed(ctx)
+
+		require.NoError(t, provider.ForceFlush(ctx))
+		assert.True(t, receiver.received("evidence_processed_count"))
+	})
+
+	t.Run("honors environment endpoint", func(t *testing.T) {
+		receiver, endpoint := startFakeOTLPReceiver(t)
+		t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "http://"+endpoint)
+		ctx := context.Background()
+
+		provider, err := NewOTLPMeterProvider(ctx, OTLPConfig{Interval: time.Hour}, WithStartupCheck(time.Second))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+		observer, err := NewEvidenceObserver(provider.Meter("test-meter"))
+		require.NoError(t, err)
+		observer.Dropped(ctx)
+
+		require.NoError(t, provider.ForceFlush(ctx))
+		assert.True(t, receiver.received("evidence_dropped_count"))
+	})
+
+	t.Run("startup check fails fast on unreachable endpoint", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		endpoint := lis.Addr().String()
+		require.NoError(t, lis.Close())
+
+		provider, err := NewOTLPMeterProvider(context.Background(),
+			OTLPConfig{Endpoint: endpoint, Insecure: true},
+			WithStartupCheck(time.Second),
+		)
+		assert.ErrorContains(t, err, "unreachable")
+		assert.Nil(t, provider)
+	})
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// parallelismBuckets resolve each small degree of parallelism individually.
+var parallelismBuckets = []float64{1, 2, 3, 4, 6, 8, 12, 16, 24, 32, 64}
+
+func (e *EvidenceObserver) initParallelism(meter metric.Meter) error {
+	var err error
+	e.parallelism, err = meter.Int64Histogram(
+		"evaluation_parallelism",
+		metric.WithDescription("The degree of parallelism achieved by policy evaluation."),
+		metric.WithExplicitBucketBoundaries(parallelismBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create parallelism histogram: %w", err)
+	}
+	return nil
+}
+
+// RecordParallelism records the degree of parallelism achieved by an evaluation.
+// A negative degree is recorded as zero.
+func (e *EvidenceObserver) RecordParallelism(ctx context.Context, degree int, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.parallelism.Record(ctx, int64(max(degree, 0)), e.measurementAttrs(attrs))
+}
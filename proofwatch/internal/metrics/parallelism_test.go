@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordParallelism(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	for _, degree := range []int{1, 2, 2, 3, 8, 100, -1} {
+		fixture.observer.RecordParallelism(ctx, degree)
+	}
+
+	hist := fixture.int64Histogram(ctx, "evaluation_parallelism")
+	require.Len(t, hist, 1)
+	assert.Equal(t, uint64(7), hist[0].Count)
+	assert.Equal(t, int64(116), hist[0].Sum)
+	// Buckets: (-inf,1] (1,2] (2,3] (3,4] (4,6] (6,8] (8,12] (12,16] (16,24] (24,32] (32,64] (64,+inf)
+	assert.Equal(t, []uint64{2, 2, 1, 0, 0, 1, 0, 0, 0, 0, 0, 1}, hist[0].BucketCounts)
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PartitionKey is the bounded processing partition attribute.
+const PartitionKey = attribute.Key("partition")
+
+// WithPartitions sets the number of partitions evidence processing is split across,
+// bounding the partitions accepted by ProcessedByPartition to [0, n). A non-positive
+// count makes NewEvidenceObserver return an error.
+func WithPartitions(n int) Option {
+	return func(cfg *observerConfig) {
+		if n <= 0 {
+			cfg.fail(fmt.Errorf("invalid partition count %d: must be positive", n))
+			return
+		}
+		cfg.partitions = n
+	}
+}
+
+// ProcessedByPartition records processed evidence attributed to partition. It returns
+// an error and records nothing when partition is outside the range configured with
+// WithPartitions, or when no partitions were configured.
+func (e *EvidenceObserver) ProcessedByPartition(ctx context.Context, partition int, attrs ...attribute.KeyValue) error {
+	if partition < 0 || partition >= e.cfg.partitions {
+		return fmt.Errorf("partition %d out of range [0, %d)", partition, e.cfg.partitions)
+	}
+	e.Processed(ctx, append(attrs[:len(attrs):len(attrs)], PartitionKey.Int(partition))...)
+	return nil
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestProcessedByPartition(t *testing.T) {
+	t.Run("records per-partition counts", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithPartitions(4))
+		ctx := context.Background()
+
+		for _, partition := range []int{0, 1, 1, 3, 3, 3} {
+			require.NoError(t, fixture.observer.ProcessedByPartition(ctx, partition))
+		}
+
+		got := sumByAttr(fixture.int64Points(ctx, "evidence_processed_count"), PartitionKey)
+		assert.Equal(t, map[string]int64{"0": 1, "1": 2, "3": 3}, got)
+	})
+
+	t.Run("rejects out of range partitions", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithPartitions(4))
+		ctx := context.Background()
+
+		for _, partition := range []int{-1, 4, 100} {
+			assert.Error(t, fixture.observer.ProcessedByPartition(ctx, partition), partition)
+		}
+		assert.False(t, fixture.collected(ctx, "evidence_processed_count"))
+	})
+
+	t.Run("rejects invalid partition counts", func(t *testing.T) {
+		meter := sdkmetric.NewMeterProvider().Meter("test-meter")
+
+		observer, err := NewEvidenceObserver(meter, WithPartitions(0))
+		assert.Error(t, err)
+		assert.Nil(t, observer)
+	})
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RegisterPassRateGauge registers the evidence_pass_rate gauge. At each collection,
+// rates is called for the current pass rate of each policy, as a percentage, and each
+// is reported under its policy.id.
+func (e *EvidenceObserver) RegisterPassRateGauge(rates func() map[string]float64) error {
+	_, err := (*e.meter).Float64ObservableGauge(
+		"evidence_pass_rate",
+		metric.WithDescription("The percentage of evaluations passing for each policy over a sliding window."),
+		metric.WithUnit("%"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			for policyID, rate := range rates() {
+				o.Observe(rate, e.measurementAttrs(nil, PolicyIDKey.String(policyID)))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pass rate gauge: %w", err)
+	}
+	return nil
+}
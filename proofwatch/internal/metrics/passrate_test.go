@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterPassRateGauge(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	rates := map[string]float64{"policy-a": 75, "policy-b": 0}
+	require.NoError(t, fixture.observer.RegisterPassRateGauge(func() map[string]float64 { return rates }))
+
+	got := map[string]float64{}
+	for _, dp := range fixture.float64Gauge(ctx, "evidence_pass_rate") {
+		policyID, _ := attrValue(dp.Attributes, PolicyIDKey)
+		got[policyID] = dp.Value
+	}
+	assert.Equal(t, rates, got)
+
+	rates = nil
+	assert.False(t, fixture.collected(ctx, "evidence_pass_rate"))
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// policyAgeBuckets span one hour to one year, in seconds.
+var policyAgeBuckets = []float64{
+	(1 * time.Hour).Seconds(),
+	(6 * time.Hour).Seconds(),
+	(24 * time.Hour).Seconds(),
+	(7 * 24 * time.Hour).Seconds(),
+	(30 * 24 * time.Hour).Seconds(),
+	(90 * 24 * time.Hour).Seconds(),
+	(180 * 24 * time.Hour).Seconds(),
+	(365 * 24 * time.Hour).Seconds(),
+}
+
+func (e *EvidenceObserver) initPolicyAge(meter metric.Meter) error {
+	var err error
+	e.policyAge, err = meter.Float64Histogram(
+		"policy_age_seconds",
+		metric.WithDescription("The age of the policy that evaluated each evidence item."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(policyAgeBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create policy age histogram: %w", err)
+	}
+	return nil
+}
+
+// RecordPolicyAge records how old the policy that evaluated evidence was, so
+// evaluations using stale policies can be flagged. A negative age is recorded as zero.
+func (e *EvidenceObserver) RecordPolicyAge(ctx context.Context, policyAge time.Duration, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.policyAge.Record(ctx, max(policyAge, 0).Seconds(), e.measurementAttrs(attrs))
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordPolicyAge(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	day := 24 * time.Hour
+	for _, age := range []time.Duration{30 * time.Minute, 2 * day, 2 * day, 45 * day, 400 * day, -time.Hour} {
+		fixture.observer.RecordPolicyAge(ctx, age)
+	}
+
+	hist := fixture.float64Histogram(ctx, "policy_age_seconds")
+	require.Len(t, hist, 1)
+	assert.Equal(t, uint64(6), hist[0].Count)
+	// Buckets: (-inf,1h] (1h,6h] (6h,1d] (1d,7d] (7d,30d] (30d,90d] (90d,180d] (180d,365d] (365d,+inf)
+	assert.Equal(t, []uint64{2, 0, 0, 2, 0, 1, 0, 0, 1}, hist[0].BucketCounts)
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// PolicyLoadReasonKey is the bounded policy load failure reason attribute.
+const PolicyLoadReasonKey = attribute.Key("policy.load.reason")
+
+var policyLoadReasons = newBoundedSet("syntax_error", "missing_dependency", "permission_denied")
+
+func (e *EvidenceObserver) initPolicyLoad(meter metric.Meter) error {
+	var err error
+	e.policyLoadFailures, err = meter.Int64Counter(
+		"policy_load_failure_count",
+		metric.WithDescription("The total number of policies that failed to load, by reason."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create policy load failure counter: %w", err)
+	}
+	return nil
+}
+
+// RecordPolicyLoadFailure records a policy that failed to load because of reason,
+// one of syntax_error, missing_dependency or permission_denied. Other reasons are
+// recorded as "other".
+func (e *EvidenceObserver) RecordPolicyLoadFailure(ctx context.Context, reason string, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.policyLoadFailures.Add(ctx, 1, e.measurementAttrs(attrs, e.bounded(PolicyLoadReasonKey, reason, policyLoadReasons)))
+}
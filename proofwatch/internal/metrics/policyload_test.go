@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordPolicyLoadFailure(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	for _, reason := range []string{"syntax_error", "syntax_error", "missing_dependency", "permission_denied", "disk_full"} {
+		fixture.observer.RecordPolicyLoadFailure(ctx, reason)
+	}
+
+	got := sumByAttr(fixture.int64Points(ctx, "policy_load_failure_count"), PolicyLoadReasonKey)
+	assert.Equal(t, map[string]int64{"syntax_error": 2, "missing_dependency": 1, "permission_denied": 1, "other": 1}, got)
+}
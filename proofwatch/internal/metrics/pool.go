@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+func (e *EvidenceObserver) initPool(meter metric.Meter) error {
+	var err error
+	e.queueDepth, err = meter.Int64UpDownCounter(
+		"evidence_queue_depth",
+		metric.WithDescription("The number of evidence items waiting for a worker."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create queue depth counter: %w", err)
+	}
+
+	e.activeWorkers, err = meter.Int64UpDownCounter(
+		"evidence_active_workers",
+		metric.WithDescription("The number of workers currently processing evidence."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create active workers counter: %w", err)
+	}
+
+	e.queueWait, err = meter.Float64Histogram(
+		"evidence_queue_wait_seconds",
+		metric.WithDescription("The time evidence items wait in the queue before a worker picks them up."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create queue wait histogram: %w", err)
+	}
+	return nil
+}
+
+// Enqueued marks an evidence item as waiting for a worker. Every call must be paired
+// with a call to Dequeued once a worker picks the item up, or to Unqueued if the item
+// leaves the queue otherwise.
+func (e *EvidenceObserver) Enqueued(ctx context.Context) {
+	if !e.recording() {
+		return
+	}
+	e.queueDepth.Add(ctx, 1, e.measurementAttrs(nil))
+}
+
+// Dequeued marks an evidence item queued with Enqueued as picked up by a worker after
+// waiting for wait. A negative wait is recorded as zero.
+func (e *EvidenceObserver) Dequeued(ctx context.Context, wait time.Duration) {
+	if !e.recording() {
+		return
+	}
+	opt := e.measurementAttrs(nil)
+	e.queueDepth.Add(ctx, -1, opt)
+	e.queueWait.Record(ctx, max(wait, 0).Seconds(), opt)
+}
+
+// Unqueued marks an evidence item marked with Enqueued as no longer waiting without a
+// worker picking it up, such as when the queue was full. Unlike Dequeued, it records no
+// queue wait.
+func (e *EvidenceObserver) Unqueued(ctx context.Context) {
+	if !e.recording() {
+		return
+	}
+	e.queueDepth.Add(ctx, -1, e.measurementAttrs(nil))
+}
+
+// WorkerStarted marks a worker as busy. Every call must be paired with a call to
+// WorkerFinished.
+func (e *EvidenceObserver) WorkerStarted(ctx context.Context) {
+	if !e.recording() {
+		return
+	}
+	e.activeWorkers.Add(ctx, 1, e.measurementAttrs(nil))
+}
+
+// WorkerFinished marks a worker started with WorkerStarted as idle.
+func (e *EvidenceObserver) WorkerFinished(ctx context.Context) {
+	if !e.recording() {
+		return
+	}
+	e.activeWorkers.Add(ctx, -1, e.measurementAttrs(nil))
+}
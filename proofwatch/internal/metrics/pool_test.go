@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolMetrics(t *testing.T) {
+	t.Run("tracks queue depth and wait time", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		fixture.observer.Enqueued(ctx)
+		fixture.observer.Enqueued(ctx)
+		fixture.observer.Dequeued(ctx, 20*time.Millisecond)
+
+		points := fixture.int64Points(ctx, "evidence_queue_depth")
+		require.Len(t, points, 1)
+		assert.Equal(t, int64(1), points[0].Value)
+
+		waits := fixture.float64Histogram(ctx, "evidence_queue_wait_seconds")
+		require.Len(t, waits, 1)
+		assert.Equal(t, uint64(1), waits[0].Count)
+		assert.InDelta(t, 0.02, waits[0].Sum, 1e-9)
+	})
+
+	t.Run("unqueued items record no wait", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		fixture.observer.Enqueued(ctx)
+		fixture.observer.Enqueued(ctx)
+		fixture.observer.Unqueued(ctx)
+		fixture.observer.Dequeued(ctx, 20*time.Millisecond)
+
+		assert.Equal(t, int64(0), fixture.int64Points(ctx, "evidence_queue_depth")[0].Value)
+		waits := fixture.float64Histogram(ctx, "evidence_queue_wait_seconds")
+		require.Len(t, waits, 1)
+		assert.Equal(t, uint64(1), waits[0].Count)
+	})
+
+	t.Run("clamps negative wait", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		fixture.observer.Enqueued(ctx)
+		fixture.observer.Dequeued(ctx, -time.Second)
+
+		waits := fixture.float64Histogram(ctx, "evidence_queue_wait_seconds")
+		require.Len(t, waits, 1)
+		assert.Equal(t, 0.0, waits[0].Sum)
+	})
+
+	t.Run("tracks active workers", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		fixture.observer.WorkerStarted(ctx)
+		fixture.observer.WorkerStarted(ctx)
+		fixture.observer.WorkerFinished(ctx)
+		assert.Equal(t, int64(1), fixture.int64Points(ctx, "evidence_active_workers")[0].Value)
+
+		fixture.observer.WorkerFinished(ctx)
+		assert.Equal(t, int64(0), fixture.int64Points(ctx, "evidence_active_workers")[0].Value)
+	})
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// namePrefixPattern matches prefixes that keep instrument names valid under the
+// OpenTelemetry instrument name syntax, leaving room for the longest default name.
+var namePrefixPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_./-]{0,127}$`)
+
+// WithNamePrefix prefixes the name of every instrument created by the observer with
+// prefix and an underscore, so "complybeacon" turns evidence_processed_count into
+// complybeacon_evidence_processed_count. An empty prefix keeps the default names.
+// A prefix that would produce invalid instrument names, for example one starting
+// with a digit or containing spaces, makes NewEvidenceObserver return an error.
+func WithNamePrefix(prefix string) Option {
+	return func(cfg *observerConfig) {
+		if prefix != "" && !namePrefixPattern.MatchString(prefix) {
+			cfg.fail(fmt.Errorf("invalid metric name prefix %q: must start with a letter and contain only letters, digits, '_', '.', '/' or '-', up to 128 characters", prefix))
+			return
+		}
+		cfg.namePrefix = prefix
+	}
+}
+
+// prefixedMeter creates every instrument under a name prefix. Methods that are not
+// overridden, such as RegisterCallback, are served by the wrapped meter.
+type prefixedMeter struct {
+	metric.Meter
+	prefix string
+}
+
+// withNamePrefix returns meter unchanged when prefix is empty and a prefixedMeter otherwise.
+func withNamePrefix(meter metric.Meter, prefix string) metric.Meter {
+	if prefix == "" || meter == nil {
+		return meter
+	}
+	return &prefixedMeter{Meter: meter, prefix: prefix + "_"}
+}
+
+func (m *prefixedMeter) Int64Counter(name string, options ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return m.Meter.Int64Counter(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Int64UpDownCounter(name string, options ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	return m.Meter.Int64UpDownCounter(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Int64Histogram(name string, options ...metric.Int64HistogramOption) (metric.Int64Histogram, error) {
+	return m.Meter.Int64Histogram(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Int64Gauge(name string, options ...metric.Int64GaugeOption) (metric.Int64Gauge, error) {
+	return m.Meter.Int64Gauge(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Int64ObservableCounter(name string, options ...metric.Int64ObservableCounterOption) (metric.Int64ObservableCounter, error) {
+	return m.Meter.Int64ObservableCounter(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Int64ObservableUpDownCounter(name string, options ...metric.Int64ObservableUpDownCounterOption) (metric.Int64ObservableUpDownCounter, error) {
+	return m.Meter.Int64ObservableUpDownCounter(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Int64ObservableGauge(name string, options ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	return m.Meter.Int64ObservableGauge(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Float64Counter(name string, options ...metric.Float64CounterOption) (metric.Float64Counter, error) {
+	return m.Meter.Float64Counter(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Float64UpDownCounter(name string, options ...metric.Float64UpDownCounterOption) (metric.Float64UpDownCounter, error) {
+	return m.Meter.Float64UpDownCounter(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Float64Histogram(name string, options ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return m.Meter.Float64Histogram(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Float64Gauge(name string, options ...metric.Float64GaugeOption) (metric.Float64Gauge, error) {
+	return m.Meter.Float64Gauge(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Float64ObservableCounter(name string, options ...metric.Float64ObservableCounterOption) (metric.Float64ObservableCounter, error) {
+	return m.Meter.Float64ObservableCounter(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Float64ObservableUpDownCounter(name string, options ...metric.Float64ObservableUpDownCounterOption) (metric.Float64ObservableUpDownCounter, error) {
+	return m.Meter.Float64ObservableUpDownCounter(m.prefix+name, options...)
+}
+
+func (m *prefixedMeter) Float64ObservableGauge(name string, options ...metric.Float64ObservableGaugeOption) (metric.Float64ObservableGauge, error) {
+	return m.Meter.Float64ObservableGauge(m.prefix+name, options...)
+}
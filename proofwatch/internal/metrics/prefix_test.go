@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestWithNamePrefix(t *testing.T) {
+	t.Run("prefixes instrument names", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithNamePrefix("tenantA"))
+		ctx := context.Background()
+
+		fixture.observer.Processed(ctx)
+		fixture.observer.Dropped(ctx)
+		fixture.observer.ObserveDuration(ctx, time.Millisecond)
+
+		for _, name := range []string{"evidence_processed_count", "evidence_dropped_count", "evidence_processing_duration_seconds"} {
+			assert.True(t, fixture.collected(ctx, "tenantA_"+name), name)
+			assert.False(t, fixture.collected(ctx, name), name)
+		}
+	})
+
+	t.Run("rejects invalid prefixes", func(t *testing.T) {
+		meter := sdkmetric.NewMeterProvider().Meter("test-meter")
+
+		for _, prefix := range []string{"1tenant", "tenant A", "tenant:a", "_tenant", strings.Repeat("a", 129)} {
+			observer, err := NewEvidenceObserver(meter, WithNamePrefix(prefix))
+			assert.Error(t, err, prefix)
+			assert.Nil(t, observer)
+		}
+	})
+
+	t.Run("empty prefix keeps default names", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithNamePrefix(""))
+		ctx := context.Background()
+
+		fixture.observer.Processed(ctx)
+
+		assert.True(t, fixture.collected(ctx, "evidence_processed_count"))
+	})
+}
+
+func TestWithDurationHistogram(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithDurationHistogram(false))
+	ctx := context.Background()
+
+	fixture.observer.ObserveDuration(ctx, time.Millisecond)
+
+	assert.False(t, fixture.collected(ctx, "evidence_processing_duration_seconds"))
+	summary, err := fixture.observer.ComplianceSummary(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Millisecond, summary.ProcessingP95)
+}
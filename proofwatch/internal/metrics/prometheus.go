@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/otlptranslator"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// NewPrometheusMeterProvider creates a MeterProvider backed by an OpenTelemetry
+// Prometheus exporter and returns it along with an http.Handler serving the collected
+// metrics in the Prometheus exposition format, ready to be mounted on /metrics. Metric
+// names are exposed as recorded, without the unit and _total suffixes the exporter adds
+// by default, so an EvidenceObserver built on a meter of the provider surfaces
+// evidence_processed_count and evidence_dropped_count. Further settings, such as views,
+// are passed through opts. Each call uses its own registry; call Shutdown on the
+// provider before exiting.
+func NewPrometheusMeterProvider(opts ...sdkmetric.Option) (*sdkmetric.MeterProvider, http.Handler, error) {
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprom.New(
+		otelprom.WithRegisterer(registry),
+		otelprom.WithTranslationStrategy(otlptranslator.UnderscoreEscapingWithoutSuffixes),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+	}
+
+	provider := sdkmetric.NewMeterProvider(append(opts[:len(opts):len(opts)], sdkmetric.WithReader(exporter))...)
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	return provider, handler, nil
+}
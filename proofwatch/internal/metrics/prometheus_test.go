@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestNewPrometheusMeterProvider(t *testing.T) {
+	provider, handler, err := NewPrometheusMeterProvider()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	observer, err := NewEvidenceObserver(provider.Meter("github.com/complytime/complybeacon/proofwatch"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	observer.Processed(ctx, attribute.String("policy.id", "AC-1"))
+	observer.Processed(ctx, attribute.String("policy.id", "AC-1"))
+	observer.Dropped(ctx, attribute.String("policy.id", "AC-1"))
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "# TYPE evidence_processed_count counter")
+	assert.Contains(t, string(body), "# TYPE evidence_dropped_count counter")
+	assert.Regexp(t, `evidence_processed_count\{[^}]*policy_id="AC-1"[^}]*\} 2`, string(body))
+	assert.Regexp(t, `evidence_dropped_count\{[^}]*policy_id="AC-1"[^}]*\} 1`, string(body))
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RegisterRateLimitTokens registers the evidence_rate_limit_tokens gauge. At each
+// collection, tokens is called for the number of tokens currently available to the
+// ingestion rate limiter.
+func (e *EvidenceObserver) RegisterRateLimitTokens(tokens func() float64) error {
+	_, err := (*e.meter).Float64ObservableGauge(
+		"evidence_rate_limit_tokens",
+		metric.WithDescription("The number of tokens currently available to the evidence ingestion rate limiter."),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(max(tokens(), 0), metric.WithAttributes(e.cfg.static...))
+			return nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create rate limit tokens gauge: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterRateLimitTokens(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	tokens := 4.5
+	require.NoError(t, fixture.observer.RegisterRateLimitTokens(func() float64 { return tokens }))
+
+	points := fixture.float64Gauge(ctx, "evidence_rate_limit_tokens")
+	require.Len(t, points, 1)
+	assert.Equal(t, 4.5, points[0].Value)
+
+	// A limiter in debt reports no tokens rather than a negative count.
+	tokens = -2
+	points = fixture.float64Gauge(ctx, "evidence_rate_limit_tokens")
+	require.Len(t, points, 1)
+	assert.Zero(t, points[0].Value)
+}
+
+func TestDroppedWithReasonRateLimited(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.DroppedWithReason(ctx, DropReasonRateLimited)
+
+	assert.Equal(t, map[string]int64{"rate_limited": 1}, sumByAttr(fixture.int64Points(ctx, "evidence_dropped_count"), DropReasonKey))
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import "time"
+
+type ratioSlot struct {
+	start   int64
+	total   int64
+	matched int64
+}
+
+// RatioWindow counts events, and how many of them matched a condition such as being
+// dropped or passing, in fixed-width time slots. Counts over any window up to the slot
+// width times the slot count can then be read back, with samples expiring one slot at
+// a time. It is not safe for concurrent use.
+type RatioWindow struct {
+	width time.Duration
+	slots []ratioSlot
+}
+
+// NewRatioWindow creates a RatioWindow of n slots of the given width. A width below a
+// millisecond is raised to it, and fewer than one slot to one.
+func NewRatioWindow(width time.Duration, n int) *RatioWindow {
+	return &RatioWindow{
+		width: max(width, time.Millisecond),
+		slots: make([]ratioSlot, max(n, 1)),
+	}
+}
+
+// Observe records an event happening at now, and whether it matched.
+func (w *RatioWindow) Observe(now time.Time, matched bool) {
+	start := now.UnixNano() / int64(w.width)
+	slot := &w.slots[start%int64(len(w.slots))]
+	if slot.start != start {
+		*slot = ratioSlot{start: start}
+	}
+	slot.total++
+	if matched {
+		slot.matched++
+	}
+}
+
+// Counts returns the number of matched and total events within window ending at now.
+func (w *RatioWindow) Counts(now time.Time, window time.Duration) (matched, total int64) {
+	oldest := now.Add(-window).UnixNano() / int64(w.width)
+	for _, s := range w.slots {
+		if s.start > oldest && s.total > 0 {
+			matched += s.matched
+			total += s.total
+		}
+	}
+	return matched, total
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatioWindow(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	w := NewRatioWindow(time.Minute, 5)
+
+	w.Observe(now, true)
+	w.Observe(now, false)
+	w.Observe(now.Add(time.Minute), true)
+
+	matched, total := w.Counts(now.Add(time.Minute), 5*time.Minute)
+	assert.Equal(t, int64(2), matched)
+	assert.Equal(t, int64(3), total)
+
+	// Only the latest slot falls inside a one-minute window.
+	matched, total = w.Counts(now.Add(time.Minute), time.Minute)
+	assert.Equal(t, int64(1), matched)
+	assert.Equal(t, int64(1), total)
+
+	// Slots expire once the window has moved past them.
+	matched, total = w.Counts(now.Add(10*time.Minute), 5*time.Minute)
+	assert.Zero(t, matched)
+	assert.Zero(t, total)
+
+	// A reused slot starts counting afresh.
+	w.Observe(now.Add(5*time.Minute), false)
+	matched, total = w.Counts(now.Add(5*time.Minute), time.Minute)
+	assert.Zero(t, matched)
+	assert.Equal(t, int64(1), total)
+}
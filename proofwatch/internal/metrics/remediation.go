@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// BreachedKey records whether a remediation SLA was breached.
+const BreachedKey = attribute.Key("breached")
+
+func (e *EvidenceObserver) initRemediationSLA(meter metric.Meter) error {
+	var err error
+	e.remediationSLACounter, err = meter.Int64Counter(
+		"remediation_sla_count",
+		metric.WithDescription("The total number of remediated findings, by whether the remediation SLA was breached and severity."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create remediation SLA counter: %w", err)
+	}
+	return nil
+}
+
+// RecordRemediationSLA records whether the remediation of a finding of the given
+// severity breached its SLA.
+func (e *EvidenceObserver) RecordRemediationSLA(ctx context.Context, breached bool, severity Severity, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.remediationSLACounter.Add(ctx, 1, e.measurementAttrs(attrs, BreachedKey.Bool(breached), severity.attribute()))
+}
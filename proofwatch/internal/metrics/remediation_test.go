@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordRemediationSLA(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordRemediationSLA(ctx, true, SeverityCritical)
+	fixture.observer.RecordRemediationSLA(ctx, false, SeverityCritical)
+	fixture.observer.RecordRemediationSLA(ctx, false, SeverityCritical)
+	fixture.observer.RecordRemediationSLA(ctx, true, SeverityLow)
+	fixture.observer.RecordRemediationSLA(ctx, false, SeverityMedium)
+
+	got := map[string]int64{}
+	for _, dp := range fixture.int64Points(ctx, "remediation_sla_count") {
+		breached, _ := attrValue(dp.Attributes, BreachedKey)
+		severity, _ := attrValue(dp.Attributes, SeverityKey)
+		got[severity+"/"+breached] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{
+		"critical/true":  1,
+		"critical/false": 2,
+		"low/true":       1,
+		"medium/false":   1,
+	}, got)
+}
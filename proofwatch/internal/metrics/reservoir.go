@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// reservoirSize bounds the samples kept by a durationReservoir.
+const reservoirSize = 1024
+
+// durationReservoir keeps the most recent durations in a fixed-size ring so
+// percentiles can be estimated without unbounded memory. It is not safe for
+// concurrent use; callers guard it.
+type durationReservoir struct {
+	samples []time.Duration
+	next    int
+}
+
+func (r *durationReservoir) add(d time.Duration) {
+	if len(r.samples) < reservoirSize {
+		r.samples = append(r.samples, d)
+		return
+	}
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % reservoirSize
+}
+
+// percentile returns the nearest-rank q-quantile of the kept samples, with q
+// clamped to [0, 1]. It returns zero when no samples were kept.
+func (r *durationReservoir) percentile(q float64) time.Duration {
+	if len(r.samples) == 0 {
+		return 0
+	}
+	q = min(max(q, 0), 1)
+	sorted := slices.Clone(r.samples)
+	slices.Sort(sorted)
+	rank := max(int(math.Ceil(q*float64(len(sorted)))), 1)
+	return sorted[rank-1]
+}
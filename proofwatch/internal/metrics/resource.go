@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ResourceKey is the bounded compute resource attribute.
+const ResourceKey = attribute.Key("resource")
+
+var ceilingResources = newBoundedSet("cpu", "memory", "disk")
+
+// DroppedResourceCeiling records evidence dropped because its evaluation hit the
+// configured ceiling for resource (cpu, memory or disk) and was aborted.
+func (e *EvidenceObserver) DroppedResourceCeiling(ctx context.Context, resource string, attrs ...attribute.KeyValue) {
+	e.droppedWithReason(ctx, DropReasonResourceCeiling, attrs,
+		e.bounded(ResourceKey, strings.ToLower(resource), ceilingResources),
+	)
+}
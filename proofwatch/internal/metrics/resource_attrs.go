@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	// ServiceNameKey is the logical name of the service emitting metrics.
+	ServiceNameKey = attribute.Key("service.name")
+	// ServiceVersionKey is the version of the service emitting metrics.
+	ServiceVersionKey = attribute.Key("service.version")
+	// DeploymentEnvironmentKey is the deployment environment, such as staging or production.
+	DeploymentEnvironmentKey = attribute.Key("deployment.environment")
+)
+
+// defaultServiceName is used when ResourceConfig does not name the service.
+const defaultServiceName = "proofwatch"
+
+// ResourceConfig describes the service emitting evidence metrics.
+type ResourceConfig struct {
+	// ServiceName defaults to "proofwatch".
+	ServiceName string
+	// ServiceVersion defaults to DefaultServiceVersion.
+	ServiceVersion string
+	// DefaultServiceVersion is the version reported when neither ServiceVersion nor the
+	// environment sets one. service.version is omitted when all are empty.
+	DefaultServiceVersion string
+	// Environment is omitted when empty.
+	Environment string
+}
+
+// NewResource builds a Resource carrying service.name, service.version and, when set,
+// deployment.environment, on top of the SDK defaults and any OTEL_RESOURCE_ATTRIBUTES
+// and OTEL_SERVICE_NAME settings. Non-empty config values take precedence over the
+// environment.
+func NewResource(ctx context.Context, cfg ResourceConfig) (*resource.Resource, error) {
+	name := cfg.ServiceName
+	if name == "" {
+		name = defaultServiceName
+	}
+	defaults := []attribute.KeyValue{ServiceNameKey.String(name)}
+	if cfg.DefaultServiceVersion != "" {
+		defaults = append(defaults, ServiceVersionKey.String(cfg.DefaultServiceVersion))
+	}
+	var explicit []attribute.KeyValue
+	if cfg.ServiceName != "" {
+		explicit = append(explicit, ServiceNameKey.String(cfg.ServiceName))
+	}
+	if cfg.ServiceVersion != "" {
+		explicit = append(explicit, ServiceVersionKey.String(cfg.ServiceVersion))
+	}
+	if cfg.Environment != "" {
+		explicit = append(explicit, DeploymentEnvironmentKey.String(cfg.Environment))
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(defaults...),
+		resource.WithFromEnv(),
+		resource.WithAttributes(explicit...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+	return res, nil
+}
+
+// NewMeterProvider creates a MeterProvider whose metrics carry the Resource built by
+// NewResource from cfg. Readers and other settings are passed through opts.
+func NewMeterProvider(ctx context.Context, cfg ResourceConfig, opts ...sdkmetric.Option) (*sdkmetric.MeterProvider, error) {
+	res, err := NewResource(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sdkmetric.NewMeterProvider(append(opts[:len(opts):len(opts)], sdkmetric.WithResource(res))...), nil
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewMeterProvider(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+
+	collectResource := func(t *testing.T, cfg ResourceConfig) *attribute.Set {
+		t.Helper()
+		ctx := context.Background()
+
+		reader := sdkmetric.NewManualReader()
+		mp, err := NewMeterProvider(ctx, cfg, sdkmetric.WithReader(reader))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+
+		observer, err := NewEvidenceObserver(mp.Meter("test-meter"))
+		require.NoError(t, err)
+		observer.Processed(ctx)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		return rm.Resource.Set()
+	}
+
+	t.Run("uses config values", func(t *testing.T) {
+		set := collectResource(t, ResourceConfig{ServiceName: "beacon", ServiceVersion: "v1.2.3", Environment: "prod"})
+
+		for key, want := range map[attribute.Key]string{
+			ServiceNameKey:           "beacon",
+			ServiceVersionKey:        "v1.2.3",
+			DeploymentEnvironmentKey: "prod",
+		} {
+			got, ok := set.Value(key)
+			assert.True(t, ok, key)
+			assert.Equal(t, want, got.AsString(), key)
+		}
+	})
+
+	t.Run("defaults to the default version", func(t *testing.T) {
+		set := collectResource(t, ResourceConfig{DefaultServiceVersion: "v9.9.9-test"})
+
+		name, _ := set.Value(ServiceNameKey)
+		assert.Equal(t, "proofwatch", name.AsString())
+		version, _ := set.Value(ServiceVersionKey)
+		assert.Equal(t, "v9.9.9-test", version.AsString())
+		_, ok := set.Value(DeploymentEnvironmentKey)
+		assert.False(t, ok)
+	})
+
+	t.Run("omits an unset version", func(t *testing.T) {
+		_, ok := collectResource(t, ResourceConfig{}).Value(ServiceVersionKey)
+		assert.False(t, ok)
+	})
+
+	t.Run("config overrides environment", func(t *testing.T) {
+		t.Setenv("OTEL_SERVICE_NAME", "from-env")
+
+		assert.Equal(t, "from-env", resourceValue(collectResource(t, ResourceConfig{}).Value(ServiceNameKey)).AsString())
+		assert.Equal(t, "beacon", resourceValue(collectResource(t, ResourceConfig{ServiceName: "beacon"}).Value(ServiceNameKey)).AsString())
+	})
+}
+
+func resourceValue(v attribute.Value, _ bool) attribute.Value {
+	return v
+}
@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDroppedResourceCeiling(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	for _, resource := range []string{"cpu", "memory", "Memory", "disk", "gpu"} {
+		fixture.observer.DroppedResourceCeiling(ctx, resource)
+	}
+
+	points := fixture.int64Points(ctx, "evidence_dropped_count")
+	assert.Equal(t, map[string]int64{"resource_ceiling": 5}, sumByAttr(points, DropReasonKey))
+	assert.Equal(t, map[string]int64{"cpu": 1, "memory": 2, "disk": 1, "other": 1}, sumByAttr(points, ResourceKey))
+}
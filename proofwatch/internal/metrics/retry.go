@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RetryAttemptKey is the retry attempt attribute, clamped to [1, maxRetryAttempt].
+const RetryAttemptKey = attribute.Key("retry.attempt")
+
+// maxRetryAttempt bounds the retry attempt attribute; later attempts share its value.
+const maxRetryAttempt = 5
+
+// retryBackoffBuckets cover backoff delays from a few milliseconds up to a minute.
+var retryBackoffBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+func (e *EvidenceObserver) initRetry(meter metric.Meter) error {
+	var err error
+	e.retryBackoff, err = meter.Float64Histogram(
+		"retry_backoff_seconds",
+		metric.WithDescription("The delay before each evaluation retry, by attempt."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(retryBackoffBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create retry backoff histogram: %w", err)
+	}
+	return nil
+}
+
+// RecordRetryBackoff records the delay waited before retry attempt. Attempts are
+// clamped to [1, 5] and negative delays are recorded as zero.
+func (e *EvidenceObserver) RecordRetryBackoff(ctx context.Context, attempt int, delay time.Duration, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.retryBackoff.Record(ctx, max(delay, 0).Seconds(), e.measurementAttrs(attrs, RetryAttempt(attempt)))
+}
+
+// RetryAttempt returns the retry attempt attribute for attempt, clamped to [1, 5].
+func RetryAttempt(attempt int) attribute.KeyValue {
+	return RetryAttemptKey.Int(min(max(attempt, 1), maxRetryAttempt))
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordRetryBackoff(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordRetryBackoff(ctx, 1, 100*time.Millisecond)
+	fixture.observer.RecordRetryBackoff(ctx, 2, 200*time.Millisecond)
+	fixture.observer.RecordRetryBackoff(ctx, 2, 400*time.Millisecond)
+	fixture.observer.RecordRetryBackoff(ctx, 0, -time.Second)
+	fixture.observer.RecordRetryBackoff(ctx, 12, 20*time.Second)
+
+	hist := fixture.float64Histogram(ctx, "retry_backoff_seconds")
+	require.Len(t, hist, 3)
+
+	byAttempt := map[string][]uint64{}
+	counts := map[string]uint64{}
+	for _, dp := range hist {
+		attempt, _ := attrValue(dp.Attributes, RetryAttemptKey)
+		byAttempt[attempt] = dp.BucketCounts
+		counts[attempt] = dp.Count
+	}
+	assert.Equal(t, map[string]uint64{"1": 2, "2": 2, "5": 1}, counts)
+
+	// 0s lands in (-inf,0.01], 0.1s in (0.05,0.1].
+	assert.Equal(t, uint64(1), byAttempt["1"][0])
+	assert.Equal(t, uint64(1), byAttempt["1"][2])
+	// 0.2s and 0.4s land in (0.1,0.25] and (0.25,0.5].
+	assert.Equal(t, uint64(1), byAttempt["2"][3])
+	assert.Equal(t, uint64(1), byAttempt["2"][4])
+	// 20s lands in (10,30].
+	assert.Equal(t, uint64(1), byAttempt["5"][9])
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// rollupInputBuckets covers rollups from a handful of items up to large batches.
+var rollupInputBuckets = []float64{1, 5, 10, 50, 100, 500, 1000, 5000, 10000, 50000}
+
+func (e *EvidenceObserver) initRollup(meter metric.Meter) error {
+	var err error
+	e.rollupCounter, err = meter.Int64Counter(
+		"evidence_rollup_count",
+		metric.WithDescription("The total number of rollups summarizing fine-grained evidence."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create rollup counter: %w", err)
+	}
+
+	e.rollupInputSize, err = meter.Int64Histogram(
+		"rollup_input_size",
+		metric.WithDescription("The number of evidence items summarized by each rollup."),
+		metric.WithExplicitBucketBoundaries(rollupInputBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create rollup input size histogram: %w", err)
+	}
+	return nil
+}
+
+// RecordRollup records a rollup summarizing inputCount evidence items.
+// A negative inputCount is recorded as zero.
+func (e *EvidenceObserver) RecordRollup(ctx context.Context, inputCount int64, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	opt := e.measurementAttrs(attrs)
+	e.rollupCounter.Add(ctx, 1, opt)
+	e.rollupInputSize.Record(ctx, max(inputCount, 0), opt)
+}
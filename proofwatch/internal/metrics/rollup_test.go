@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordRollup(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordRollup(ctx, 3)
+	fixture.observer.RecordRollup(ctx, 120)
+	fixture.observer.RecordRollup(ctx, -5)
+
+	counts := fixture.int64Points(ctx, "evidence_rollup_count")
+	require.Len(t, counts, 1)
+	assert.Equal(t, int64(3), counts[0].Value)
+
+	hist := fixture.int64Histogram(ctx, "rollup_input_size")
+	require.Len(t, hist, 1)
+	assert.Equal(t, uint64(3), hist[0].Count)
+	assert.Equal(t, int64(123), hist[0].Sum)
+	assert.Equal(t, rollupInputBuckets, hist[0].Bounds)
+	// 0 and 3 fall in (-inf,1] and (1,5], 120 falls in (100,500].
+	assert.Equal(t, uint64(1), hist[0].BucketCounts[0])
+	assert.Equal(t, uint64(1), hist[0].BucketCounts[1])
+	assert.Equal(t, uint64(1), hist[0].BucketCounts[5])
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Sampler decides whether a processed evidence event is recorded.
+type Sampler interface {
+	ShouldSample(ctx context.Context, attrs []attribute.KeyValue) bool
+}
+
+// SamplerFunc adapts an ordinary function to the Sampler interface.
+type SamplerFunc func(ctx context.Context, attrs []attribute.KeyValue) bool
+
+// ShouldSample calls f(ctx, attrs).
+func (f SamplerFunc) ShouldSample(ctx context.Context, attrs []attribute.KeyValue) bool {
+	return f(ctx, attrs)
+}
+
+// WithSampler installs a sampler consulted before recording processed evidence.
+// Dropped evidence is always recorded since drops are the signal operators alert on.
+// Sampled events count once: WithSampler replaces any sampler and upscaling set by an
+// earlier WithSampling, and a later WithSampling replaces sampler.
+func WithSampler(sampler Sampler) Option {
+	return func(cfg *observerConfig) {
+		cfg.sampler = sampler
+		cfg.sampleWeight = nil
+	}
+}
+
+// WithSampling records only the given fraction of processed evidence, clamped to
+// (0, 1], and upscales each recorded event by 1/rate so evidence_processed_count still
+// reports an estimate of the total processed. Fractional increments are carried over
+// to later events. Dropped evidence is always recorded and never upscaled. A rate of
+// zero or less is rejected. WithSampling replaces any sampler set by an earlier
+// WithSampler.
+func WithSampling(rate float64) Option {
+	return func(cfg *observerConfig) {
+		if rate <= 0 {
+			cfg.fail(fmt.Errorf("sampling rate must be positive, got %v", rate))
+			return
+		}
+		rate = clampRate(rate)
+		cfg.sampler = NewRateSampler(rate)
+		cfg.sampleWeight = &sampleWeight{weight: 1 / rate}
+	}
+}
+
+// sampleWeight turns the fractional upscaling factor of sampled events into integer
+// counter increments, carrying the remainder over to the next event.
+type sampleWeight struct {
+	weight float64
+
+	mu    sync.Mutex
+	carry float64
+}
+
+// next returns the increment for the next sampled event.
+func (w *sampleWeight) next() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.carry += w.weight
+	n := math.Floor(w.carry)
+	w.carry -= n
+	return int64(n)
+}
+
+type rateSampler struct {
+	rate float64
+}
+
+// NewRateSampler returns a Sampler recording the given fraction of events.
+// The rate is clamped to [0, 1].
+func NewRateSampler(rate float64) Sampler {
+	return rateSampler{rate: clampRate(rate)}
+}
+
+func (s rateSampler) ShouldSample(_ context.Context, _ []attribute.KeyValue) bool {
+	return sampleAt(s.rate)
+}
+
+type prioritySampler struct {
+	key         attribute.Key
+	rates       map[string]float64
+	defaultRate float64
+}
+
+// NewPrioritySampler returns a Sampler whose rate depends on the value of key.
+// Events whose key value is listed in rates are sampled at that rate, all other
+// events are sampled at defaultRate. Rates are clamped to [0, 1].
+func NewPrioritySampler(key attribute.Key, rates map[string]float64, defaultRate float64) Sampler {
+	s := prioritySampler{
+		key:         key,
+		rates:       make(map[string]float64, len(rates)),
+		defaultRate: clampRate(defaultRate),
+	}
+	for value, rate := range rates {
+		s.rates[value] = clampRate(rate)
+	}
+	return s
+}
+
+func (s prioritySampler) ShouldSample(_ context.Context, attrs []attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv.Key != s.key {
+			continue
+		}
+		if rate, ok := s.rates[kv.Value.Emit()]; ok {
+			return sampleAt(rate)
+		}
+	}
+	return sampleAt(s.defaultRate)
+}
+
+func clampRate(rate float64) float64 {
+	switch {
+	case rate < 0:
+		return 0
+	case rate > 1:
+		return 1
+	default:
+		return rate
+	}
+}
+
+func sampleAt(rate float64) bool {
+	switch rate {
+	case 0:
+		return false
+	case 1:
+		return true
+	default:
+		return rand.Float64() < rate // #nosec G404 -- sampling does not need a cryptographic source
+	}
+}
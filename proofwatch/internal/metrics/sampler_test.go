@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestWithSampler(t *testing.T) {
+	t.Run("custom sampler selects events", func(t *testing.T) {
+		onlyCritical := SamplerFunc(func(_ context.Context, attrs []attribute.KeyValue) bool {
+			for _, kv := range attrs {
+				if kv.Key == "priority" && kv.Value.AsString() == "critical" {
+					return true
+				}
+			}
+			return false
+		})
+		fixture := setupEvidenceObserverTest(t, WithSampler(onlyCritical))
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			fixture.observer.Processed(ctx, attribute.String("priority", "critical"))
+			fixture.observer.Processed(ctx, attribute.String("priority", "low"))
+		}
+		fixture.observer.Dropped(ctx, attribute.String("priority", "low"))
+
+		points := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, points, 1)
+		priority, _ := attrValue(points[0].Attributes, "priority")
+		assert.Equal(t, "critical", priority)
+		assert.Equal(t, int64(3), points[0].Value)
+
+		dropped := fixture.int64Points(ctx, "evidence_dropped_count")
+		require.Len(t, dropped, 1)
+		assert.Equal(t, int64(1), dropped[0].Value)
+	})
+}
+
+func TestRateSampler(t *testing.T) {
+	ctx := context.Background()
+
+	assert.True(t, NewRateSampler(1).ShouldSample(ctx, nil))
+	assert.True(t, NewRateSampler(2).ShouldSample(ctx, nil))
+	assert.False(t, NewRateSampler(0).ShouldSample(ctx, nil))
+	assert.False(t, NewRateSampler(-1).ShouldSample(ctx, nil))
+}
+
+func TestPrioritySampler(t *testing.T) {
+	ctx := context.Background()
+	sampler := NewPrioritySampler("priority", map[string]float64{"critical": 1, "low": 0}, 0)
+
+	assert.True(t, sampler.ShouldSample(ctx, []attribute.KeyValue{attribute.String("priority", "critical")}))
+	assert.False(t, sampler.ShouldSample(ctx, []attribute.KeyValue{attribute.String("priority", "low")}))
+	assert.False(t, sampler.ShouldSample(ctx, []attribute.KeyValue{attribute.String("priority", "medium")}))
+	assert.False(t, sampler.ShouldSample(ctx, nil))
+}
+
+// everyNth returns a Sampler deterministically sampling one event out of every n.
+func everyNth(n int) Sampler {
+	var seen int
+	return SamplerFunc(func(context.Context, []attribute.KeyValue) bool {
+		seen++
+		return seen%n == 0
+	})
+}
+
+func TestWithSampling(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("processed counter reports the upscaled estimate", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithSampling(0.25))
+		fixture.observer.cfg.sampler = everyNth(4)
+
+		for i := 0; i < 100; i++ {
+			fixture.observer.Processed(ctx)
+		}
+		for i := 0; i < 3; i++ {
+			fixture.observer.Dropped(ctx)
+		}
+
+		processed := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		assert.Equal(t, int64(100), processed[0].Value)
+
+		dropped := fixture.int64Points(ctx, "evidence_dropped_count")
+		require.Len(t, dropped, 1)
+		assert.Equal(t, int64(3), dropped[0].Value)
+	})
+
+	t.Run("fractional weights are carried over", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithSampling(0.4))
+		fixture.observer.cfg.sampler = SamplerFunc(func(context.Context, []attribute.KeyValue) bool { return true })
+
+		for i := 0; i < 4; i++ {
+			fixture.observer.Processed(ctx)
+		}
+
+		processed := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		assert.Equal(t, int64(10), processed[0].Value)
+	})
+
+	t.Run("rate of one records every event once", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithSampling(1))
+
+		for i := 0; i < 5; i++ {
+			fixture.observer.Processed(ctx)
+		}
+
+		processed := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		assert.Equal(t, int64(5), processed[0].Value)
+	})
+
+	t.Run("non-positive rate is rejected", func(t *testing.T) {
+		meter := sdkmetric.NewMeterProvider().Meter("test-meter")
+		_, err := NewEvidenceObserver(meter, WithSampling(0))
+		assert.Error(t, err)
+	})
+}
+
+func TestSamplingOptionOrder(t *testing.T) {
+	ctx := context.Background()
+	always := SamplerFunc(func(context.Context, []attribute.KeyValue) bool { return true })
+
+	t.Run("later sampler drops the upscaling", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithSampling(0.25), WithSampler(always))
+
+		for i := 0; i < 3; i++ {
+			fixture.observer.Processed(ctx)
+		}
+
+		processed := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		assert.Equal(t, int64(3), processed[0].Value)
+	})
+
+	t.Run("later sampling replaces the sampler", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithSampler(always), WithSampling(0.5))
+		assert.Equal(t, NewRateSampler(0.5), fixture.observer.cfg.sampler)
+		require.NotNil(t, fixture.observer.cfg.sampleWeight)
+		assert.Equal(t, 2.0, fixture.observer.cfg.sampleWeight.weight)
+	})
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ScheduleNameKey is the bounded scheduled scan attribute. Schedules are registered
+// with WithSchedules.
+const ScheduleNameKey = attribute.Key("schedule.name")
+
+// WithSchedules registers the scheduled scans accepted by ProcessedFromSchedule.
+func WithSchedules(names ...string) Option {
+	return WithAllowedValues(ScheduleNameKey, names...)
+}
+
+// ProcessedFromSchedule records processed evidence produced by the scheduled scan
+// scheduleName, as opposed to event-driven evidence. Unregistered schedules are
+// recorded as "other".
+func (e *EvidenceObserver) ProcessedFromSchedule(ctx context.Context, scheduleName string, attrs ...attribute.KeyValue) {
+	e.Processed(ctx, append(attrs[:len(attrs):len(attrs)], e.bounded(ScheduleNameKey, scheduleName, nil))...)
+}
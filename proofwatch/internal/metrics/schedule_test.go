@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessedFromSchedule(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithSchedules("nightly-cis", "weekly-fedramp"))
+	ctx := context.Background()
+
+	fixture.observer.ProcessedFromSchedule(ctx, "nightly-cis")
+	fixture.observer.ProcessedFromSchedule(ctx, "nightly-cis")
+	fixture.observer.ProcessedFromSchedule(ctx, "weekly-fedramp")
+	fixture.observer.ProcessedFromSchedule(ctx, "adhoc")
+
+	got := sumByAttr(fixture.int64Points(ctx, "evidence_processed_count"), ScheduleNameKey)
+	assert.Equal(t, map[string]int64{"nightly-cis": 2, "weekly-fedramp": 1, "other": 1}, got)
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// SchemaVersionKey is the registry key for known evidence schema versions.
+	// Versions registered under it with WithAllowedValues bound both migration attributes.
+	SchemaVersionKey = attribute.Key("schema.version")
+	// SchemaFromVersionKey is the bounded schema version evidence was migrated from.
+	SchemaFromVersionKey = attribute.Key("schema.version.from")
+	// SchemaToVersionKey is the bounded schema version evidence was migrated to.
+	SchemaToVersionKey = attribute.Key("schema.version.to")
+	// SuccessKey records whether an operation succeeded.
+	SuccessKey = attribute.Key("success")
+)
+
+func (e *EvidenceObserver) initSchemaMigration(meter metric.Meter) error {
+	var err error
+	e.schemaMigrationCounter, err = meter.Int64Counter(
+		"schema_migration_count",
+		metric.WithDescription("The total number of evidence schema migrations, by outcome."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create schema migration counter: %w", err)
+	}
+	return nil
+}
+
+// RecordSchemaMigration records an automatic migration of evidence from fromVersion
+// to toVersion. Versions not registered under SchemaVersionKey are recorded as "other".
+func (e *EvidenceObserver) RecordSchemaMigration(ctx context.Context, fromVersion, toVersion string, success bool, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.schemaMigrationCounter.Add(ctx, 1, e.measurementAttrs(attrs,
+		SchemaFromVersionKey.String(e.allowedValue(SchemaVersionKey, fromVersion, nil)),
+		SchemaToVersionKey.String(e.allowedValue(SchemaVersionKey, toVersion, nil)),
+		SuccessKey.Bool(success),
+	))
+}
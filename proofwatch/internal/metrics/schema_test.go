@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordSchemaMigration(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithAllowedValues(SchemaVersionKey, "v1", "v2"))
+	ctx := context.Background()
+
+	fixture.observer.RecordSchemaMigration(ctx, "v1", "v2", true)
+	fixture.observer.RecordSchemaMigration(ctx, "v1", "v2", true)
+	fixture.observer.RecordSchemaMigration(ctx, "v0-beta", "v2", false)
+
+	points := fixture.int64Points(ctx, "schema_migration_count")
+	require.Len(t, points, 2)
+	assert.Equal(t, map[string]int64{"true": 2, "false": 1}, sumByAttr(points, SuccessKey))
+	assert.Equal(t, map[string]int64{"v1": 2, "other": 1}, sumByAttr(points, SchemaFromVersionKey))
+	assert.Equal(t, map[string]int64{"v2": 3}, sumByAttr(points, SchemaToVersionKey))
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import "go.opentelemetry.io/otel/attribute"
+
+// Severity is the severity of a finding or alert.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// SeverityKey is the bounded severity attribute.
+const SeverityKey = attribute.Key("severity")
+
+var severities = newBoundedSet(
+	string(SeverityInfo),
+	string(SeverityLow),
+	string(SeverityMedium),
+	string(SeverityHigh),
+	string(SeverityCritical),
+)
+
+// attribute returns the severity attribute, recording severities outside the known
+// set as "unknown".
+func (s Severity) attribute() attribute.KeyValue {
+	return SeverityKey.String(severities.normalize(string(s), unknownValue))
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// WithPolicyIDs registers the policy IDs recorded by bounded policy metrics such as
+// RecordShortCircuit. Other policy IDs are recorded as "other".
+func WithPolicyIDs(policyIDs ...string) Option {
+	return WithAllowedValues(PolicyIDKey, policyIDs...)
+}
+
+func (e *EvidenceObserver) initShortCircuit(meter metric.Meter) error {
+	var err error
+	e.shortCircuitCounter, err = meter.Int64Counter(
+		"policy_short_circuit_count",
+		metric.WithDescription("The total number of policy evaluations that short-circuited before evaluating every rule, by policy."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create short-circuit counter: %w", err)
+	}
+	return nil
+}
+
+// RecordShortCircuit records that the evaluation of policyID short-circuited, for
+// example because its first failing rule aborted the rest. Policy IDs not registered
+// with WithPolicyIDs are recorded as "other".
+func (e *EvidenceObserver) RecordShortCircuit(ctx context.Context, policyID string, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.shortCircuitCounter.Add(ctx, 1, e.measurementAttrs(attrs, e.bounded(PolicyIDKey, policyID, nil)))
+}
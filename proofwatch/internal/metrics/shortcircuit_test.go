@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordShortCircuit(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithPolicyIDs("AC-1", "AC-2"))
+	ctx := context.Background()
+
+	fixture.observer.RecordShortCircuit(ctx, "AC-1")
+	fixture.observer.RecordShortCircuit(ctx, "AC-1")
+	fixture.observer.RecordShortCircuit(ctx, "AC-2")
+	fixture.observer.RecordShortCircuit(ctx, "SC-7")
+	fixture.observer.RecordShortCircuit(ctx, "SC-8")
+
+	got := sumByAttr(fixture.int64Points(ctx, "policy_short_circuit_count"), PolicyIDKey)
+	assert.Equal(t, map[string]int64{"AC-1": 2, "AC-2": 1, "other": 2}, got)
+}
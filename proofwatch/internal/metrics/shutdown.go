@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrObserverShutdown is returned by Shutdown when the observer was already shut down.
+var ErrObserverShutdown = errors.New("evidence observer already shut down")
+
+// Flusher flushes pending telemetry, such as an SDK MeterProvider.
+type Flusher interface {
+	ForceFlush(ctx context.Context) error
+}
+
+// WithFlusher sets the flusher Shutdown uses to export pending metrics, typically the
+// MeterProvider the observer's meter was obtained from.
+func WithFlusher(f Flusher) Option {
+	return func(cfg *observerConfig) {
+		cfg.flusher = f
+	}
+}
+
+// Shutdown flushes pending metrics through the configured flusher and marks the
+// observer closed. After Shutdown, every recording method is a no-op; methods
+// validating their input still return validation errors. Calling Shutdown again
+// returns ErrObserverShutdown.
+func (e *EvidenceObserver) Shutdown(ctx context.Context) error {
+	if e.closed.Swap(true) {
+		return ErrObserverShutdown
+	}
+	if e.cfg.flusher == nil {
+		return nil
+	}
+	if err := e.cfg.flusher.ForceFlush(ctx); err != nil {
+		return fmt.Errorf("failed to flush metrics: %w", err)
+	}
+	return nil
+}
+
+// recording reports whether the observer records measurements, that is, whether it was
+// not shut down. Every recording method checks it first.
+func (e *EvidenceObserver) recording() bool {
+	return !e.closed.Load()
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+type countingFlusher struct {
+	flushes int
+}
+
+func (f *countingFlusher) ForceFlush(context.Context) error {
+	f.flushes++
+	return nil
+}
+
+func TestShutdown(t *testing.T) {
+	t.Run("flushes and stops recording", func(t *testing.T) {
+		flusher := &countingFlusher{}
+		fixture := setupEvidenceObserverTest(t, WithFlusher(flusher))
+		ctx := context.Background()
+
+		fixture.observer.Processed(ctx)
+		fixture.observer.Dropped(ctx)
+		require.NoError(t, fixture.observer.Shutdown(ctx))
+		assert.Equal(t, 1, flusher.flushes)
+
+		before := fixture.collectMetrics(ctx)
+		assert.NotPanics(t, func() {
+			fixture.observer.Processed(ctx)
+			fixture.observer.Dropped(ctx)
+			fixture.observer.ProcessedWithStatus(ctx, EvaluationStatusPass)
+		})
+
+		assert.Equal(t, int64(1), fixture.int64Points(ctx, "evidence_processed_count")[0].Value)
+		assert.Equal(t, int64(1), fixture.int64Points(ctx, "evidence_dropped_count")[0].Value)
+		assert.Len(t, fixture.collectMetrics(ctx).ScopeMetrics[0].Metrics, len(before.ScopeMetrics[0].Metrics))
+	})
+
+	t.Run("stops every recorder", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+		record := func() {
+			fixture.observer.Begin(ctx)
+			fixture.observer.ObserveDuration(ctx, time.Second)
+			require.NoError(t, fixture.observer.RecordEvaluation(ctx, Evaluation{PolicyID: "AC-1", Status: EvaluationStatusPass}))
+		}
+
+		record()
+		require.NoError(t, fixture.observer.Shutdown(ctx))
+		record()
+		fixture.observer.RecordDrift(ctx, true)
+
+		assert.Equal(t, int64(1), fixture.int64Points(ctx, "evidence_in_flight")[0].Value)
+		assert.Equal(t, uint64(1), fixture.float64Histogram(ctx, "evidence_processing_duration_seconds")[0].Count)
+		assert.Equal(t, int64(1), fixture.int64Points(ctx, "policy_evaluation_count")[0].Value)
+		assert.False(t, fixture.collected(ctx, "evidence_drift_count"))
+		assert.Error(t, fixture.observer.RecordWeightedResult(ctx, -1, true), "input is still validated")
+	})
+
+	t.Run("returns an error when called twice", func(t *testing.T) {
+		observer, err := NewEvidenceObserver(sdkmetric.NewMeterProvider().Meter("test-meter"))
+		require.NoError(t, err)
+		ctx := context.Background()
+
+		require.NoError(t, observer.Shutdown(ctx))
+		assert.ErrorIs(t, observer.Shutdown(ctx), ErrObserverShutdown)
+	})
+}
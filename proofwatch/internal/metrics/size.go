@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// byteSizeBuckets span 256B to 16MiB in powers of four.
+var byteSizeBuckets = []float64{256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20}
+
+// sizeRatioBuckets resolve results much smaller and much larger than their input.
+var sizeRatioBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10}
+
+func (e *EvidenceObserver) initSize(meter metric.Meter) error {
+	var err error
+	e.evidenceSize, err = meter.Int64Histogram(
+		"evidence_size_bytes",
+		metric.WithDescription("The size of evidence payloads."),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(byteSizeBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create evidence size histogram: %w", err)
+	}
+
+	e.inputSize, err = meter.Int64Histogram(
+		"evaluation_input_size_bytes",
+		metric.WithDescription("The size of the input to each policy evaluation."),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(byteSizeBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create input size histogram: %w", err)
+	}
+
+	e.resultSize, err = meter.Int64Histogram(
+		"evaluation_result_size_bytes",
+		metric.WithDescription("The size of the result of each policy evaluation."),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(byteSizeBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create result size histogram: %w", err)
+	}
+
+	e.sizeRatio, err = meter.Float64Histogram(
+		"evaluation_size_ratio",
+		metric.WithDescription("The ratio of result size to input size of each policy evaluation."),
+		metric.WithExplicitBucketBoundaries(sizeRatioBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create size ratio histogram: %w", err)
+	}
+	return nil
+}
+
+// RecordSizeRatio records the input and result sizes of an evaluation along with
+// their ratio, all under the same attributes. Negative sizes are recorded as zero,
+// and the ratio is skipped when the input is empty.
+func (e *EvidenceObserver) RecordSizeRatio(ctx context.Context, inputBytes, resultBytes int64, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	inputBytes, resultBytes = max(inputBytes, 0), max(resultBytes, 0)
+	opt := e.measurementAttrs(attrs)
+	e.inputSize.Record(ctx, inputBytes, opt)
+	e.resultSize.Record(ctx, resultBytes, opt)
+	if inputBytes > 0 {
+		e.sizeRatio.Record(ctx, float64(resultBytes)/float64(inputBytes), opt)
+	}
+}
+
+// ObserveSize records the size of an evidence payload. A negative size is recorded as zero.
+func (e *EvidenceObserver) ObserveSize(ctx context.Context, sizeBytes int64, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.evidenceSize.Record(ctx, max(sizeBytes, 0), e.measurementAttrs(attrs))
+}
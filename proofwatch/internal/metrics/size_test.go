@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordSizeRatio(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordSizeRatio(ctx, 1000, 250)
+	fixture.observer.RecordSizeRatio(ctx, 2000, 4000)
+	fixture.observer.RecordSizeRatio(ctx, 0, 100)
+
+	input := fixture.int64Histogram(ctx, "evaluation_input_size_bytes")
+	require.Len(t, input, 1)
+	assert.Equal(t, uint64(3), input[0].Count)
+	assert.Equal(t, int64(3000), input[0].Sum)
+
+	result := fixture.int64Histogram(ctx, "evaluation_result_size_bytes")
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(4350), result[0].Sum)
+
+	ratio := fixture.float64Histogram(ctx, "evaluation_size_ratio")
+	require.Len(t, ratio, 1)
+	assert.Equal(t, uint64(2), ratio[0].Count, "zero input must not record a ratio")
+	assert.InDelta(t, 2.25, ratio[0].Sum, 1e-9)
+}
+
+func TestObserveSize(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.ObserveSize(ctx, 100)
+	fixture.observer.ObserveSize(ctx, 3<<20)
+	fixture.observer.ObserveSize(ctx, 0)
+	fixture.observer.ObserveSize(ctx, -42)
+
+	points := fixture.int64Histogram(ctx, "evidence_size_bytes")
+	require.Len(t, points, 1)
+	assert.Equal(t, uint64(4), points[0].Count)
+	assert.Equal(t, int64(100+3<<20), points[0].Sum)
+	// Buckets: (-inf,256] (256,1Ki] (1Ki,4Ki] (4Ki,16Ki] (16Ki,64Ki] (64Ki,256Ki] (256Ki,1Mi] (1Mi,4Mi] (4Mi,16Mi] (16Mi,+inf)
+	assert.Equal(t, []uint64{3, 0, 0, 0, 0, 0, 0, 1, 0, 0}, points[0].BucketCounts)
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// PipelineStage is a stage of the evidence processing pipeline.
+type PipelineStage string
+
+const (
+	PipelineStageIngest    PipelineStage = "ingest"
+	PipelineStageValidate  PipelineStage = "validate"
+	PipelineStageNormalize PipelineStage = "normalize"
+	PipelineStageDedup     PipelineStage = "dedup"
+	PipelineStageEnrich    PipelineStage = "enrich"
+	PipelineStageEvaluate  PipelineStage = "evaluate"
+	PipelineStageExport    PipelineStage = "export"
+)
+
+// PipelineStageKey is the bounded pipeline stage attribute.
+const PipelineStageKey = attribute.Key("pipeline.stage")
+
+var pipelineStages = newBoundedSet(
+	string(PipelineStageIngest),
+	string(PipelineStageValidate),
+	string(PipelineStageNormalize),
+	string(PipelineStageDedup),
+	string(PipelineStageEnrich),
+	string(PipelineStageEvaluate),
+	string(PipelineStageExport),
+)
+
+func (e *EvidenceObserver) initStageSkip(meter metric.Meter) error {
+	var err error
+	e.stageSkipCounter, err = meter.Int64Counter(
+		"pipeline_stage_skip_count",
+		metric.WithDescription("The total number of pipeline stages skipped by short-circuit optimizations, by stage."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create stage skip counter: %w", err)
+	}
+	return nil
+}
+
+// RecordStageSkip records that stage was skipped for an evidence item.
+// Stages outside the known set are recorded as "unknown".
+func (e *EvidenceObserver) RecordStageSkip(ctx context.Context, stage PipelineStage, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.stageSkipCounter.Add(ctx, 1, e.measurementAttrs(attrs,
+		PipelineStageKey.String(pipelineStages.normalize(string(stage), unknownValue)),
+	))
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordStageSkip(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordStageSkip(ctx, PipelineStageEnrich)
+	fixture.observer.RecordStageSkip(ctx, PipelineStageEvaluate)
+	fixture.observer.RecordStageSkip(ctx, PipelineStageEvaluate)
+	fixture.observer.RecordStageSkip(ctx, PipelineStageDedup)
+	fixture.observer.RecordStageSkip(ctx, PipelineStage("teleport"))
+
+	got := sumByAttr(fixture.int64Points(ctx, "pipeline_stage_skip_count"), PipelineStageKey)
+	assert.Equal(t, map[string]int64{"enrich": 1, "evaluate": 2, "dedup": 1, "unknown": 1}, got)
+}
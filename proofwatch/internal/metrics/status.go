@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// EvaluationStatus is the outcome of a policy evaluation.
+type EvaluationStatus string
+
+const (
+	EvaluationStatusPass    EvaluationStatus = "pass"
+	EvaluationStatusFail    EvaluationStatus = "fail"
+	EvaluationStatusError   EvaluationStatus = "error"
+	EvaluationStatusSkipped EvaluationStatus = "skipped"
+)
+
+var evaluationStatuses = newBoundedSet(
+	string(EvaluationStatusPass),
+	string(EvaluationStatusFail),
+	string(EvaluationStatusError),
+	string(EvaluationStatusSkipped),
+)
+
+// StatusFromAttributes returns the evaluation status carried by attrs: the
+// policy.evaluation.status attribute or, when it is absent, the policy.evaluation.result
+// attribute normalized with StatusFromResult. It returns the empty status when attrs
+// carry neither.
+func StatusFromAttributes(attrs []attribute.KeyValue) EvaluationStatus {
+	var status, result string
+	for _, kv := range attrs {
+		switch kv.Key {
+		case EvaluationStatusKey:
+			status = kv.Value.Emit()
+		case EvaluationResultKey:
+			result = kv.Value.Emit()
+		}
+	}
+	if status != "" {
+		return EvaluationStatus(status)
+	}
+	return StatusFromResult(result)
+}
+
+// StatusFromResult normalizes a policy evaluation result, such as the "Passed",
+// "Failed", "Not Run" and "Not Applicable" results of OCSF and Gemara evidence, into an
+// evaluation status, ignoring case. Results that are neither passed, failed nor skipped
+// map to "unknown", and the empty result to the empty status.
+func StatusFromResult(result string) EvaluationStatus {
+	switch strings.ToLower(result) {
+	case "":
+		return ""
+	case "passed", "pass":
+		return EvaluationStatusPass
+	case "failed", "fail":
+		return EvaluationStatusFail
+	case "error":
+		return EvaluationStatusError
+	case "not run", "not applicable", "skipped":
+		return EvaluationStatusSkipped
+	default:
+		return unknownValue
+	}
+}
+
+func (e *EvidenceObserver) initStatus(meter metric.Meter) error {
+	var err error
+	e.unknownStatusCounter, err = meter.Int64Counter(
+		"evidence_unknown_status_count",
+		metric.WithDescription("The total number of evaluations reported with an unrecognized status."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create unknown status counter: %w", err)
+	}
+	return nil
+}
+
+// evaluationStatus returns the status attribute for status, recording statuses outside
+// the known set as "unknown" and counting them in evidence_unknown_status_count.
+func (e *EvidenceObserver) evaluationStatus(ctx context.Context, status EvaluationStatus, attrs []attribute.KeyValue) attribute.KeyValue {
+	normalized := evaluationStatuses.normalize(string(status), unknownValue)
+	if normalized == unknownValue {
+		e.unknownStatusCounter.Add(ctx, 1, e.measurementAttrs(attrs))
+	}
+	return EvaluationStatusKey.String(normalized)
+}
+
+// ProcessedWithStatus records processed evidence along with the status of its policy
+// evaluation. Statuses outside pass, fail, error and skipped are recorded as "unknown"
+// and also counted in evidence_unknown_status_count.
+func (e *EvidenceObserver) ProcessedWithStatus(ctx context.Context, status EvaluationStatus, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.Processed(ctx, append(attrs[:len(attrs):len(attrs)], e.evaluationStatus(ctx, status, attrs))...)
+}
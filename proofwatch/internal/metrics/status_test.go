@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestProcessedWithStatus(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.ProcessedWithStatus(ctx, EvaluationStatusPass)
+	fixture.observer.ProcessedWithStatus(ctx, EvaluationStatusPass)
+	fixture.observer.ProcessedWithStatus(ctx, EvaluationStatusFail)
+	fixture.observer.ProcessedWithStatus(ctx, EvaluationStatusError)
+	fixture.observer.ProcessedWithStatus(ctx, EvaluationStatusSkipped)
+	fixture.observer.ProcessedWithStatus(ctx, "PASSED")
+	fixture.observer.ProcessedWithStatus(ctx, "")
+
+	got := sumByAttr(fixture.int64Points(ctx, "evidence_processed_count"), EvaluationStatusKey)
+	assert.Equal(t, map[string]int64{"pass": 2, "fail": 1, "error": 1, "skipped": 1, "unknown": 2}, got)
+
+	warnings := fixture.int64Points(ctx, "evidence_unknown_status_count")
+	require.Len(t, warnings, 1)
+	assert.Equal(t, int64(2), warnings[0].Value)
+}
+
+func TestStatusFromAttributes(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs []attribute.KeyValue
+		want  EvaluationStatus
+	}{
+		{"status", []attribute.KeyValue{EvaluationStatusKey.String("fail")}, EvaluationStatusFail},
+		{"passed result", []attribute.KeyValue{EvaluationResultKey.String("Passed")}, EvaluationStatusPass},
+		{"failed result", []attribute.KeyValue{EvaluationResultKey.String("Failed")}, EvaluationStatusFail},
+		{"not applicable result", []attribute.KeyValue{EvaluationResultKey.String("Not Applicable")}, EvaluationStatusSkipped},
+		{"unknown result", []attribute.KeyValue{EvaluationResultKey.String("Unknown")}, "unknown"},
+		{"status wins", []attribute.KeyValue{EvaluationResultKey.String("Passed"), EvaluationStatusKey.String("error")}, EvaluationStatusError},
+		{"neither", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StatusFromAttributes(tt.attrs))
+		})
+	}
+}
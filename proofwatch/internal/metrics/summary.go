@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// FrameworkKey is the compliance framework attribute summaries are grouped by.
+	FrameworkKey = attribute.Key("compliance.frameworks")
+	// EvaluationStatusKey is the policy evaluation status attribute.
+	EvaluationStatusKey = attribute.Key("policy.evaluation.status")
+	// EvaluationResultKey is the policy evaluation result attribute set by OCSF and
+	// Gemara evidence, such as "Passed" or "Failed".
+	EvaluationResultKey = attribute.Key("policy.evaluation.result")
+)
+
+const (
+	// summaryTopDropReasons bounds the number of drop reasons reported in a Summary.
+	summaryTopDropReasons = 5
+)
+
+// StatusCounts tallies evaluation outcomes.
+type StatusCounts struct {
+	Pass  int64
+	Fail  int64
+	Error int64
+	Other int64
+}
+
+// ReasonCount is the number of evidence items dropped for a reason.
+type ReasonCount struct {
+	Reason string
+	Count  int64
+}
+
+// Summary is a point-in-time compliance summary.
+type Summary struct {
+	// Frameworks holds the evaluation outcomes of processed evidence by framework.
+	// Evidence without a framework is reported under "unknown".
+	Frameworks map[string]StatusCounts
+	// TopDropReasons lists the most frequent drop reasons, most frequent first.
+	TopDropReasons []ReasonCount
+	// ProcessingP95 is the 95th percentile of recently observed processing durations.
+	ProcessingP95 time.Duration
+}
+
+// summaryState accumulates the data reported by ComplianceSummary.
+type summaryState struct {
+	mu          sync.Mutex
+	frameworks  map[string]*StatusCounts
+	dropReasons map[string]int64
+	durations   durationReservoir
+}
+
+func newSummaryState() *summaryState {
+	return &summaryState{
+		frameworks:  make(map[string]*StatusCounts),
+		dropReasons: make(map[string]int64),
+	}
+}
+
+func (s *summaryState) processed(attrs []attribute.KeyValue) {
+	framework := unknownValue
+	for _, kv := range attrs {
+		if kv.Key == FrameworkKey {
+			framework = kv.Value.Emit()
+		}
+	}
+	status := strings.ToLower(string(StatusFromAttributes(attrs)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts, ok := s.frameworks[framework]
+	if !ok {
+		counts = &StatusCounts{}
+		s.frameworks[framework] = counts
+	}
+	switch status {
+	case "pass", "passed":
+		counts.Pass++
+	case "fail", "failed":
+		counts.Fail++
+	case "error":
+		counts.Error++
+	default:
+		counts.Other++
+	}
+}
+
+func (s *summaryState) dropped(attrs []attribute.KeyValue) {
+	reason := unknownValue
+	for _, kv := range attrs {
+		if kv.Key == DropReasonKey {
+			reason = kv.Value.Emit()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropReasons[reason]++
+}
+
+// duration keeps d among the recent processing durations.
+func (s *summaryState) duration(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.durations.add(d)
+}
+
+// ComplianceSummary returns pass/fail/error counts per framework, the most frequent
+// drop reasons, and the processing p95 accumulated since the observer was created.
+func (e *EvidenceObserver) ComplianceSummary(ctx context.Context) (Summary, error) {
+	if err := ctx.Err(); err != nil {
+		return Summary{}, err
+	}
+
+	s := e.summary
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summary := Summary{Frameworks: make(map[string]StatusCounts, len(s.frameworks))}
+	for framework, counts := range s.frameworks {
+		summary.Frameworks[framework] = *counts
+	}
+
+	for reason, count := range s.dropReasons {
+		summary.TopDropReasons = append(summary.TopDropReasons, ReasonCount{Reason: reason, Count: count})
+	}
+	slices.SortFunc(summary.TopDropReasons, func(a, b ReasonCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Reason, b.Reason)
+	})
+	if len(summary.TopDropReasons) > summaryTopDropReasons {
+		summary.TopDropReasons = summary.TopDropReasons[:summaryTopDropReasons]
+	}
+
+	summary.ProcessingP95 = s.durations.percentile(0.95)
+	return summary, nil
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestComplianceSummary(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	record := func(framework, status string, n int) {
+		for i := 0; i < n; i++ {
+			fixture.observer.Processed(ctx, FrameworkKey.String(framework), EvaluationStatusKey.String(status))
+		}
+	}
+	record("NIST-800-53", "pass", 3)
+	record("NIST-800-53", "fail", 2)
+	record("SOC2", "pass", 1)
+	record("SOC2", "error", 1)
+	fixture.observer.Processed(ctx, EvaluationStatusKey.String("skipped"))
+
+	for reason, n := range map[string]int{"timeout": 3, "validation_failed": 2, "a": 1, "b": 1, "c": 1, "d": 1} {
+		for i := 0; i < n; i++ {
+			fixture.observer.Dropped(ctx, attribute.String("reason", reason))
+		}
+	}
+
+	for i := 1; i <= 100; i++ {
+		fixture.observer.summary.duration(time.Duration(i) * time.Millisecond)
+	}
+
+	summary, err := fixture.observer.ComplianceSummary(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]StatusCounts{
+		"NIST-800-53": {Pass: 3, Fail: 2},
+		"SOC2":        {Pass: 1, Error: 1},
+		"unknown":     {Other: 1},
+	}, summary.Frameworks)
+	assert.Equal(t, []ReasonCount{
+		{Reason: "timeout", Count: 3},
+		{Reason: "validation_failed", Count: 2},
+		{Reason: "a", Count: 1},
+		{Reason: "b", Count: 1},
+		{Reason: "c", Count: 1},
+	}, summary.TopDropReasons)
+	assert.Equal(t, 95*time.Millisecond, summary.ProcessingP95)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = fixture.observer.ComplianceSummary(cancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestComplianceSummaryEvaluationResult(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	for _, result := range []string{"Passed", "Passed", "Failed", "Needs Review"} {
+		fixture.observer.Processed(ctx, FrameworkKey.String("CIS"), EvaluationResultKey.String(result))
+	}
+
+	summary, err := fixture.observer.ComplianceSummary(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]StatusCounts{"CIS": {Pass: 2, Fail: 1, Other: 1}}, summary.Frameworks)
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// SubjectBucketKey is the bucketed evidence subject attribute. Raw subjects are never recorded.
+const SubjectBucketKey = attribute.Key("subject.bucket")
+
+// defaultSubjectBuckets is the number of subject buckets used unless WithSubjectBuckets is set.
+const defaultSubjectBuckets = 16
+
+// WithSubjectBuckets sets the number of buckets evidence subjects are hashed into.
+// Non-positive values keep the default.
+func WithSubjectBuckets(n int) Option {
+	return func(cfg *observerConfig) {
+		if n > 0 {
+			cfg.subjectBuckets = n
+		}
+	}
+}
+
+func (e *EvidenceObserver) initSuperseded(meter metric.Meter) error {
+	var err error
+	e.supersededCounter, err = meter.Int64Counter(
+		"evidence_superseded_count",
+		metric.WithDescription("The total number of evidence items superseded by newer evidence for the same subject."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create superseded counter: %w", err)
+	}
+	return nil
+}
+
+// RecordSuperseded records evidence for subject superseded by newer evidence, which
+// should no longer count towards compliance.
+func (e *EvidenceObserver) RecordSuperseded(ctx context.Context, subject string, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.supersededCounter.Add(ctx, 1, e.measurementAttrs(attrs, e.subjectBucket(subject)))
+}
+
+func (e *EvidenceObserver) subjectBucket(subject string) attribute.KeyValue {
+	n := e.cfg.subjectBuckets
+	if n <= 0 {
+		n = defaultSubjectBuckets
+	}
+	return SubjectBucketKey.String(bucketOf(subject, n))
+}
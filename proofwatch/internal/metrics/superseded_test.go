@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordSuperseded(t *testing.T) {
+	const buckets = 4
+	fixture := setupEvidenceObserverTest(t, WithSubjectBuckets(buckets))
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		fixture.observer.RecordSuperseded(ctx, "repo/service-"+strconv.Itoa(i))
+	}
+	fixture.observer.RecordSuperseded(ctx, "repo/service-0")
+
+	points := fixture.int64Points(ctx, "evidence_superseded_count")
+	assert.LessOrEqual(t, len(points), buckets)
+
+	var total int64
+	for _, dp := range points {
+		bucket, ok := attrValue(dp.Attributes, SubjectBucketKey)
+		require.True(t, ok)
+		n, err := strconv.Atoi(bucket)
+		require.NoError(t, err)
+		assert.True(t, n >= 0 && n < buckets)
+		total += dp.Value
+	}
+	assert.Equal(t, int64(21), total)
+}
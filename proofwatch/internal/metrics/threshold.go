@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ThresholdPassedKey records whether a value met its threshold.
+const ThresholdPassedKey = attribute.Key("threshold.passed")
+
+// thresholdDeltaBuckets are symmetric around zero so overshoot and shortfall
+// resolve equally.
+var thresholdDeltaBuckets = []float64{-100, -50, -25, -10, -5, -1, 0, 1, 5, 10, 25, 50, 100}
+
+func (e *EvidenceObserver) initThreshold(meter metric.Meter) error {
+	var err error
+	e.thresholdCounter, err = meter.Int64Counter(
+		"threshold_check_count",
+		metric.WithDescription("The total number of quantitative threshold checks, by outcome."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create threshold check counter: %w", err)
+	}
+
+	e.thresholdDelta, err = meter.Float64Histogram(
+		"threshold_delta",
+		metric.WithDescription("The signed difference between a checked value and its threshold."),
+		metric.WithExplicitBucketBoundaries(thresholdDeltaBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create threshold delta histogram: %w", err)
+	}
+	return nil
+}
+
+// RecordThresholdCheck records a quantitative check of value against threshold.
+// The check passes when value is at least threshold, and value-threshold is recorded
+// so both the overshoot and the shortfall can be analyzed.
+func (e *EvidenceObserver) RecordThresholdCheck(ctx context.Context, value, threshold float64, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	opt := e.measurementAttrs(attrs, ThresholdPassedKey.Bool(value >= threshold))
+	e.thresholdCounter.Add(ctx, 1, opt)
+	e.thresholdDelta.Record(ctx, value-threshold, opt)
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordThresholdCheck(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t)
+	ctx := context.Background()
+
+	fixture.observer.RecordThresholdCheck(ctx, 92, 80)
+	fixture.observer.RecordThresholdCheck(ctx, 80, 80)
+	fixture.observer.RecordThresholdCheck(ctx, 65, 80)
+
+	got := sumByAttr(fixture.int64Points(ctx, "threshold_check_count"), ThresholdPassedKey)
+	assert.Equal(t, map[string]int64{"true": 2, "false": 1}, got)
+
+	sums := map[string]float64{}
+	for _, dp := range fixture.float64Histogram(ctx, "threshold_delta") {
+		passed, _ := attrValue(dp.Attributes, ThresholdPassedKey)
+		sums[passed] = dp.Sum
+	}
+	assert.Equal(t, map[string]float64{"true": 12, "false": -15}, sums)
+}
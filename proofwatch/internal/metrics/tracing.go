@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracer makes Processed and Dropped start a short span from tracer around each
+// recording, tagged with the policy.id attribute when present. Because the recording
+// is made in the span's context, SDKs with exemplars enabled attach the span's trace
+// to the recorded data points, linking metric spikes to the traces behind them.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(cfg *observerConfig) {
+		cfg.tracer = tracer
+	}
+}
+
+// startRecordSpan starts the span wrapping a Processed or Dropped recording.
+func (e *EvidenceObserver) startRecordSpan(ctx context.Context, name string, attrs []attribute.KeyValue) (context.Context, trace.Span) {
+	var spanAttrs []attribute.KeyValue
+	for _, kv := range attrs {
+		if kv.Key == PolicyIDKey {
+			spanAttrs = append(spanAttrs, kv)
+		}
+	}
+	return e.cfg.tracer.Start(ctx, name, trace.WithAttributes(spanAttrs...))
+}
+
+// ProcessedWithSpan records processed evidence and starts an "evidence.processed"
+// span from tracer carrying the same attributes as the recorded data point. The
+// caller owns the returned span and must end it.
+func (e *EvidenceObserver) ProcessedWithSpan(ctx context.Context, tracer trace.Tracer, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, "evidence.processed", trace.WithAttributes(e.attributes(attrs)...))
+	e.Processed(ctx, attrs...)
+	return ctx, span
+}
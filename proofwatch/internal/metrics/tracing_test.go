@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestProcessedWithSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	fixture := setupEvidenceObserverTest(t, WithCloudContext("aws", 4))
+	ctx := context.Background()
+
+	spanCtx, span := fixture.observer.ProcessedWithSpan(ctx, tp.Tracer("test-tracer"), attribute.String("policy.id", "policy-1"))
+	assert.True(t, trace.SpanFromContext(spanCtx).SpanContext().Equal(span.SpanContext()))
+	span.End()
+
+	points := fixture.int64Points(ctx, "evidence_processed_count")
+	require.Len(t, points, 1)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "evidence.processed", spans[0].Name)
+	spanAttrs := attribute.NewSet(spans[0].Attributes...)
+	assert.True(t, points[0].Attributes.Equals(&spanAttrs))
+}
+
+func TestWithTracer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	fixture := setupEvidenceObserverTest(t, WithTracer(tp.Tracer("test-tracer")))
+	ctx := context.Background()
+
+	fixture.observer.Dropped(ctx, attribute.String("policy.id", "policy-1"), attribute.String("reason", "timeout"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "evidence.record.dropped", spans[0].Name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("policy.id", "policy-1")}, spans[0].Attributes)
+
+	points := fixture.int64Points(ctx, "evidence_dropped_count")
+	require.Len(t, points, 1)
+	require.Len(t, points[0].Exemplars, 1)
+	traceID := spans[0].SpanContext.TraceID()
+	assert.Equal(t, traceID[:], points[0].Exemplars[0].TraceID)
+}
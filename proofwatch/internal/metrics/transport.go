@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TransportEncryptedKey records whether evidence was received over an encrypted channel.
+const TransportEncryptedKey = attribute.Key("transport.encrypted")
+
+// WithStrictTransport drops evidence received over plaintext transport instead of
+// recording it as processed.
+func WithStrictTransport() Option {
+	return func(cfg *observerConfig) {
+		cfg.strictTransport = true
+	}
+}
+
+// ProcessedWithTransport records processed evidence along with whether it was received
+// over an encrypted channel. In strict mode, plaintext evidence is recorded as dropped
+// with DropReasonPlaintextTransport.
+func (e *EvidenceObserver) ProcessedWithTransport(ctx context.Context, encrypted bool, attrs ...attribute.KeyValue) {
+	transport := TransportEncryptedKey.Bool(encrypted)
+	if !encrypted && e.cfg.strictTransport {
+		e.droppedWithReason(ctx, DropReasonPlaintextTransport, attrs, transport)
+		return
+	}
+	e.Processed(ctx, append(attrs[:len(attrs):len(attrs)], transport)...)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessedWithTransport(t *testing.T) {
+	t.Run("records encrypted and plaintext", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		fixture.observer.ProcessedWithTransport(ctx, true)
+		fixture.observer.ProcessedWithTransport(ctx, false)
+
+		got := sumByAttr(fixture.int64Points(ctx, "evidence_processed_count"), TransportEncryptedKey)
+		assert.Equal(t, map[string]int64{"true": 1, "false": 1}, got)
+	})
+
+	t.Run("strict mode drops plaintext", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t, WithStrictTransport())
+		ctx := context.Background()
+
+		fixture.observer.ProcessedWithTransport(ctx, true)
+		fixture.observer.ProcessedWithTransport(ctx, false)
+
+		processed := fixture.int64Points(ctx, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		encrypted, _ := attrValue(processed[0].Attributes, TransportEncryptedKey)
+		assert.Equal(t, "true", encrypted)
+
+		dropped := fixture.int64Points(ctx, "evidence_dropped_count")
+		require.Len(t, dropped, 1)
+		reason, _ := attrValue(dropped[0].Attributes, DropReasonKey)
+		assert.Equal(t, string(DropReasonPlaintextTransport), reason)
+		encrypted, _ = attrValue(dropped[0].Attributes, TransportEncryptedKey)
+		assert.Equal(t, "false", encrypted)
+	})
+}
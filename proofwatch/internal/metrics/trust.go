@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TrustAnchorKey is the bounded trust anchor attribute. Anchors are registered with
+// WithTrustAnchors.
+const TrustAnchorKey = attribute.Key("trust.anchor")
+
+// untrustedAnchor is recorded for evidence signed by an unregistered trust anchor.
+const untrustedAnchor = "untrusted"
+
+// WithTrustAnchors registers the trust anchors accepted by ProcessedWithTrustAnchor.
+func WithTrustAnchors(anchorIDs ...string) Option {
+	return WithAllowedValues(TrustAnchorKey, anchorIDs...)
+}
+
+// ProcessedWithTrustAnchor records processed evidence along with the trust anchor
+// that signed it. Anchors that were not registered are recorded as "untrusted".
+func (e *EvidenceObserver) ProcessedWithTrustAnchor(ctx context.Context, anchorID string, attrs ...attribute.KeyValue) {
+	anchor := TrustAnchorKey.String(e.allowedValueOr(TrustAnchorKey, anchorID, nil, untrustedAnchor))
+	e.Processed(ctx, append(attrs[:len(attrs):len(attrs)], anchor)...)
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessedWithTrustAnchor(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithTrustAnchors("sigstore-root", "corp-ca"))
+	ctx := context.Background()
+
+	fixture.observer.ProcessedWithTrustAnchor(ctx, "sigstore-root")
+	fixture.observer.ProcessedWithTrustAnchor(ctx, "corp-ca")
+	fixture.observer.ProcessedWithTrustAnchor(ctx, "corp-ca")
+	fixture.observer.ProcessedWithTrustAnchor(ctx, "self-signed")
+	fixture.observer.ProcessedWithTrustAnchor(ctx, "")
+
+	got := sumByAttr(fixture.int64Points(ctx, "evidence_processed_count"), TrustAnchorKey)
+	assert.Equal(t, map[string]int64{"sigstore-root": 1, "corp-ca": 2, "untrusted": 2}, got)
+}
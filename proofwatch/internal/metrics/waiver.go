@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// WaiverIDKey is the bounded waiver identifier attribute.
+	WaiverIDKey = attribute.Key("waiver.id")
+	// WaiverStateKey explains why a waiver was not applied.
+	WaiverStateKey = attribute.Key("waiver.state")
+)
+
+// WithWaivers registers approved waivers and the time each one expires.
+// Registering a waiver again replaces its expiry.
+func WithWaivers(expiries map[string]time.Time) Option {
+	return func(cfg *observerConfig) {
+		if cfg.waivers == nil {
+			cfg.waivers = make(map[string]time.Time, len(expiries))
+		}
+		for id, expiry := range expiries {
+			cfg.waivers[id] = expiry
+		}
+	}
+}
+
+func (e *EvidenceObserver) initWaiver(meter metric.Meter) error {
+	var err error
+	e.waiverAppliedCounter, err = meter.Int64Counter(
+		"waiver_applied_count",
+		metric.WithDescription("The total number of findings waived by an active approved exception."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create waiver applied counter: %w", err)
+	}
+
+	e.waiverInactiveCounter, err = meter.Int64Counter(
+		"waiver_inactive_count",
+		metric.WithDescription("The total number of findings referencing an expired or unregistered exception."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create waiver inactive counter: %w", err)
+	}
+	return nil
+}
+
+// RecordWaiver records a finding waived by waiverID. Only registered, unexpired
+// waivers count as applied; expired and unregistered waivers are recorded on a
+// separate counter, with unregistered waiver IDs collapsed to "other".
+func (e *EvidenceObserver) RecordWaiver(ctx context.Context, waiverID string, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	expiry, ok := e.cfg.waivers[waiverID]
+	switch {
+	case !ok:
+		e.waiverInactiveCounter.Add(ctx, 1, e.measurementAttrs(attrs,
+			WaiverIDKey.String(otherValue),
+			WaiverStateKey.String("unregistered"),
+		))
+	case !time.Now().Before(expiry):
+		e.waiverInactiveCounter.Add(ctx, 1, e.measurementAttrs(attrs,
+			WaiverIDKey.String(waiverID),
+			WaiverStateKey.String("expired"),
+		))
+	default:
+		e.waiverAppliedCounter.Add(ctx, 1, e.measurementAttrs(attrs, WaiverIDKey.String(waiverID)))
+	}
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordWaiver(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithWaivers(map[string]time.Time{
+		"EXC-1": time.Now().Add(24 * time.Hour),
+		"EXC-2": time.Now().Add(-24 * time.Hour),
+	}))
+	ctx := context.Background()
+
+	fixture.observer.RecordWaiver(ctx, "EXC-1")
+	fixture.observer.RecordWaiver(ctx, "EXC-1")
+	fixture.observer.RecordWaiver(ctx, "EXC-2")
+	fixture.observer.RecordWaiver(ctx, "EXC-404")
+
+	applied := fixture.int64Points(ctx, "waiver_applied_count")
+	require.Len(t, applied, 1)
+	assert.Equal(t, map[string]int64{"EXC-1": 2}, sumByAttr(applied, WaiverIDKey))
+
+	inactive := fixture.int64Points(ctx, "waiver_inactive_count")
+	require.Len(t, inactive, 2)
+	assert.Equal(t, map[string]int64{"EXC-2": 1, "other": 1}, sumByAttr(inactive, WaiverIDKey))
+	assert.Equal(t, map[string]int64{"expired": 1, "unregistered": 1}, sumByAttr(inactive, WaiverStateKey))
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func (e *EvidenceObserver) initWeighted(meter metric.Meter) error {
+	var err error
+	e.weightedPassed, err = meter.Float64Counter(
+		"weighted_passed_total",
+		metric.WithDescription("The sum of the weights of passing control evaluations."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create weighted passed counter: %w", err)
+	}
+
+	e.weightedTotal, err = meter.Float64Counter(
+		"weighted_total",
+		metric.WithDescription("The sum of the weights of all control evaluations."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create weighted total counter: %w", err)
+	}
+	return nil
+}
+
+// RecordWeightedResult records a control evaluation weighted by the control's
+// importance, so a weighted compliance score can be derived as
+// weighted_passed_total / weighted_total. Negative and non-finite weights are
+// rejected with an error and nothing is recorded.
+func (e *EvidenceObserver) RecordWeightedResult(ctx context.Context, weight float64, passed bool, attrs ...attribute.KeyValue) error {
+	if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return fmt.Errorf("invalid weight %v: must be a finite non-negative number", weight)
+	}
+	if !e.recording() {
+		return nil
+	}
+	opt := e.measurementAttrs(attrs)
+	e.weightedTotal.Add(ctx, weight, opt)
+	if passed {
+		e.weightedPassed.Add(ctx, weight, opt)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordWeightedResult(t *testing.T) {
+	t.Run("derives weighted score", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		require.NoError(t, fixture.observer.RecordWeightedResult(ctx, 5, true))
+		require.NoError(t, fixture.observer.RecordWeightedResult(ctx, 3, false))
+		require.NoError(t, fixture.observer.RecordWeightedResult(ctx, 1.5, true))
+		require.NoError(t, fixture.observer.RecordWeightedResult(ctx, 0.5, false))
+
+		passed := fixture.float64Points(ctx, "weighted_passed_total")
+		total := fixture.float64Points(ctx, "weighted_total")
+		require.Len(t, passed, 1)
+		require.Len(t, total, 1)
+		assert.InDelta(t, 6.5, passed[0].Value, 1e-9)
+		assert.InDelta(t, 10, total[0].Value, 1e-9)
+		assert.InDelta(t, 0.65, passed[0].Value/total[0].Value, 1e-9)
+	})
+
+	t.Run("rejects invalid weights", func(t *testing.T) {
+		fixture := setupEvidenceObserverTest(t)
+		ctx := context.Background()
+
+		for _, weight := range []float64{-1, math.NaN(), math.Inf(1)} {
+			assert.Error(t, fixture.observer.RecordWeightedResult(ctx, weight, true), weight)
+		}
+		assert.False(t, fixture.collected(ctx, "weighted_total"))
+	})
+}
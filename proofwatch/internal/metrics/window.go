@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// WindowKey is the bounded validity window attribute, such as business hours or a
+// maintenance window. Windows are registered with WithWindows.
+const WindowKey = attribute.Key("window")
+
+// WithWindows registers the validity windows accepted by RecordOutOfWindow.
+func WithWindows(names ...string) Option {
+	return WithAllowedValues(WindowKey, names...)
+}
+
+func (e *EvidenceObserver) initWindow(meter metric.Meter) error {
+	var err error
+	e.outOfWindowCounter, err = meter.Int64Counter(
+		"evaluation_out_of_window_count",
+		metric.WithDescription("The total number of evaluations that fell outside the validity window of their control, by window."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create out-of-window counter: %w", err)
+	}
+	return nil
+}
+
+// RecordOutOfWindow records an evaluation that fell outside window, the period its
+// control is valid to be evaluated in. Unregistered windows are recorded as "other".
+func (e *EvidenceObserver) RecordOutOfWindow(ctx context.Context, window string, attrs ...attribute.KeyValue) {
+	if !e.recording() {
+		return
+	}
+	e.outOfWindowCounter.Add(ctx, 1, e.measurementAttrs(attrs, e.bounded(WindowKey, window, nil)))
+}
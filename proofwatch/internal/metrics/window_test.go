@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordOutOfWindow(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithWindows("business-hours", "maintenance"))
+	ctx := context.Background()
+
+	// In-window evaluations are recorded as regular processed evidence.
+	fixture.observer.Processed(ctx, WindowKey.String("business-hours"))
+	fixture.observer.Processed(ctx, WindowKey.String("maintenance"))
+
+	fixture.observer.RecordOutOfWindow(ctx, "business-hours")
+	fixture.observer.RecordOutOfWindow(ctx, "business-hours")
+	fixture.observer.RecordOutOfWindow(ctx, "maintenance")
+	fixture.observer.RecordOutOfWindow(ctx, "weekend")
+
+	got := sumByAttr(fixture.int64Points(ctx, "evaluation_out_of_window_count"), WindowKey)
+	assert.Equal(t, map[string]int64{"business-hours": 2, "maintenance": 1, "other": 1}, got)
+
+	processed := sumByAttr(fixture.int64Points(ctx, "evidence_processed_count"), WindowKey)
+	assert.Equal(t, map[string]int64{"business-hours": 1, "maintenance": 1}, processed)
+}
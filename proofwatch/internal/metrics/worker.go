@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultWorkerBuckets is the number of worker buckets used unless WithWorkerBuckets is set.
+const defaultWorkerBuckets = 8
+
+// WorkerBucketKey is the bounded worker bucket attribute.
+const WorkerBucketKey = attribute.Key("worker.bucket")
+
+// WithWorkerBuckets sets the number of buckets worker IDs are folded into by
+// ProcessedByWorker. Non-positive values keep the default.
+func WithWorkerBuckets(n int) Option {
+	return func(cfg *observerConfig) {
+		if n > 0 {
+			cfg.workerBuckets = n
+		}
+	}
+}
+
+// ProcessedByWorker records processed evidence attributed to the bucket of workerID,
+// computed as workerID modulo the configured bucket count.
+func (e *EvidenceObserver) ProcessedByWorker(ctx context.Context, workerID int, attrs ...attribute.KeyValue) {
+	n := e.cfg.workerBuckets
+	if n <= 0 {
+		n = defaultWorkerBuckets
+	}
+	bucket := workerID % n
+	if bucket < 0 {
+		bucket += n
+	}
+	e.Processed(ctx, append(attrs[:len(attrs):len(attrs)], WorkerBucketKey.Int(bucket))...)
+}
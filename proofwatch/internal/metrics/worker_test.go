@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessedByWorker(t *testing.T) {
+	fixture := setupEvidenceObserverTest(t, WithWorkerBuckets(4))
+	ctx := context.Background()
+
+	for worker := -2; worker < 10; worker++ {
+		fixture.observer.ProcessedByWorker(ctx, worker)
+	}
+
+	got := sumByAttr(fixture.int64Points(ctx, "evidence_processed_count"), WorkerBucketKey)
+	assert.Equal(t, map[string]int64{"0": 3, "1": 3, "2": 3, "3": 3}, got)
+}
@@ -0,0 +1,74 @@
+package proofwatch
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// defaultMetricKeys are the evidence attributes recorded as metric labels by
+// Instrumentation. Their values come from bounded sets, such as policy and control
+// identifiers, frameworks and evaluation outcomes. Free-form attributes, such as
+// evaluation messages, target names and assessment IDs, stay on spans and logs only.
+var defaultMetricKeys = []attribute.Key{
+	POLICY_RULE_ID,
+	POLICY_ENGINE_NAME,
+	POLICY_ENGINE_VERSION,
+	POLICY_EVALUATION_RESULT,
+	POLICY_TARGET_TYPE,
+	POLICY_TARGET_ENVIRONMENT,
+	COMPLIANCE_CONTROL_ID,
+	COMPLIANCE_CONTROL_CATALOG_ID,
+	COMPLIANCE_CONTROL_CATEGORY,
+	COMPLIANCE_CONTROL_APPLICABILITY,
+	COMPLIANCE_ENRICHMENT_STATUS,
+	COMPLIANCE_FRAMEWORKS,
+	COMPLIANCE_REMEDIATION_ACTION,
+	COMPLIANCE_REMEDIATION_STATUS,
+	COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE,
+	COMPLIANCE_RISK_LEVEL,
+	COMPLIANCE_STATUS,
+	CLOUDEVENTS_EVENT_TYPE,
+	metrics.PolicyIDKey,
+	metrics.SourceKey,
+	metrics.BundleVersionKey,
+	metrics.CloudProviderKey,
+	metrics.EvaluationStatusKey,
+	metrics.SeverityKey,
+}
+
+// WithMetricAttributes adds keys to the evidence attributes recorded as metric labels.
+// Only add keys whose values come from a small, bounded set: every distinct value
+// creates a new time series. Keys registered with WithAllowedValues or
+// WithCardinalityLimit are added automatically, as is the cloud account ID once
+// WithCloudContext buckets it.
+func WithMetricAttributes(keys ...attribute.Key) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		cfg.MetricKeys = append(cfg.MetricKeys, keys...)
+	})
+}
+
+// newMetricKeys returns the set of evidence attributes recorded as metric labels: the
+// defaults along with extra.
+func newMetricKeys(extra []attribute.Key) map[attribute.Key]struct{} {
+	keys := make(map[attribute.Key]struct{}, len(defaultMetricKeys)+len(extra))
+	for _, key := range defaultMetricKeys {
+		keys[key] = struct{}{}
+	}
+	for _, key := range extra {
+		keys[key] = struct{}{}
+	}
+	return keys
+}
+
+// metricAttributes returns the attributes of attrs recorded as metric labels, those
+// whose key is in keys.
+func metricAttributes(keys map[attribute.Key]struct{}, attrs []attribute.KeyValue) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		if _, ok := keys[kv.Key]; ok {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
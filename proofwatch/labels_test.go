@@ -0,0 +1,65 @@
+package proofwatch_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestMetricAttributes(t *testing.T) {
+	ctx := context.Background()
+	evidence := observedEvidence{
+		attribute.String(proofwatch.POLICY_RULE_ID, "AC-1"),
+		attribute.String(proofwatch.POLICY_EVALUATION_MESSAGE, "bucket acme-prod is public"),
+		attribute.String(proofwatch.POLICY_TARGET_ID, "i-0abc123"),
+		attribute.String(proofwatch.COMPLIANCE_ASSESSMENT_ID, "3f1c7a"),
+		attribute.String("tenant", "acme"),
+		attribute.String("cloud.account.id", "123456789012"),
+	}
+	keys := func(set attribute.Set) []attribute.Key {
+		var keys []attribute.Key
+		for _, kv := range set.ToSlice() {
+			keys = append(keys, kv.Key)
+		}
+		return keys
+	}
+
+	t.Run("records bounded attributes only", func(t *testing.T) {
+		inst, reader := setupObserverTest(t)
+
+		require.NoError(t, inst.Process(ctx, evidence, succeed))
+		require.Error(t, inst.Process(ctx, evidence, func(context.Context, proofwatch.Evidence) error { return assert.AnError }))
+
+		processed := sumPoints(t, reader, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		assert.Equal(t, []attribute.Key{proofwatch.POLICY_RULE_ID}, keys(processed[0].Attributes))
+		dropped := sumPoints(t, reader, "evidence_dropped_count")
+		require.Len(t, dropped, 1)
+		assert.Equal(t, []attribute.Key{proofwatch.POLICY_RULE_ID, "reason"}, keys(dropped[0].Attributes))
+	})
+
+	t.Run("WithMetricAttributes adds keys", func(t *testing.T) {
+		inst, reader := setupObserverTest(t, proofwatch.WithMetricAttributes("tenant"))
+
+		require.NoError(t, inst.Process(ctx, evidence, succeed))
+
+		processed := sumPoints(t, reader, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		assert.Equal(t, []attribute.Key{proofwatch.POLICY_RULE_ID, "tenant"}, keys(processed[0].Attributes))
+	})
+
+	t.Run("WithAllowedValues adds its key", func(t *testing.T) {
+		inst, reader := setupObserverTest(t, proofwatch.WithAllowedValues("tenant", "acme"))
+
+		require.NoError(t, inst.Process(ctx, evidence, succeed))
+
+		processed := sumPoints(t, reader, "evidence_processed_count")
+		require.Len(t, processed, 1)
+		assert.Equal(t, "acme", value(processed[0].Attributes, "tenant"))
+	})
+}
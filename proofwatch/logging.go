@@ -0,0 +1,59 @@
+package proofwatch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// dropLevel returns the level drops with reason are logged at: error for failures of
+// the pipeline itself, and warn for evidence rejected on its own merits or shed under
+// load.
+func dropLevel(reason DropReason) slog.Level {
+	switch reason {
+	case DropReasonProcessingError, DropReasonTimeout, DropReasonUnknown:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// logDropped logs a dropped evidence item, if a logger is configured. Evidence failing
+// validation is logged with the JSON pointer to the first failing field.
+func (i *Instrumentation) logDropped(ctx context.Context, reason DropReason, policyID string, err error) {
+	if i.logger == nil {
+		return
+	}
+	level := dropLevel(reason)
+	if !i.logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{slog.String("reason", string(reason))}
+	if policyID != "" {
+		attrs = append(attrs, slog.String("policy.id", policyID))
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) && len(validationErr.Fields) > 0 {
+		attrs = append(attrs, slog.String(string(ValidationFieldKey), validationErr.Fields[0].Field))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	i.logger.LogAttrs(ctx, level, "evidence dropped", attrs...)
+}
+
+// logProcessed logs a processed evidence item at debug level, if a logger is
+// configured.
+func (i *Instrumentation) logProcessed(ctx context.Context, result EvaluationResult) {
+	if i.logger == nil || !i.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	var attrs []slog.Attr
+	if result.PolicyID != "" {
+		attrs = append(attrs, slog.String("policy.id", result.PolicyID))
+	}
+	if result.Status != "" {
+		attrs = append(attrs, slog.String("policy.evaluation.status", string(result.Status)))
+	}
+	i.logger.LogAttrs(ctx, slog.LevelDebug, "evidence processed", attrs...)
+}
@@ -0,0 +1,132 @@
+package proofwatch
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// captureHandler records the log records it handles.
+type captureHandler struct {
+	mu      sync.Mutex
+	level   slog.Level
+	records []slog.Record
+}
+
+func (h *captureHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *captureHandler) Handle(_ context.Context, record slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, record.Clone())
+	return nil
+}
+
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *captureHandler) WithGroup(string) slog.Handler      { return h }
+
+// recordAttrs returns the attributes of record by key.
+func recordAttrs(record slog.Record) map[string]string {
+	attrs := map[string]string{}
+	record.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value.String()
+		return true
+	})
+	return attrs
+}
+
+func setupLoggingTest(t *testing.T, level slog.Level) (*Instrumentation, *captureHandler) {
+	t.Helper()
+
+	handler := &captureHandler{level: level}
+	inst, err := NewInstrumentation(
+		WithMeterProvider(sdkmetric.NewMeterProvider()),
+		WithSlogLogger(slog.New(handler)),
+	)
+	require.NoError(t, err)
+	return inst, handler
+}
+
+func TestInstrumentationLogging(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("drop logs one entry with reason, policy and error", func(t *testing.T) {
+		inst, handler := setupLoggingTest(t, slog.LevelInfo)
+
+		err := inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error {
+			return assert.AnError
+		})
+		require.Error(t, err)
+
+		require.Len(t, handler.records, 1)
+		record := handler.records[0]
+		assert.Equal(t, slog.LevelError, record.Level)
+		assert.Equal(t, "evidence dropped", record.Message)
+		assert.Equal(t, map[string]string{
+			"reason":    "processing_error",
+			"policy.id": "test-policy",
+			"error":     assert.AnError.Error(),
+		}, recordAttrs(record))
+	})
+
+	t.Run("evidence rejected on its own merits logs at warn", func(t *testing.T) {
+		inst, handler := setupLoggingTest(t, slog.LevelInfo)
+
+		require.Error(t, inst.Reject(ctx, createTestEvidence(), ErrRateLimited))
+
+		require.Len(t, handler.records, 1)
+		assert.Equal(t, slog.LevelWarn, handler.records[0].Level)
+		assert.Equal(t, "rate_limited", recordAttrs(handler.records[0])["reason"])
+	})
+
+	t.Run("validation failure logs the failing field", func(t *testing.T) {
+		inst, handler := setupLoggingTest(t, slog.LevelInfo)
+		verr := &ValidationError{Fields: []FieldError{{Field: "/policy/uid", Keyword: "required", Message: "missing required property"}}}
+
+		require.Error(t, inst.Reject(ctx, createTestEvidence(), verr))
+
+		require.Len(t, handler.records, 1)
+		assert.Equal(t, "/policy/uid", recordAttrs(handler.records[0])[string(ValidationFieldKey)])
+	})
+
+	t.Run("processing logs at debug", func(t *testing.T) {
+		inst, handler := setupLoggingTest(t, slog.LevelDebug)
+
+		_, err := inst.Evaluate(ctx, createTestEvidence(), evaluatorFunc(func(context.Context, Evidence) (EvaluationResult, error) {
+			return EvaluationResult{PolicyID: "policy-1", Status: EvaluationStatusPass}, nil
+		}))
+		require.NoError(t, err)
+
+		require.Len(t, handler.records, 1)
+		record := handler.records[0]
+		assert.Equal(t, slog.LevelDebug, record.Level)
+		assert.Equal(t, "evidence processed", record.Message)
+		assert.Equal(t, map[string]string{
+			"policy.id":                "policy-1",
+			"policy.evaluation.status": "pass",
+		}, recordAttrs(record))
+	})
+
+	t.Run("processing is not logged above debug", func(t *testing.T) {
+		inst, handler := setupLoggingTest(t, slog.LevelInfo)
+
+		require.NoError(t, inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return nil }))
+		assert.Empty(t, handler.records)
+	})
+
+	t.Run("no logger configured", func(t *testing.T) {
+		inst, _, _ := setupInstrumentationTest(t)
+
+		assert.NotPanics(t, func() {
+			_ = inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return nil })
+			_ = inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return assert.AnError })
+		})
+	})
+}
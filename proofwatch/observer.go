@@ -0,0 +1,444 @@
+package proofwatch
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// EvidenceObserver records the evidence metrics of an Instrumentation. Process,
+// Evaluate and Reject record processed and dropped evidence through it; Observer gives
+// access to the more specific measurements it offers.
+type EvidenceObserver = metrics.EvidenceObserver
+
+// Observer returns the evidence observer of the Instrumentation, for recording
+// measurements beyond processed and dropped evidence. Reset replaces the observer, so
+// call Observer again after resetting.
+func (i *Instrumentation) Observer() *EvidenceObserver {
+	return i.observer
+}
+
+// withObserverOption returns an OptionFunc configuring the evidence observer with opt.
+func withObserverOption(opt metrics.Option) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		cfg.ObserverOptions = append(cfg.ObserverOptions, opt)
+	})
+}
+
+// WithCloudContext segments all evidence metrics by cloud provider and account. The
+// provider is normalized against the known cloud providers, and any cloud.account.id
+// attribute is replaced by a cloud.account bucket in the range [0, accountBuckets), so
+// account cardinality stays bounded. Without it, cloud.account.id is not recorded on
+// metrics at all.
+func WithCloudContext(provider string, accountBuckets int) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		cfg.MetricKeys = append(cfg.MetricKeys, metrics.CloudAccountIDKey)
+		cfg.ObserverOptions = append(cfg.ObserverOptions, metrics.WithCloudContext(provider, accountBuckets))
+	})
+}
+
+// Sampler decides whether a processed evidence event is recorded.
+type Sampler = metrics.Sampler
+
+// SamplerFunc adapts an ordinary function to the Sampler interface.
+type SamplerFunc = metrics.SamplerFunc
+
+// NewRateSampler returns a Sampler recording the given fraction of events. The rate is
+// clamped to [0, 1].
+func NewRateSampler(rate float64) Sampler {
+	return metrics.NewRateSampler(rate)
+}
+
+// NewPrioritySampler returns a Sampler whose rate depends on the value of key. Events
+// whose key value is listed in rates are sampled at that rate, all other events at
+// defaultRate. Rates are clamped to [0, 1].
+func NewPrioritySampler(key attribute.Key, rates map[string]float64, defaultRate float64) Sampler {
+	return metrics.NewPrioritySampler(key, rates, defaultRate)
+}
+
+// WithSampler installs a sampler consulted before recording processed evidence.
+// Dropped evidence is always recorded since drops are the signal operators alert on.
+// Sampled evidence counts once. The last of WithSampling and WithSampler given decides
+// how evidence is sampled.
+func WithSampler(sampler Sampler) OptionFunc {
+	return withObserverOption(metrics.WithSampler(sampler))
+}
+
+// FeedbackVerdict is an analyst verdict on a reported finding, recorded with
+// EvidenceObserver.RecordFeedback.
+type FeedbackVerdict = metrics.FeedbackVerdict
+
+// Analyst verdicts on findings.
+const (
+	FeedbackTruePositive  = metrics.FeedbackTruePositive
+	FeedbackFalsePositive = metrics.FeedbackFalsePositive
+	FeedbackBenign        = metrics.FeedbackBenign
+)
+
+// WithAllowedValues registers the values accepted for a bounded attribute key, such as
+// the feature recorded by EvidenceObserver.RecordFeatureDisabled. Values recorded for
+// key outside the registered set, and outside any built-in defaults for that key,
+// collapse to "other". Registering a key again extends its set. Evidence attributes
+// with key are recorded as metric labels.
+func WithAllowedValues(key attribute.Key, values ...string) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		cfg.MetricKeys = append(cfg.MetricKeys, key)
+		cfg.ObserverOptions = append(cfg.ObserverOptions, metrics.WithAllowedValues(key, values...))
+	})
+}
+
+// WithStrictTransport makes EvidenceObserver.ProcessedWithTransport drop evidence
+// received over plaintext transport, with reason plaintext_transport, instead of
+// recording it as processed.
+func WithStrictTransport() OptionFunc {
+	return withObserverOption(metrics.WithStrictTransport())
+}
+
+// WithBurnRateAlert alerts on dropped evidence burning through the error budget of slo.
+// The burn rate over a window is the drop ratio divided by the error budget (1 - slo).
+// fn is called with the long-window burn rate when both the short and the long window
+// burn faster than the fast-burn threshold of 14.4, and is not called again until the
+// burn rate recovers. Invalid parameters disable the alert.
+func WithBurnRateAlert(slo float64, shortWindow, longWindow time.Duration, fn func(burnRate float64)) OptionFunc {
+	return withObserverOption(metrics.WithBurnRateAlert(slo, shortWindow, longWindow, fn))
+}
+
+// WithWorkerBuckets sets the number of buckets worker IDs are folded into by
+// EvidenceObserver.ProcessedByWorker. Non-positive values keep the default of 8.
+func WithWorkerBuckets(n int) OptionFunc {
+	return withObserverOption(metrics.WithWorkerBuckets(n))
+}
+
+// SchemaVersionKey is the key evidence schema versions are registered under with
+// WithAllowedValues, bounding the versions recorded by
+// EvidenceObserver.RecordSchemaMigration.
+const SchemaVersionKey = metrics.SchemaVersionKey
+
+// WithComparisonMeterProvider duplicates processed and dropped evidence recordings into
+// a second meter provider, so two differently configured providers can be compared side
+// by side. If none is specified, recordings are not duplicated.
+func WithComparisonMeterProvider(provider metric.MeterProvider) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		if provider != nil {
+			meter := provider.Meter(ScopeName, metric.WithInstrumentationVersion(Version()))
+			cfg.ObserverOptions = append(cfg.ObserverOptions, metrics.WithComparisonMeter(meter))
+		}
+	})
+}
+
+// WithJurisdiction tags every evidence metric with the jurisdiction evidence is
+// processed in. The code must be an ISO 3166-1 alpha-2 country code; it is matched
+// case-insensitively and an invalid code makes NewInstrumentation return an error.
+func WithJurisdiction(code string) OptionFunc {
+	return withObserverOption(metrics.WithJurisdiction(code))
+}
+
+// Summary is a point-in-time compliance summary, returned by
+// EvidenceObserver.ComplianceSummary.
+type Summary = metrics.Summary
+
+// StatusCounts holds the evaluation outcomes of a framework within a Summary.
+type StatusCounts = metrics.StatusCounts
+
+// ReasonCount is a drop reason and how often it occurred, within a Summary.
+type ReasonCount = metrics.ReasonCount
+
+// BundleVersionKey is the policy bundle version attribute. Versions must be registered
+// with WithAllowedValues to be recorded under their own value.
+const BundleVersionKey = metrics.BundleVersionKey
+
+// WithTrustAnchors registers the trust anchors accepted by
+// EvidenceObserver.ProcessedWithTrustAnchor; evidence signed by other anchors is
+// recorded as "untrusted".
+func WithTrustAnchors(anchorIDs ...string) OptionFunc {
+	return withObserverOption(metrics.WithTrustAnchors(anchorIDs...))
+}
+
+// withObserverGauge returns an OptionFunc registering a gauge on the evidence observer
+// with register. Gauges are registered again when the Instrumentation is reset.
+func withObserverGauge(register func(*metrics.EvidenceObserver) error) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		cfg.ObserverGauges = append(cfg.ObserverGauges, register)
+	})
+}
+
+// WithBundleVersionSkew registers the policy_bundle_version_skew gauge, reporting how
+// many distinct policy bundle versions versions returns at each collection. Anything
+// above one means replicas may produce diverging results. If versions is nil, the gauge
+// is not registered.
+func WithBundleVersionSkew(versions func(ctx context.Context) []string) OptionFunc {
+	if versions == nil {
+		return OptionFunc(func(*config) {})
+	}
+	return withObserverGauge(func(observer *metrics.EvidenceObserver) error {
+		return observer.RegisterBundleVersionSkew(versions)
+	})
+}
+
+// WithClientBuckets makes EvidenceObserver.ProcessedByClient record client identifiers
+// as one of buckets client.bucket values, keyed-hashed with salt so buckets cannot be
+// traced back to clients without it. The salt must not be empty, or NewInstrumentation
+// returns an error, and should be shared across replicas and restarts so clients keep
+// their bucket. Non-positive bucket counts keep the default of 16.
+func WithClientBuckets(salt []byte, buckets int) OptionFunc {
+	return withObserverOption(metrics.WithClientBuckets(salt, buckets))
+}
+
+// PipelineStage is a stage of the evidence processing pipeline, recorded with
+// EvidenceObserver.RecordStageSkip.
+type PipelineStage = metrics.PipelineStage
+
+// Evidence processing pipeline stages.
+const (
+	PipelineStageIngest    = metrics.PipelineStageIngest
+	PipelineStageValidate  = metrics.PipelineStageValidate
+	PipelineStageNormalize = metrics.PipelineStageNormalize
+	PipelineStageDedup     = metrics.PipelineStageDedup
+	PipelineStageEnrich    = metrics.PipelineStageEnrich
+	PipelineStageEvaluate  = metrics.PipelineStageEvaluate
+	PipelineStageExport    = metrics.PipelineStageExport
+)
+
+// DestinationKey is the export destination attribute recorded by
+// EvidenceObserver.RecordExport. Destinations beyond ticketing, siem, dashboard,
+// storage and webhook must be registered with WithAllowedValues.
+const DestinationKey = metrics.DestinationKey
+
+// WithWaivers registers approved waivers and the time each one expires, for
+// EvidenceObserver.RecordWaiver. Registering a waiver again replaces its expiry.
+func WithWaivers(expiries map[string]time.Time) OptionFunc {
+	return withObserverOption(metrics.WithWaivers(expiries))
+}
+
+// DetectedFormatKey is the auto-detected evidence format attribute recorded by
+// EvidenceObserver.RecordFormatDetection. Formats beyond the built-in ones must be
+// registered with WithAllowedValues.
+const DetectedFormatKey = metrics.DetectedFormatKey
+
+// SourceKey is the evidence source attribute. Sources reported by the
+// source_health_score gauge must be registered under it with WithAllowedValues.
+const SourceKey = metrics.SourceKey
+
+// WithSourceHealth registers the source_health_score gauge. At each collection, scorer
+// is called for every source registered under SourceKey with WithAllowedValues, and its
+// result is reported for that source. NewInstrumentation returns an error when no
+// source is registered. If scorer is nil, the gauge is not registered.
+func WithSourceHealth(scorer func(source string) float64) OptionFunc {
+	if scorer == nil {
+		return OptionFunc(func(*config) {})
+	}
+	return withObserverGauge(func(observer *metrics.EvidenceObserver) error {
+		return observer.RegisterSourceHealthGauge(context.Background(), scorer)
+	})
+}
+
+// IngestProtocolKey is the ingestion protocol attribute recorded by
+// EvidenceObserver.RecordIngestionLag. Protocols beyond http, grpc, otlp, kafka and
+// file must be registered with WithAllowedValues.
+const IngestProtocolKey = metrics.IngestProtocolKey
+
+// WithSubjectBuckets sets the number of buckets evidence subjects are hashed into by
+// EvidenceObserver.RecordSuperseded. Non-positive values keep the default of 16.
+func WithSubjectBuckets(n int) OptionFunc {
+	return withObserverOption(metrics.WithSubjectBuckets(n))
+}
+
+// WithSequenceSubjects sets the number of subjects whose highest sequence number is
+// remembered by EvidenceObserver.ObserveSequence. Once full, the least recently seen
+// subject is forgotten, so its next sequence number is accepted as in order.
+// Non-positive values keep the default of 10000.
+func WithSequenceSubjects(n int) OptionFunc {
+	return withObserverOption(metrics.WithSequenceSubjects(n))
+}
+
+// WithSchedules registers the scheduled scans accepted by
+// EvidenceObserver.ProcessedFromSchedule; evidence from other schedules is recorded
+// as "other".
+func WithSchedules(names ...string) OptionFunc {
+	return withObserverOption(metrics.WithSchedules(names...))
+}
+
+// Severity is the severity of a finding or alert, recorded with
+// EvidenceObserver.RecordAckLatency. Severities outside the known set are recorded as
+// "unknown".
+type Severity = metrics.Severity
+
+// Finding and alert severities.
+const (
+	SeverityInfo     = metrics.SeverityInfo
+	SeverityLow      = metrics.SeverityLow
+	SeverityMedium   = metrics.SeverityMedium
+	SeverityHigh     = metrics.SeverityHigh
+	SeverityCritical = metrics.SeverityCritical
+)
+
+// Evaluation describes the outcome of evaluating a policy against evidence, recorded
+// with EvidenceObserver.RecordEvaluation.
+type Evaluation = metrics.Evaluation
+
+// The range of compliance maturity levels an Evaluation may target.
+const (
+	MinMaturityLevel = metrics.MinMaturityLevel
+	MaxMaturityLevel = metrics.MaxMaturityLevel
+)
+
+// DeliveryGuarantee is the delivery guarantee offered by an evidence source.
+type DeliveryGuarantee = metrics.DeliveryGuarantee
+
+// Evidence source delivery guarantees.
+const (
+	DeliveryAtMostOnce  = metrics.DeliveryAtMostOnce
+	DeliveryAtLeastOnce = metrics.DeliveryAtLeastOnce
+	DeliveryExactlyOnce = metrics.DeliveryExactlyOnce
+)
+
+// WithDeliveryGuarantee tags every evidence metric with the delivery guarantee of the
+// evidence source, so duplicate and loss rates can be read against it. Levels are
+// matched case-insensitively; levels outside the known set are recorded as "unknown".
+func WithDeliveryGuarantee(level DeliveryGuarantee) OptionFunc {
+	return withObserverOption(metrics.WithDeliveryGuarantee(level))
+}
+
+// WithPolicyIDs registers the policy IDs recorded by bounded policy metrics such as
+// EvidenceObserver.RecordShortCircuit. Other policy IDs are recorded as "other".
+func WithPolicyIDs(policyIDs ...string) OptionFunc {
+	return withObserverOption(metrics.WithPolicyIDs(policyIDs...))
+}
+
+// WithAgentVersions registers the collector agent versions accepted by
+// EvidenceObserver.ProcessedByAgentVersion; other versions are recorded as "other".
+func WithAgentVersions(versions ...string) OptionFunc {
+	return withObserverOption(metrics.WithAgentVersions(versions...))
+}
+
+// EscalationReasonKey is the escalation reason attribute recorded by
+// EvidenceObserver.RecordEscalation. Reasons beyond manual_control,
+// ambiguous_evidence, low_confidence and policy_exception must be registered with
+// WithAllowedValues.
+const EscalationReasonKey = metrics.EscalationReasonKey
+
+// KeyStatus is the rotation status of the key that signed an evidence item, recorded
+// with EvidenceObserver.RecordKeyStatus. Statuses outside the known set are recorded
+// as "unknown".
+type KeyStatus = metrics.KeyStatus
+
+// Signing key rotation statuses.
+const (
+	KeyStatusActive   = metrics.KeyStatusActive
+	KeyStatusRotating = metrics.KeyStatusRotating
+	KeyStatusRetired  = metrics.KeyStatusRetired
+	KeyStatusRevoked  = metrics.KeyStatusRevoked
+)
+
+// WithBoundaries registers the compliance boundaries, such as FedRAMP authorization
+// boundaries, accepted by WithBoundary.
+func WithBoundaries(ids ...string) OptionFunc {
+	return withObserverOption(metrics.WithBoundaries(ids...))
+}
+
+// WithBoundary tags every evidence metric with the compliance boundary evidence
+// belongs to. The boundary must be registered with WithBoundaries, in any option
+// order; otherwise NewInstrumentation returns an error.
+func WithBoundary(id string) OptionFunc {
+	return withObserverOption(metrics.WithBoundary(id))
+}
+
+// WithDegradedMode registers the evaluation_degraded_mode gauge, reporting whether
+// evaluation currently runs in degraded mode with reduced checks, as set with
+// EvidenceObserver.SetDegraded. Transitions between modes are counted either way.
+func WithDegradedMode() OptionFunc {
+	return withObserverOption(metrics.WithDegradedMode())
+}
+
+// WithDefaultAttributes adds attrs, such as the service or tenant, to every evidence
+// metric. Duplicate keys are deduplicated, keeping the last value given for each key.
+func WithDefaultAttributes(attrs ...attribute.KeyValue) OptionFunc {
+	return withObserverOption(metrics.WithDefaultAttributes(attrs...))
+}
+
+// WithDurationHistogram sets whether the evidence_processing_duration_seconds
+// histogram is registered. It is registered by default; when disabled, processing
+// durations still feed EvidenceObserver.ComplianceSummary.
+func WithDurationHistogram(enabled bool) OptionFunc {
+	return withObserverOption(metrics.WithDurationHistogram(enabled))
+}
+
+// WithNamePrefix prefixes the name of every evidence metric with prefix and an
+// underscore, so "complybeacon" turns evidence_processed_count into
+// complybeacon_evidence_processed_count. An empty prefix keeps the default names. A
+// prefix that would produce invalid instrument names, for example one starting with a
+// digit or containing spaces, makes NewInstrumentation return an error.
+func WithNamePrefix(prefix string) OptionFunc {
+	return withObserverOption(metrics.WithNamePrefix(prefix))
+}
+
+// WithPartitions sets the number of partitions evidence processing is split across,
+// bounding the partitions accepted by EvidenceObserver.ProcessedByPartition to [0, n).
+// A non-positive count makes NewInstrumentation return an error.
+func WithPartitions(n int) OptionFunc {
+	return withObserverOption(metrics.WithPartitions(n))
+}
+
+// WithWindows registers the validity windows, such as business hours or a maintenance
+// window, accepted by EvidenceObserver.RecordOutOfWindow; other windows are recorded
+// as "other".
+func WithWindows(names ...string) OptionFunc {
+	return withObserverOption(metrics.WithWindows(names...))
+}
+
+// WithCardinalityLimit caps the number of distinct values recorded for key at max.
+// The first max distinct values are recorded as-is; any further value collapses into
+// the "__overflow__" bucket. A non-positive max makes NewInstrumentation return an
+// error. Evidence attributes with key are recorded as metric labels.
+func WithCardinalityLimit(key string, max int) OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		cfg.MetricKeys = append(cfg.MetricKeys, attribute.Key(key))
+		cfg.ObserverOptions = append(cfg.ObserverOptions, metrics.WithCardinalityLimit(key, max))
+	})
+}
+
+// ControlCharPolicy decides how evidence metrics handle string attribute values
+// containing control characters, which make for unreadable label values.
+type ControlCharPolicy = metrics.ControlCharPolicy
+
+const (
+	// ControlCharsSanitize removes control characters from attribute values. It is the default.
+	ControlCharsSanitize = metrics.ControlCharsSanitize
+	// ControlCharsReject skips the recording and counts it in
+	// evidence_rejected_attributes_count instead.
+	ControlCharsReject = metrics.ControlCharsReject
+)
+
+// WithControlCharPolicy sets how processed and dropped evidence attribute values
+// containing control characters are handled.
+func WithControlCharPolicy(policy ControlCharPolicy) OptionFunc {
+	return withObserverOption(metrics.WithControlCharPolicy(policy))
+}
+
+// WithRecordingSpans makes every processed and dropped evidence recording start a short
+// span from the configured TracerProvider, tagged with the policy.id attribute when
+// present. Because the recording is made in the span's context, SDKs with exemplars
+// enabled attach the span's trace to the recorded data points, linking metric spikes
+// to the traces behind them.
+func WithRecordingSpans() OptionFunc {
+	return OptionFunc(func(cfg *config) {
+		cfg.RecordingSpans = true
+	})
+}
+
+// Outcome is the outcome of processing an evidence item within an EvidenceResult.
+type Outcome = metrics.Outcome
+
+// Evidence processing outcomes.
+const (
+	OutcomeProcessed = metrics.OutcomeProcessed
+	OutcomeDropped   = metrics.OutcomeDropped
+)
+
+// EvidenceResult is the outcome of processing a single evidence item, recorded in bulk
+// with EvidenceObserver.RecordBatch.
+type EvidenceResult = metrics.EvidenceResult
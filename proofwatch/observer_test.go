@@ -0,0 +1,1187 @@
+package proofwatch_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// observedEvidence is evidence carrying arbitrary attributes.
+type observedEvidence []attribute.KeyValue
+
+func (e observedEvidence) ToJSON() ([]byte, error)          { return []byte(`{}`), nil }
+func (e observedEvidence) Attributes() []attribute.KeyValue { return e }
+func (e observedEvidence) Timestamp() time.Time             { return time.Now() }
+
+// setupObserverTest creates Instrumentation with opts recording to a manual reader.
+func setupObserverTest(t *testing.T, opts ...proofwatch.OptionFunc) (*proofwatch.Instrumentation, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	inst, err := proofwatch.NewInstrumentation(append([]proofwatch.OptionFunc{proofwatch.WithMeterProvider(provider)}, opts...)...)
+	require.NoError(t, err)
+	return inst, reader
+}
+
+// collect returns the metric named name, failing the test if it was not recorded.
+func collect(t *testing.T, reader *sdkmetric.ManualReader, name string) metricdata.Metrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+	t.Fatalf("metric %s not recorded", name)
+	return metricdata.Metrics{}
+}
+
+// sumPoints returns the data points of the int64 sum named name.
+func sumPoints(t *testing.T, reader *sdkmetric.ManualReader, name string) []metricdata.DataPoint[int64] {
+	t.Helper()
+
+	sum, ok := collect(t, reader, name).Data.(metricdata.Sum[int64])
+	require.True(t, ok, "%s is not an int64 sum", name)
+	return sum.DataPoints
+}
+
+// value returns the value of key in set as a string.
+func value(set attribute.Set, key attribute.Key) string {
+	v, _ := set.Value(key)
+	return v.Emit()
+}
+
+func succeed(context.Context, proofwatch.Evidence) error { return nil }
+
+func TestInstrumentationObserver(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+
+	inst.Observer().Processed(context.Background(), attribute.String("policy.id", "policy-1"))
+
+	points := sumPoints(t, reader, "evidence_processed_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, "policy-1", value(points[0].Attributes, "policy.id"))
+}
+
+func TestWithCloudContext(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithCloudContext("AWS", 4))
+
+	for i := 0; i < 20; i++ {
+		evidence := observedEvidence{attribute.String("cloud.account.id", "account-"+strconv.Itoa(i))}
+		require.NoError(t, inst.Process(context.Background(), evidence, succeed))
+	}
+
+	points := sumPoints(t, reader, "evidence_processed_count")
+	assert.LessOrEqual(t, len(points), 4)
+	for _, dp := range points {
+		assert.Equal(t, "aws", value(dp.Attributes, "cloud.provider"))
+		assert.False(t, dp.Attributes.HasValue("cloud.account.id"))
+		assert.True(t, dp.Attributes.HasValue("cloud.account"))
+	}
+}
+
+func TestWithSampler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("skips unsampled processed evidence", func(t *testing.T) {
+		var seen []string
+		inst, reader := setupObserverTest(t, proofwatch.WithSampler(proofwatch.SamplerFunc(func(_ context.Context, attrs []attribute.KeyValue) bool {
+			for _, kv := range attrs {
+				if kv.Key == "policy.id" {
+					seen = append(seen, kv.Value.AsString())
+					return kv.Value.AsString() == "keep"
+				}
+			}
+			return false
+		})))
+
+		require.NoError(t, inst.Process(ctx, observedEvidence{attribute.String("policy.id", "keep")}, succeed))
+		require.NoError(t, inst.Process(ctx, observedEvidence{attribute.String("policy.id", "skip")}, succeed))
+		require.Error(t, inst.Process(ctx, observedEvidence{attribute.String("policy.id", "skip")}, func(context.Context, proofwatch.Evidence) error {
+			return assert.AnError
+		}))
+
+		assert.Equal(t, []string{"keep", "skip"}, seen)
+		points := sumPoints(t, reader, "evidence_processed_count")
+		require.Len(t, points, 1)
+		assert.Equal(t, "keep", value(points[0].Attributes, "policy.id"))
+		assert.Len(t, sumPoints(t, reader, "evidence_dropped_count"), 1, "drops are always recorded")
+	})
+
+	t.Run("priority sampler", func(t *testing.T) {
+		sampler := proofwatch.NewPrioritySampler("severity", map[string]float64{"critical": 1}, 0)
+		inst, reader := setupObserverTest(t, proofwatch.WithSampler(sampler))
+
+		require.NoError(t, inst.Process(ctx, observedEvidence{attribute.String("severity", "critical")}, succeed))
+		require.NoError(t, inst.Process(ctx, observedEvidence{attribute.String("severity", "low")}, succeed))
+
+		points := sumPoints(t, reader, "evidence_processed_count")
+		require.Len(t, points, 1)
+		assert.Equal(t, "critical", value(points[0].Attributes, "severity"))
+		assert.False(t, proofwatch.NewRateSampler(0).ShouldSample(ctx, nil))
+	})
+
+	always := proofwatch.SamplerFunc(func(context.Context, []attribute.KeyValue) bool { return true })
+	processed := func(t *testing.T, opts ...proofwatch.OptionFunc) int64 {
+		t.Helper()
+		inst, reader := setupObserverTest(t, opts...)
+		for i := 0; i < 50; i++ {
+			require.NoError(t, inst.Process(ctx, observedEvidence{}, succeed))
+		}
+		var total int64
+		for _, dp := range sumPoints(t, reader, "evidence_processed_count") {
+			total += dp.Value
+		}
+		return total
+	}
+
+	t.Run("sampler after sampling counts events once", func(t *testing.T) {
+		assert.Equal(t, int64(50), processed(t, proofwatch.WithSampling(0.25), proofwatch.WithSampler(always)))
+	})
+
+	t.Run("sampling after sampler upscales", func(t *testing.T) {
+		assert.Zero(t, processed(t, proofwatch.WithSampler(always), proofwatch.WithSampling(0.5))%2)
+	})
+}
+
+func TestObserverRecordFeedback(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithPolicyIDs("policy-1"))
+
+	inst.Observer().RecordFeedback(context.Background(), proofwatch.FeedbackFalsePositive, "policy-1")
+	inst.Observer().RecordFeedback(context.Background(), proofwatch.FeedbackFalsePositive, "unregistered")
+
+	points := sumPoints(t, reader, "finding_feedback_count")
+	require.Len(t, points, 2)
+	policies := map[string]string{}
+	for _, dp := range points {
+		policies[value(dp.Attributes, "policy.id")] = value(dp.Attributes, "feedback.verdict")
+	}
+	assert.Equal(t, map[string]string{"policy-1": "false_positive", "other": "false_positive"}, policies)
+}
+
+func TestWithAllowedValues(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithAllowedValues("feature", "oscal-import"))
+	ctx := context.Background()
+
+	inst.Observer().RecordFeatureDisabled(ctx, "oscal-import")
+	inst.Observer().RecordFeatureDisabled(ctx, "unregistered")
+
+	features := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "evidence_feature_disabled_count") {
+		features[value(dp.Attributes, "feature")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"oscal-import": 1, "other": 1}, features)
+}
+
+func TestObserverRecordRollup(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+
+	inst.Observer().RecordRollup(context.Background(), 12)
+
+	points := sumPoints(t, reader, "evidence_rollup_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(1), points[0].Value)
+	sizes, ok := collect(t, reader, "rollup_input_size").Data.(metricdata.Histogram[int64])
+	require.True(t, ok)
+	require.Len(t, sizes.DataPoints, 1)
+	assert.Equal(t, int64(12), sizes.DataPoints[0].Sum)
+}
+
+func TestWithStrictTransport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records plaintext evidence by default", func(t *testing.T) {
+		inst, reader := setupObserverTest(t)
+
+		inst.Observer().ProcessedWithTransport(ctx, false)
+
+		points := sumPoints(t, reader, "evidence_processed_count")
+		require.Len(t, points, 1)
+		assert.Equal(t, "false", value(points[0].Attributes, "transport.encrypted"))
+	})
+
+	t.Run("drops plaintext evidence", func(t *testing.T) {
+		inst, reader := setupObserverTest(t, proofwatch.WithStrictTransport())
+
+		inst.Observer().ProcessedWithTransport(ctx, true)
+		inst.Observer().ProcessedWithTransport(ctx, false)
+
+		assert.Len(t, sumPoints(t, reader, "evidence_processed_count"), 1)
+		dropped := sumPoints(t, reader, "evidence_dropped_count")
+		require.Len(t, dropped, 1)
+		assert.Equal(t, string(proofwatch.DropReasonPlaintextTransport), value(dropped[0].Attributes, "reason"))
+	})
+}
+
+func TestWithBurnRateAlert(t *testing.T) {
+	var alerts []float64
+	inst, _ := setupObserverTest(t, proofwatch.WithBurnRateAlert(0.99, time.Minute, time.Hour, func(burnRate float64) {
+		alerts = append(alerts, burnRate)
+	}))
+	ctx := context.Background()
+
+	require.NoError(t, inst.Process(ctx, observedEvidence{}, succeed))
+	assert.Empty(t, alerts)
+	for i := 0; i < 20; i++ {
+		require.Error(t, inst.Process(ctx, observedEvidence{}, func(context.Context, proofwatch.Evidence) error {
+			return assert.AnError
+		}))
+	}
+
+	require.Len(t, alerts, 1, "the alert fires once until the burn rate recovers")
+	assert.Greater(t, alerts[0], 14.4)
+}
+
+func TestWithWorkerBuckets(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithWorkerBuckets(2))
+
+	for worker := 0; worker < 5; worker++ {
+		inst.Observer().ProcessedByWorker(context.Background(), worker)
+	}
+
+	buckets := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "evidence_processed_count") {
+		buckets[value(dp.Attributes, "worker.bucket")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"0": 3, "1": 2}, buckets)
+}
+
+func TestObserverRecordSchemaMigration(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithAllowedValues(proofwatch.SchemaVersionKey, "1.4.0", "1.5.0"))
+
+	inst.Observer().RecordSchemaMigration(context.Background(), "1.4.0", "9.9.9", true)
+
+	points := sumPoints(t, reader, "schema_migration_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, "1.4.0", value(points[0].Attributes, "schema.version.from"))
+	assert.Equal(t, "other", value(points[0].Attributes, "schema.version.to"))
+	assert.Equal(t, "true", value(points[0].Attributes, "success"))
+}
+
+func TestWithComparisonMeterProvider(t *testing.T) {
+	comparison := sdkmetric.NewManualReader()
+	comparisonProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(comparison))
+	t.Cleanup(func() { _ = comparisonProvider.Shutdown(context.Background()) })
+	inst, reader := setupObserverTest(t, proofwatch.WithComparisonMeterProvider(comparisonProvider))
+
+	require.NoError(t, inst.Process(context.Background(), observedEvidence{}, succeed))
+
+	assert.Len(t, sumPoints(t, reader, "evidence_processed_count"), 1)
+	assert.Len(t, sumPoints(t, comparison, "evidence_processed_count"), 1)
+}
+
+func TestWithJurisdiction(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithJurisdiction("de"))
+
+	require.NoError(t, inst.Process(context.Background(), observedEvidence{}, succeed))
+
+	points := sumPoints(t, reader, "evidence_processed_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, "DE", value(points[0].Attributes, "jurisdiction"))
+
+	_, err := proofwatch.NewInstrumentation(
+		proofwatch.WithMeterProvider(sdkmetric.NewMeterProvider()),
+		proofwatch.WithJurisdiction("Narnia"),
+	)
+	assert.ErrorContains(t, err, "invalid jurisdiction")
+}
+
+func TestObserverMaps(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().ProcessedMap(ctx, map[string]string{"policy.id": "policy-1"})
+	inst.Observer().DroppedMap(ctx, map[string]string{"policy.id": "policy-2"})
+
+	processed := sumPoints(t, reader, "evidence_processed_count")
+	require.Len(t, processed, 1)
+	assert.Equal(t, "policy-1", value(processed[0].Attributes, "policy.id"))
+	dropped := sumPoints(t, reader, "evidence_dropped_count")
+	require.Len(t, dropped, 1)
+	assert.Equal(t, "policy-2", value(dropped[0].Attributes, "policy.id"))
+}
+
+func TestObserverRecordDrift(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordDrift(ctx, true)
+	inst.Observer().RecordDriftMagnitude(ctx, -0.25)
+
+	points := sumPoints(t, reader, "evidence_drift_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, "true", value(points[0].Attributes, "drifted"))
+	magnitude, ok := collect(t, reader, "evidence_drift_magnitude").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, magnitude.DataPoints, 1)
+	assert.InDelta(t, 0.25, magnitude.DataPoints[0].Sum, 1e-9)
+}
+
+func TestObserverComplianceSummary(t *testing.T) {
+	inst, _ := setupObserverTest(t)
+	ctx := context.Background()
+
+	frameworks := attribute.String("compliance.frameworks", "NIST-800-53")
+	require.NoError(t, inst.Process(ctx, observedEvidence{frameworks, attribute.String("policy.evaluation.result", "Passed")}, succeed))
+	require.NoError(t, inst.Process(ctx, observedEvidence{frameworks, attribute.String("policy.evaluation.result", "Failed")}, succeed))
+	require.Error(t, inst.Process(ctx, observedEvidence{frameworks}, func(context.Context, proofwatch.Evidence) error {
+		return assert.AnError
+	}))
+
+	summary, err := inst.Observer().ComplianceSummary(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, proofwatch.StatusCounts{Pass: 1, Fail: 1}, summary.Frameworks["NIST-800-53"])
+	assert.Equal(t, []proofwatch.ReasonCount{{Reason: "processing_error", Count: 1}}, summary.TopDropReasons)
+}
+
+func TestPoolRecordsAccepted(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	pool := proofwatch.NewPool(inst, succeed, proofwatch.WithWorkers(1))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, pool.Submit(ctx, observedEvidence{}))
+	}
+	pool.Close()
+
+	accepted := sumPoints(t, reader, "evidence_accepted_count")
+	require.Len(t, accepted, 1)
+	assert.Equal(t, int64(3), accepted[0].Value)
+	processed := sumPoints(t, reader, "evidence_processed_count")
+	require.Len(t, processed, 1)
+	assert.Equal(t, int64(3), processed[0].Value)
+
+	inst.Observer().RecordAccepted(ctx)
+	assert.Equal(t, int64(4), sumPoints(t, reader, "evidence_accepted_count")[0].Value)
+}
+
+func TestObserverDroppedBundleTimeout(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithAllowedValues(proofwatch.BundleVersionKey, "v1"))
+
+	inst.Observer().DroppedBundleTimeout(context.Background(), "v1")
+
+	points := sumPoints(t, reader, "evidence_dropped_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, string(proofwatch.DropReasonBundleTimeout), value(points[0].Attributes, "reason"))
+	assert.Equal(t, "v1", value(points[0].Attributes, proofwatch.BundleVersionKey))
+}
+
+func TestWithTrustAnchors(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithTrustAnchors("sigstore"))
+	ctx := context.Background()
+
+	inst.Observer().ProcessedWithTrustAnchor(ctx, "sigstore")
+	inst.Observer().ProcessedWithTrustAnchor(ctx, "self-signed")
+
+	anchors := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "evidence_processed_count") {
+		anchors[value(dp.Attributes, "trust.anchor")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"sigstore": 1, "untrusted": 1}, anchors)
+}
+
+func TestWithBundleVersionSkew(t *testing.T) {
+	versions := []string{"v1", "v2", "v1", ""}
+	_, reader := setupObserverTest(t, proofwatch.WithBundleVersionSkew(func(context.Context) []string {
+		return versions
+	}))
+
+	assertSkew := func(want int64) {
+		t.Helper()
+		gauge, ok := collect(t, reader, "policy_bundle_version_skew").Data.(metricdata.Gauge[int64])
+		require.True(t, ok)
+		require.Len(t, gauge.DataPoints, 1)
+		assert.Equal(t, want, gauge.DataPoints[0].Value)
+	}
+	assertSkew(2)
+
+	versions = []string{"v2"}
+	assertSkew(1)
+}
+
+func TestWithClientBuckets(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("buckets clients with a stable salt", func(t *testing.T) {
+		salt := []byte("shared-salt")
+		bucketOf := func() string {
+			inst, reader := setupObserverTest(t, proofwatch.WithClientBuckets(salt, 4))
+			inst.Observer().ProcessedByClient(ctx, "client-1")
+			points := sumPoints(t, reader, "evidence_processed_count")
+			require.Len(t, points, 1)
+			return value(points[0].Attributes, "client.bucket")
+		}
+		first := bucketOf()
+		assert.NotEqual(t, "client-1", first)
+		assert.Equal(t, first, bucketOf())
+	})
+
+	t.Run("empty salt errors", func(t *testing.T) {
+		_, err := proofwatch.NewInstrumentation(proofwatch.WithClientBuckets(nil, 4))
+		assert.Error(t, err)
+	})
+}
+
+func TestObserverRecordStageSkip(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordStageSkip(ctx, proofwatch.PipelineStageEnrich)
+	inst.Observer().RecordStageSkip(ctx, proofwatch.PipelineStage("transcode"))
+
+	stages := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "pipeline_stage_skip_count") {
+		stages[value(dp.Attributes, "pipeline.stage")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"enrich": 1, "unknown": 1}, stages)
+}
+
+func TestObserverRecordExport(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithAllowedValues(proofwatch.DestinationKey, "datalake"))
+	ctx := context.Background()
+
+	inst.Observer().RecordExport(ctx, "siem", true)
+	inst.Observer().RecordExport(ctx, "datalake", false)
+	inst.Observer().RecordExport(ctx, "pastebin", true)
+
+	exports := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "result_export_count") {
+		exports[value(dp.Attributes, "destination")+"/"+value(dp.Attributes, "success")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"siem/true": 1, "datalake/false": 1, "other/true": 1}, exports)
+}
+
+func TestObserverRecordChainOfCustody(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordChainOfCustody(ctx, true)
+	inst.Observer().RecordChainOfCustody(ctx, false)
+
+	custody := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "evidence_custody_verification_count") {
+		custody[value(dp.Attributes, "custody.intact")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"true": 1, "false": 1}, custody)
+
+	dropped := sumPoints(t, reader, "evidence_dropped_count")
+	require.Len(t, dropped, 1)
+	assert.Equal(t, string(proofwatch.DropReasonCustodyBroken), value(dropped[0].Attributes, "reason"))
+}
+
+func TestObserverRecordParallelism(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordParallelism(ctx, 4)
+	inst.Observer().RecordParallelism(ctx, -1)
+
+	degrees, ok := collect(t, reader, "evaluation_parallelism").Data.(metricdata.Histogram[int64])
+	require.True(t, ok)
+	require.Len(t, degrees.DataPoints, 1)
+	assert.Equal(t, uint64(2), degrees.DataPoints[0].Count)
+	assert.Equal(t, int64(4), degrees.DataPoints[0].Sum)
+}
+
+func TestObserverRecordPolicyAge(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+
+	inst.Observer().RecordPolicyAge(context.Background(), 90*time.Second)
+
+	ages, ok := collect(t, reader, "policy_age_seconds").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, ages.DataPoints, 1)
+	assert.InDelta(t, 90.0, ages.DataPoints[0].Sum, 1e-9)
+}
+
+func TestObserverRecordMemoReuse(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+
+	inst.Observer().RecordMemoReuse(context.Background())
+
+	points := sumPoints(t, reader, "evaluation_memo_reuse_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(1), points[0].Value)
+}
+
+func TestWithWaivers(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithWaivers(map[string]time.Time{
+		"EX-1": time.Now().Add(time.Hour),
+		"EX-2": time.Now().Add(-time.Hour),
+	}))
+	ctx := context.Background()
+
+	inst.Observer().RecordWaiver(ctx, "EX-1")
+	inst.Observer().RecordWaiver(ctx, "EX-2")
+	inst.Observer().RecordWaiver(ctx, "EX-3")
+
+	applied := sumPoints(t, reader, "waiver_applied_count")
+	require.Len(t, applied, 1)
+	assert.Equal(t, "EX-1", value(applied[0].Attributes, "waiver.id"))
+
+	inactive := map[string]string{}
+	for _, dp := range sumPoints(t, reader, "waiver_inactive_count") {
+		inactive[value(dp.Attributes, "waiver.id")] = value(dp.Attributes, "waiver.state")
+	}
+	assert.Equal(t, map[string]string{"EX-2": "expired", "other": "unregistered"}, inactive)
+}
+
+func TestObserverBundleLoaded(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithAllowedValues(proofwatch.BundleVersionKey, "v1"))
+	ctx := context.Background()
+	evidence := observedEvidence{proofwatch.BundleVersionKey.String("v1")}
+
+	require.NoError(t, inst.Process(ctx, evidence, succeed))
+	require.NoError(t, inst.Process(ctx, evidence, succeed))
+	inst.Observer().BundleLoaded("v1")
+	require.NoError(t, inst.Process(ctx, evidence, succeed))
+
+	durations, ok := collect(t, reader, "evidence_processing_duration_seconds").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	starts := map[string]uint64{}
+	for _, dp := range durations.DataPoints {
+		starts[value(dp.Attributes, "cold_start")] += dp.Count
+	}
+	assert.Equal(t, map[string]uint64{"true": 2, "false": 1}, starts)
+}
+
+func TestObserverRecordFormatDetection(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithAllowedValues(proofwatch.DetectedFormatKey, "spdx"))
+	ctx := context.Background()
+
+	inst.Observer().RecordFormatDetection(ctx, "OCSF", true)
+	inst.Observer().RecordFormatDetection(ctx, "spdx", false)
+	inst.Observer().RecordFormatDetection(ctx, "toml", true)
+
+	formats := map[string]string{}
+	for _, dp := range sumPoints(t, reader, "evidence_format_detection_count") {
+		formats[value(dp.Attributes, "format.detected")] = value(dp.Attributes, "format.detection.correct")
+	}
+	assert.Equal(t, map[string]string{"ocsf": "true", "spdx": "false", "other": "true"}, formats)
+}
+
+func TestWithSourceHealth(t *testing.T) {
+	scorer := func(source string) float64 {
+		if source == "scanner" {
+			return 0.5
+		}
+		return 1
+	}
+
+	t.Run("reports registered sources", func(t *testing.T) {
+		_, reader := setupObserverTest(t,
+			proofwatch.WithAllowedValues(proofwatch.SourceKey, "scanner", "audit-log"),
+			proofwatch.WithSourceHealth(scorer),
+		)
+
+		gauge, ok := collect(t, reader, "source_health_score").Data.(metricdata.Gauge[float64])
+		require.True(t, ok)
+		scores := map[string]float64{}
+		for _, dp := range gauge.DataPoints {
+			scores[value(dp.Attributes, proofwatch.SourceKey)] = dp.Value
+		}
+		assert.Equal(t, map[string]float64{"scanner": 0.5, "audit-log": 1}, scores)
+	})
+
+	t.Run("without sources errors", func(t *testing.T) {
+		_, err := proofwatch.NewInstrumentation(proofwatch.WithSourceHealth(scorer))
+		assert.Error(t, err)
+	})
+}
+
+func TestObserverRecordDependencyResolution(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordDependencyResolution(ctx, true)
+	inst.Observer().RecordDependencyResolution(ctx, false)
+
+	resolutions := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "policy_dependency_resolution_count") {
+		resolutions[value(dp.Attributes, "dependency.resolved")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"true": 1, "false": 1}, resolutions)
+
+	dropped := sumPoints(t, reader, "evidence_dropped_count")
+	require.Len(t, dropped, 1)
+	assert.Equal(t, string(proofwatch.DropReasonDependencyUnresolved), value(dropped[0].Attributes, "reason"))
+}
+
+func TestObserverRecordIngestionLag(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithAllowedValues(proofwatch.IngestProtocolKey, "amqp"))
+	ctx := context.Background()
+
+	inst.Observer().RecordIngestionLag(ctx, "AMQP", 2*time.Second)
+	inst.Observer().RecordIngestionLag(ctx, "amqp", 4*time.Second)
+	inst.Observer().RecordIngestionLag(ctx, "smtp", time.Second)
+
+	lags, ok := collect(t, reader, "evidence_ingestion_lag_seconds").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	counts := map[string]uint64{}
+	for _, dp := range lags.DataPoints {
+		counts[value(dp.Attributes, proofwatch.IngestProtocolKey)] += dp.Count
+	}
+	assert.Equal(t, map[string]uint64{"amqp": 2, "other": 1}, counts)
+	assert.Equal(t, 4*time.Second, inst.Observer().IngestionLagPercentile("amqp", 1))
+	assert.Zero(t, inst.Observer().IngestionLagPercentile("http", 0.5))
+}
+
+func TestWithSubjectBuckets(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithSubjectBuckets(1))
+	ctx := context.Background()
+
+	inst.Observer().RecordSuperseded(ctx, "vm-1")
+	inst.Observer().RecordSuperseded(ctx, "vm-2")
+
+	points := sumPoints(t, reader, "evidence_superseded_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, "0", value(points[0].Attributes, "subject.bucket"))
+	assert.Equal(t, int64(2), points[0].Value)
+}
+
+func TestObserverRecordThresholdCheck(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordThresholdCheck(ctx, 95, 90)
+	inst.Observer().RecordThresholdCheck(ctx, 80, 90)
+
+	checks := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "threshold_check_count") {
+		checks[value(dp.Attributes, "threshold.passed")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"true": 1, "false": 1}, checks)
+
+	deltas, ok := collect(t, reader, "threshold_delta").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	sums := map[string]float64{}
+	for _, dp := range deltas.DataPoints {
+		sums[value(dp.Attributes, "threshold.passed")] += dp.Sum
+	}
+	assert.Equal(t, map[string]float64{"true": 5, "false": -10}, sums)
+}
+
+func TestWithSchedules(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithSchedules("nightly"))
+	ctx := context.Background()
+
+	inst.Observer().ProcessedFromSchedule(ctx, "nightly")
+	inst.Observer().ProcessedFromSchedule(ctx, "adhoc")
+
+	schedules := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "evidence_processed_count") {
+		schedules[value(dp.Attributes, "schedule.name")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"nightly": 1, "other": 1}, schedules)
+}
+
+func TestObserverProcessedWithSpan(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	exporter := tracetest.NewInMemoryExporter()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)).Tracer("test")
+
+	_, span := inst.Observer().ProcessedWithSpan(context.Background(), tracer, attribute.String("policy.id", "AC-1"))
+	span.End()
+
+	points := sumPoints(t, reader, "evidence_processed_count")
+	require.Len(t, points, 1)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "evidence.processed", spans[0].Name)
+	assert.Equal(t, points[0].Attributes.ToSlice(), spans[0].Attributes)
+}
+
+func TestObserverDroppedResourceCeiling(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().DroppedResourceCeiling(ctx, "Memory")
+	inst.Observer().DroppedResourceCeiling(ctx, "gpu")
+
+	resources := map[string]string{}
+	for _, dp := range sumPoints(t, reader, "evidence_dropped_count") {
+		resources[value(dp.Attributes, "resource")] = value(dp.Attributes, "reason")
+	}
+	ceiling := string(proofwatch.DropReasonResourceCeiling)
+	assert.Equal(t, map[string]string{"memory": ceiling, "other": ceiling}, resources)
+}
+
+func TestObserverRecordAckLatency(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordAckLatency(ctx, 10*time.Minute, proofwatch.SeverityHigh)
+	inst.Observer().RecordAckLatency(ctx, time.Hour, proofwatch.Severity("blocker"))
+
+	latencies, ok := collect(t, reader, "alert_ack_latency_seconds").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	sums := map[string]float64{}
+	for _, dp := range latencies.DataPoints {
+		sums[value(dp.Attributes, "severity")] += dp.Sum
+	}
+	assert.Equal(t, map[string]float64{"high": 600, "unknown": 3600}, sums)
+}
+
+func TestObserverRecordSizeRatio(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+
+	inst.Observer().RecordSizeRatio(context.Background(), 2048, 512)
+
+	ratios, ok := collect(t, reader, "evaluation_size_ratio").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, ratios.DataPoints, 1)
+	assert.InDelta(t, 0.25, ratios.DataPoints[0].Sum, 1e-9)
+	inputs, ok := collect(t, reader, "evaluation_input_size_bytes").Data.(metricdata.Histogram[int64])
+	require.True(t, ok)
+	require.Len(t, inputs.DataPoints, 1)
+	assert.Equal(t, int64(2048), inputs.DataPoints[0].Sum)
+}
+
+func TestObserverRecordEvaluation(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithPolicyIDs("AC-1"))
+	ctx := context.Background()
+
+	require.NoError(t, inst.Observer().RecordEvaluation(ctx, proofwatch.Evaluation{
+		PolicyID:      "AC-1",
+		Status:        proofwatch.EvaluationStatusPass,
+		MaturityLevel: proofwatch.MaxMaturityLevel,
+	}))
+	assert.Error(t, inst.Observer().RecordEvaluation(ctx, proofwatch.Evaluation{
+		PolicyID:      "AC-1",
+		Status:        proofwatch.EvaluationStatusFail,
+		MaturityLevel: proofwatch.MaxMaturityLevel + 1,
+	}))
+
+	points := sumPoints(t, reader, "policy_evaluation_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, "AC-1", value(points[0].Attributes, "policy.id"))
+	assert.Equal(t, "5", value(points[0].Attributes, "maturity.level"))
+}
+
+func TestObserverRecordDeadlineBudget(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordDeadlineBudget(ctx, 2*time.Second)
+	inst.Observer().RecordDeadlineBudget(ctx, -time.Second)
+
+	budgets, ok := collect(t, reader, "evidence_deadline_budget_remaining_seconds").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, budgets.DataPoints, 1)
+	assert.Equal(t, uint64(2), budgets.DataPoints[0].Count)
+	assert.InDelta(t, 2.0, budgets.DataPoints[0].Sum, 1e-9)
+	overruns := sumPoints(t, reader, "evidence_deadline_overrun_count")
+	require.Len(t, overruns, 1)
+	assert.Equal(t, int64(1), overruns[0].Value)
+}
+
+func TestObserverRuleComplexity(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordRuleComplexity(ctx, 3)
+	inst.Observer().ObserveDurationWithComplexity(ctx, time.Second, 7)
+
+	scores, ok := collect(t, reader, "rule_complexity").Data.(metricdata.Histogram[int64])
+	require.True(t, ok)
+	require.Len(t, scores.DataPoints, 1)
+	assert.Equal(t, int64(10), scores.DataPoints[0].Sum)
+	durations, ok := collect(t, reader, "evidence_processing_duration_seconds").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, durations.DataPoints, 1)
+	assert.Equal(t, uint64(1), durations.DataPoints[0].Count)
+}
+
+func TestWithDeliveryGuarantee(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithDeliveryGuarantee("At-Least-Once"))
+
+	require.NoError(t, inst.Process(context.Background(), observedEvidence{}, succeed))
+
+	points := sumPoints(t, reader, "evidence_processed_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, string(proofwatch.DeliveryAtLeastOnce), value(points[0].Attributes, "delivery.guarantee"))
+}
+
+func TestWithPolicyIDs(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithPolicyIDs("AC-1"))
+	ctx := context.Background()
+
+	inst.Observer().RecordShortCircuit(ctx, "AC-1")
+	inst.Observer().RecordShortCircuit(ctx, "AC-2")
+
+	policies := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "policy_short_circuit_count") {
+		policies[value(dp.Attributes, "policy.id")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"AC-1": 1, "other": 1}, policies)
+}
+
+func TestObserverRecordRemediationSLA(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordRemediationSLA(ctx, true, proofwatch.SeverityCritical)
+	inst.Observer().RecordRemediationSLA(ctx, false, proofwatch.SeverityLow)
+
+	remediations := map[string]string{}
+	for _, dp := range sumPoints(t, reader, "remediation_sla_count") {
+		remediations[value(dp.Attributes, "severity")] = value(dp.Attributes, "breached")
+	}
+	assert.Equal(t, map[string]string{"critical": "true", "low": "false"}, remediations)
+}
+
+func TestWithAgentVersions(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithAgentVersions("1.4.0"))
+	ctx := context.Background()
+
+	inst.Observer().ProcessedByAgentVersion(ctx, "1.4.0")
+	inst.Observer().ProcessedByAgentVersion(ctx, "0.9.0-dev")
+
+	versions := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "evidence_processed_count") {
+		versions[value(dp.Attributes, "agent.version")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"1.4.0": 1, "other": 1}, versions)
+}
+
+func TestObserverRecordEscalation(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithAllowedValues(proofwatch.EscalationReasonKey, "legal_hold"))
+	ctx := context.Background()
+
+	inst.Observer().RecordEscalation(ctx, "low_confidence")
+	inst.Observer().RecordEscalation(ctx, "legal_hold")
+	inst.Observer().RecordEscalation(ctx, "coffee_break")
+
+	reasons := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "evaluation_escalation_count") {
+		reasons[value(dp.Attributes, proofwatch.EscalationReasonKey)] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"low_confidence": 1, "legal_hold": 1, "other": 1}, reasons)
+}
+
+func TestObserverRecordKeyStatus(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordKeyStatus(ctx, proofwatch.KeyStatusActive)
+	inst.Observer().RecordKeyStatus(ctx, proofwatch.KeyStatusRevoked)
+
+	statuses := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "signing_key_status_count") {
+		statuses[value(dp.Attributes, "signing_key.status")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"active": 1, "revoked": 1}, statuses)
+
+	dropped := sumPoints(t, reader, "evidence_dropped_count")
+	require.Len(t, dropped, 1)
+	assert.Equal(t, string(proofwatch.DropReasonRevokedKey), value(dropped[0].Attributes, "reason"))
+}
+
+func TestObserverOrdering(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	assert.True(t, inst.Observer().ObserveSequence(ctx, "vm-1", 2))
+	assert.False(t, inst.Observer().ObserveSequence(ctx, "vm-1", 1))
+	inst.Observer().RecordOrderingViolation(ctx, "vm-2")
+
+	var violations int64
+	for _, dp := range sumPoints(t, reader, "ordering_violation_count") {
+		assert.NotContains(t, []string{"vm-1", "vm-2"}, value(dp.Attributes, "subject.bucket"))
+		violations += dp.Value
+	}
+	assert.Equal(t, int64(2), violations)
+}
+
+func TestWithSequenceSubjects(t *testing.T) {
+	inst, _ := setupObserverTest(t, proofwatch.WithSequenceSubjects(1))
+	ctx := context.Background()
+
+	assert.True(t, inst.Observer().ObserveSequence(ctx, "vm-1", 2))
+	assert.True(t, inst.Observer().ObserveSequence(ctx, "vm-2", 1))
+	assert.True(t, inst.Observer().ObserveSequence(ctx, "vm-1", 1), "vm-1 was forgotten for vm-2")
+}
+
+func TestWithBoundary(t *testing.T) {
+	t.Run("tags evidence with the boundary", func(t *testing.T) {
+		inst, reader := setupObserverTest(t, proofwatch.WithBoundary("fedramp-moderate"), proofwatch.WithBoundaries("fedramp-moderate"))
+
+		require.NoError(t, inst.Process(context.Background(), observedEvidence{}, succeed))
+
+		points := sumPoints(t, reader, "evidence_processed_count")
+		require.Len(t, points, 1)
+		assert.Equal(t, "fedramp-moderate", value(points[0].Attributes, "compliance.boundary"))
+	})
+
+	t.Run("unregistered boundary errors", func(t *testing.T) {
+		_, err := proofwatch.NewInstrumentation(proofwatch.WithBoundary("fedramp-high"))
+		assert.Error(t, err)
+	})
+}
+
+func TestWithDegradedMode(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithDegradedMode())
+	ctx := context.Background()
+
+	inst.Observer().SetDegraded(ctx, true)
+
+	gauge, ok := collect(t, reader, "evaluation_degraded_mode").Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	assert.Equal(t, int64(1), gauge.DataPoints[0].Value)
+	transitions := sumPoints(t, reader, "evaluation_degraded_mode_transitions_count")
+	require.Len(t, transitions, 1)
+	assert.Equal(t, "true", value(transitions[0].Attributes, "degraded"))
+}
+
+func TestObserverCanonicalCache(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordCanonicalCacheMiss(ctx)
+	inst.Observer().RecordCanonicalCacheHit(ctx)
+	inst.Observer().RecordCanonicalCacheHit(ctx)
+
+	hits := sumPoints(t, reader, "normalization_canonical_cache_hit_count")
+	require.Len(t, hits, 1)
+	assert.Equal(t, int64(2), hits[0].Value)
+	misses := sumPoints(t, reader, "normalization_canonical_cache_miss_count")
+	require.Len(t, misses, 1)
+	assert.Equal(t, int64(1), misses[0].Value)
+}
+
+func TestWithDefaultAttributes(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithDefaultAttributes(
+		attribute.String("tenant", "a"),
+		attribute.String("tenant", "b"),
+	))
+
+	require.NoError(t, inst.Process(context.Background(), observedEvidence{}, succeed))
+
+	points := sumPoints(t, reader, "evidence_processed_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, "b", value(points[0].Attributes, "tenant"))
+}
+
+func TestWithDurationHistogram(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithDurationHistogram(false))
+	ctx := context.Background()
+
+	inst.Observer().ObserveDuration(ctx, time.Second)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			assert.NotEqual(t, "evidence_processing_duration_seconds", m.Name)
+		}
+	}
+	summary, err := inst.Observer().ComplianceSummary(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, summary.ProcessingP95)
+}
+
+func TestWithNamePrefix(t *testing.T) {
+	t.Run("prefixes metric names", func(t *testing.T) {
+		inst, reader := setupObserverTest(t, proofwatch.WithNamePrefix("complybeacon"))
+
+		require.NoError(t, inst.Process(context.Background(), observedEvidence{}, succeed))
+
+		points := sumPoints(t, reader, "complybeacon_evidence_processed_count")
+		require.Len(t, points, 1)
+	})
+
+	t.Run("invalid prefix errors", func(t *testing.T) {
+		_, err := proofwatch.NewInstrumentation(proofwatch.WithNamePrefix("1 bad"))
+		assert.Error(t, err)
+	})
+}
+
+func TestObserverRecordWeightedResult(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	require.NoError(t, inst.Observer().RecordWeightedResult(ctx, 3, true))
+	require.NoError(t, inst.Observer().RecordWeightedResult(ctx, 1, false))
+	assert.Error(t, inst.Observer().RecordWeightedResult(ctx, -1, true))
+
+	weighted := func(name string) float64 {
+		t.Helper()
+		sum, ok := collect(t, reader, name).Data.(metricdata.Sum[float64])
+		require.True(t, ok)
+		require.Len(t, sum.DataPoints, 1)
+		return sum.DataPoints[0].Value
+	}
+	assert.Equal(t, 3.0, weighted("weighted_passed_total"))
+	assert.Equal(t, 4.0, weighted("weighted_total"))
+}
+
+func TestObserverRecordPolicyLoadFailure(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().RecordPolicyLoadFailure(ctx, "syntax_error")
+	inst.Observer().RecordPolicyLoadFailure(ctx, "disk on fire")
+
+	reasons := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "policy_load_failure_count") {
+		reasons[value(dp.Attributes, "policy.load.reason")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"syntax_error": 1, "other": 1}, reasons)
+}
+
+func TestWithPartitions(t *testing.T) {
+	t.Run("records partitions in range", func(t *testing.T) {
+		inst, reader := setupObserverTest(t, proofwatch.WithPartitions(4))
+		ctx := context.Background()
+
+		require.NoError(t, inst.Observer().ProcessedByPartition(ctx, 3))
+		assert.Error(t, inst.Observer().ProcessedByPartition(ctx, 4))
+
+		points := sumPoints(t, reader, "evidence_processed_count")
+		require.Len(t, points, 1)
+		assert.Equal(t, "3", value(points[0].Attributes, "partition"))
+	})
+
+	t.Run("non-positive count errors", func(t *testing.T) {
+		_, err := proofwatch.NewInstrumentation(proofwatch.WithPartitions(0))
+		assert.Error(t, err)
+	})
+}
+
+func TestWithWindows(t *testing.T) {
+	inst, reader := setupObserverTest(t, proofwatch.WithWindows("business-hours"))
+	ctx := context.Background()
+
+	inst.Observer().RecordOutOfWindow(ctx, "business-hours")
+	inst.Observer().RecordOutOfWindow(ctx, "full-moon")
+
+	windows := map[string]int64{}
+	for _, dp := range sumPoints(t, reader, "evaluation_out_of_window_count") {
+		windows[value(dp.Attributes, "window")] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"business-hours": 1, "other": 1}, windows)
+}
+
+func TestWithCardinalityLimit(t *testing.T) {
+	t.Run("collapses values beyond the limit", func(t *testing.T) {
+		inst, reader := setupObserverTest(t, proofwatch.WithCardinalityLimit("policy.id", 1))
+		ctx := context.Background()
+
+		for _, id := range []string{"AC-1", "AC-2", "AC-1"} {
+			require.NoError(t, inst.Process(ctx, observedEvidence{attribute.String("policy.id", id)}, succeed))
+		}
+
+		policies := map[string]int64{}
+		for _, dp := range sumPoints(t, reader, "evidence_processed_count") {
+			policies[value(dp.Attributes, "policy.id")] += dp.Value
+		}
+		assert.Equal(t, map[string]int64{"AC-1": 2, "__overflow__": 1}, policies)
+	})
+
+	t.Run("non-positive limit errors", func(t *testing.T) {
+		_, err := proofwatch.NewInstrumentation(proofwatch.WithCardinalityLimit("policy.id", 0))
+		assert.Error(t, err)
+	})
+}
+
+func TestWithControlCharPolicy(t *testing.T) {
+	ctx := context.Background()
+	evidence := observedEvidence{attribute.String("policy.id", "AC-1\x1b[31m")}
+
+	t.Run("sanitizes by default", func(t *testing.T) {
+		inst, reader := setupObserverTest(t)
+
+		require.NoError(t, inst.Process(ctx, evidence, succeed))
+
+		points := sumPoints(t, reader, "evidence_processed_count")
+		require.Len(t, points, 1)
+		assert.Equal(t, "AC-1[31m", value(points[0].Attributes, "policy.id"))
+	})
+
+	t.Run("reject skips the recording", func(t *testing.T) {
+		inst, reader := setupObserverTest(t, proofwatch.WithControlCharPolicy(proofwatch.ControlCharsReject))
+
+		require.NoError(t, inst.Process(ctx, evidence, succeed))
+
+		rejected := sumPoints(t, reader, "evidence_rejected_attributes_count")
+		require.Len(t, rejected, 1)
+		assert.Equal(t, int64(1), rejected[0].Value)
+	})
+}
+
+func TestWithRecordingSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	inst, _ := setupObserverTest(t, proofwatch.WithTracerProvider(tracerProvider), proofwatch.WithRecordingSpans())
+
+	require.NoError(t, inst.Process(context.Background(), observedEvidence{attribute.String("policy.id", "AC-1")}, succeed))
+
+	var recorded []sdktrace.ReadOnlySpan
+	for _, span := range exporter.GetSpans().Snapshots() {
+		if span.Name() == "evidence.record.processed" {
+			recorded = append(recorded, span)
+		}
+	}
+	require.Len(t, recorded, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("policy.id", "AC-1")}, recorded[0].Attributes())
+}
+
+func TestObserverObserveSize(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	ctx := context.Background()
+
+	inst.Observer().ObserveSize(ctx, 2048)
+	inst.Observer().ObserveSize(ctx, -1)
+
+	sizes, ok := collect(t, reader, "evidence_size_bytes").Data.(metricdata.Histogram[int64])
+	require.True(t, ok)
+	require.Len(t, sizes.DataPoints, 1)
+	assert.Equal(t, uint64(2), sizes.DataPoints[0].Count)
+	assert.Equal(t, int64(2048), sizes.DataPoints[0].Sum)
+}
+
+func TestObserverRecordBatch(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+	policy := []attribute.KeyValue{attribute.String("policy.id", "AC-1")}
+
+	inst.Observer().RecordBatch(context.Background(), []proofwatch.EvidenceResult{
+		{Outcome: proofwatch.OutcomeProcessed, Attributes: policy},
+		{Outcome: proofwatch.OutcomeProcessed, Attributes: policy},
+		{Outcome: proofwatch.OutcomeDropped, Reason: proofwatch.DropReasonDuplicate, Attributes: policy},
+	})
+
+	processed := sumPoints(t, reader, "evidence_processed_count")
+	require.Len(t, processed, 1)
+	assert.Equal(t, int64(2), processed[0].Value)
+	dropped := sumPoints(t, reader, "evidence_dropped_count")
+	require.Len(t, dropped, 1)
+	assert.Equal(t, string(proofwatch.DropReasonDuplicate), value(dropped[0].Attributes, "reason"))
+}
+
+func TestObserverDropped(t *testing.T) {
+	inst, reader := setupObserverTest(t)
+
+	inst.Observer().Dropped(context.Background(), attribute.String("policy.id", "AC-1"))
+
+	points := sumPoints(t, reader, "evidence_dropped_count")
+	require.Len(t, points, 1)
+	assert.Equal(t, "AC-1", value(points[0].Attributes, "policy.id"))
+}
@@ -0,0 +1,148 @@
+// Package opa provides a proofwatch.PolicyEvaluator backed by Open Policy Agent, for
+// evaluating evidence against Rego policies.
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// DefaultQuery is the query evaluated unless WithQuery is set.
+const DefaultQuery = "data.proofwatch.allow"
+
+// ErrUndefined is returned by Evaluate when the query is undefined for the evidence,
+// typically because the policy has no default for it.
+var ErrUndefined = errors.New("policy query is undefined for the evidence")
+
+var _ proofwatch.PolicyEvaluator = (*Evaluator)(nil)
+
+type config struct {
+	query    string
+	policyID string
+}
+
+// Option configures an Evaluator.
+type Option func(*config)
+
+// WithQuery sets the Rego query evaluated for each piece of evidence. The query must
+// produce either a boolean, where true means pass and false means fail, or a string
+// holding the evaluation status.
+// If none is specified, DefaultQuery is used.
+func WithQuery(query string) Option {
+	return func(cfg *config) {
+		if query != "" {
+			cfg.query = query
+		}
+	}
+}
+
+// WithPolicyID sets the policy ID reported in evaluation results.
+// If none is specified, the query is used.
+func WithPolicyID(id string) Option {
+	return func(cfg *config) {
+		cfg.policyID = id
+	}
+}
+
+// Evaluator evaluates evidence against Rego policies, using the evidence JSON as input.
+type Evaluator struct {
+	query    rego.PreparedEvalQuery
+	policyID string
+}
+
+// NewEvaluator creates an Evaluator from the Rego modules in dir and its
+// subdirectories. Rego test files, ending in _test.rego, are skipped.
+func NewEvaluator(ctx context.Context, dir string, opts ...Option) (*Evaluator, error) {
+	cfg := config{query: DefaultQuery}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.policyID == "" {
+		cfg.policyID = cfg.query
+	}
+
+	modules, err := loadModules(dir)
+	if err != nil {
+		return nil, err
+	}
+	regoOpts := []func(*rego.Rego){rego.Query(cfg.query)}
+	for name, src := range modules {
+		regoOpts = append(regoOpts, rego.Module(name, src))
+	}
+
+	query, err := rego.New(regoOpts...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare policy query: %w", err)
+	}
+	return &Evaluator{query: query, policyID: cfg.policyID}, nil
+}
+
+// loadModules reads the Rego modules under dir by path.
+func loadModules(dir string) (map[string]string, error) {
+	modules := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".rego" || strings.HasSuffix(path, "_test.rego") {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		modules[path] = string(src)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load policies from %s: %w", dir, err)
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no Rego policies found in %s", dir)
+	}
+	return modules, nil
+}
+
+// Evaluate evaluates the query with the evidence JSON as input.
+func (e *Evaluator) Evaluate(ctx context.Context, evidence proofwatch.Evidence) (proofwatch.EvaluationResult, error) {
+	data, err := evidence.ToJSON()
+	if err != nil {
+		return proofwatch.EvaluationResult{}, fmt.Errorf("failed to marshal evidence: %w", err)
+	}
+	var input any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return proofwatch.EvaluationResult{}, fmt.Errorf("failed to decode evidence: %w", err)
+	}
+
+	rs, err := e.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return proofwatch.EvaluationResult{}, fmt.Errorf("failed to evaluate policy: %w", err)
+	}
+	if len(rs) == 0 || len(rs[0].Expressions) == 0 {
+		return proofwatch.EvaluationResult{}, ErrUndefined
+	}
+
+	result := proofwatch.EvaluationResult{PolicyID: e.policyID}
+	switch v := rs[0].Expressions[0].Value.(type) {
+	case bool:
+		result.Status = proofwatch.EvaluationStatusFail
+		if v {
+			result.Status = proofwatch.EvaluationStatusPass
+		}
+	case string:
+		result.Status = proofwatch.EvaluationStatus(v)
+	default:
+		return proofwatch.EvaluationResult{}, fmt.Errorf("policy query returned %T, want a boolean or a status string", v)
+	}
+	return result, nil
+}
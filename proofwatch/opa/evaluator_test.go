@@ -0,0 +1,112 @@
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const allowPolicy = `package proofwatch
+
+default allow := false
+
+allow if input.encrypted == true
+`
+
+const statusPolicy = `package proofwatch
+
+status := "skipped" if input.exempt
+
+status := "pass" if {
+	not input.exempt
+	input.encrypted == true
+}
+`
+
+type testEvidence map[string]any
+
+func (e testEvidence) ToJSON() ([]byte, error) {
+	return json.Marshal(map[string]any(e))
+}
+
+func (e testEvidence) Attributes() []attribute.KeyValue {
+	return nil
+}
+
+func (e testEvidence) Timestamp() time.Time {
+	return time.Now()
+}
+
+func writePolicies(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(src), 0o600))
+	}
+	return dir
+}
+
+func TestEvaluator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("allow and deny", func(t *testing.T) {
+		dir := writePolicies(t, map[string]string{
+			"encryption/allow.rego":      allowPolicy,
+			"encryption/allow_test.rego": "package proofwatch\n\nthis is not valid rego\n",
+		})
+		evaluator, err := NewEvaluator(ctx, dir, WithPolicyID("encryption-at-rest"))
+		require.NoError(t, err)
+
+		result, err := evaluator.Evaluate(ctx, testEvidence{"encrypted": true})
+		require.NoError(t, err)
+		assert.Equal(t, proofwatch.EvaluationResult{PolicyID: "encryption-at-rest", Status: proofwatch.EvaluationStatusPass}, result)
+
+		result, err = evaluator.Evaluate(ctx, testEvidence{"encrypted": false})
+		require.NoError(t, err)
+		assert.Equal(t, proofwatch.EvaluationStatusFail, result.Status)
+	})
+
+	t.Run("status query", func(t *testing.T) {
+		dir := writePolicies(t, map[string]string{"status.rego": statusPolicy})
+		evaluator, err := NewEvaluator(ctx, dir, WithQuery("data.proofwatch.status"))
+		require.NoError(t, err)
+
+		result, err := evaluator.Evaluate(ctx, testEvidence{"exempt": true})
+		require.NoError(t, err)
+		assert.Equal(t, proofwatch.EvaluationResult{PolicyID: "data.proofwatch.status", Status: proofwatch.EvaluationStatusSkipped}, result)
+
+		_, err = evaluator.Evaluate(ctx, testEvidence{"exempt": false, "encrypted": false})
+		assert.ErrorIs(t, err, ErrUndefined)
+	})
+
+	t.Run("surfaces evaluation errors", func(t *testing.T) {
+		dir := writePolicies(t, map[string]string{"allow.rego": allowPolicy})
+		evaluator, err := NewEvaluator(ctx, dir, WithQuery("data.proofwatch"))
+		require.NoError(t, err)
+
+		_, err = evaluator.Evaluate(ctx, testEvidence{"encrypted": true})
+		assert.ErrorContains(t, err, "want a boolean or a status string")
+	})
+
+	t.Run("rejects invalid policy directories", func(t *testing.T) {
+		_, err := NewEvaluator(ctx, t.TempDir())
+		assert.ErrorContains(t, err, "no Rego policies found")
+
+		_, err = NewEvaluator(ctx, filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+
+		_, err = NewEvaluator(ctx, writePolicies(t, map[string]string{"broken.rego": "package proofwatch\n\nallow if {"}))
+		assert.ErrorContains(t, err, "failed to prepare policy query")
+	})
+}
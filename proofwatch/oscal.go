@@ -0,0 +1,173 @@
+package proofwatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+var _ Evidence = (*OSCALEvidence)(nil)
+
+// OSCAL finding target states.
+const (
+	oscalStateSatisfied    = "satisfied"
+	oscalStateNotSatisfied = "not-satisfied"
+)
+
+// oscalAssessmentResults is the subset of an OSCAL Assessment Results document read
+// by FromOSCALAssessmentResults.
+type oscalAssessmentResults struct {
+	AssessmentResults *struct {
+		UUID    string        `json:"uuid"`
+		Results []oscalResult `json:"results"`
+	} `json:"assessment-results"`
+}
+
+type oscalResult struct {
+	UUID         string             `json:"uuid"`
+	End          *time.Time         `json:"end"`
+	Start        time.Time          `json:"start"`
+	Observations []oscalObservation `json:"observations"`
+	Findings     []oscalFinding     `json:"findings"`
+}
+
+type oscalObservation struct {
+	UUID        string    `json:"uuid"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Collected   time.Time `json:"collected"`
+}
+
+type oscalFinding struct {
+	UUID        string `json:"uuid"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Target      struct {
+		Type     string `json:"type"`
+		TargetID string `json:"target-id"`
+		Status   struct {
+			State string `json:"state"`
+		} `json:"status"`
+	} `json:"target"`
+	RelatedObservations []struct {
+		ObservationUUID string `json:"observation-uuid"`
+	} `json:"related-observations"`
+}
+
+// OSCALEvidence represents a finding, or an observation no finding resolved, from an
+// OSCAL Assessment Results document.
+type OSCALEvidence struct {
+	ResultUUID      string           `json:"result-uuid"`
+	FindingUUID     string           `json:"finding-uuid,omitempty"`
+	ObservationUUID string           `json:"observation-uuid,omitempty"`
+	ControlID       string           `json:"control-id,omitempty"`
+	Title           string           `json:"title,omitempty"`
+	Description     string           `json:"description,omitempty"`
+	Status          EvaluationStatus `json:"status,omitempty"`
+	Collected       time.Time        `json:"collected,omitzero"`
+	// DropReason is set when the evidence has no result to evaluate and should be
+	// recorded as dropped with this reason rather than processed.
+	DropReason DropReason `json:"drop-reason,omitempty"`
+}
+
+// FromOSCALAssessmentResults parses an OSCAL Assessment Results JSON document into
+// evidence. Each finding becomes one evidence item, carrying its target ID as policy.id
+// and its target state as policy.evaluation.status: satisfied maps to pass and
+// not-satisfied to fail. Findings without a recognized state, and observations not
+// related to any finding, are returned with DropReason set to validation_failed so the
+// caller can count them as dropped.
+func FromOSCALAssessmentResults(r io.Reader) ([]Evidence, error) {
+	var doc oscalAssessmentResults
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode OSCAL assessment results: %w", err)
+	}
+	if doc.AssessmentResults == nil {
+		return nil, errors.New("document is missing assessment-results")
+	}
+
+	var evidence []Evidence
+	for _, result := range doc.AssessmentResults.Results {
+		collected := result.Start
+		if result.End != nil {
+			collected = *result.End
+		}
+
+		resolved := make(map[string]bool)
+		for _, finding := range result.Findings {
+			ev := OSCALEvidence{
+				ResultUUID:  result.UUID,
+				FindingUUID: finding.UUID,
+				ControlID:   finding.Target.TargetID,
+				Title:       finding.Title,
+				Description: finding.Description,
+				Collected:   collected,
+			}
+			switch finding.Target.Status.State {
+			case oscalStateSatisfied:
+				ev.Status = EvaluationStatusPass
+			case oscalStateNotSatisfied:
+				ev.Status = EvaluationStatusFail
+			default:
+				ev.DropReason = DropReasonValidationFailed
+			}
+			if ev.DropReason == "" {
+				for _, related := range finding.RelatedObservations {
+					resolved[related.ObservationUUID] = true
+				}
+			}
+			evidence = append(evidence, ev)
+		}
+
+		for _, observation := range result.Observations {
+			if resolved[observation.UUID] {
+				continue
+			}
+			ev := OSCALEvidence{
+				ResultUUID:      result.UUID,
+				ObservationUUID: observation.UUID,
+				Title:           observation.Title,
+				Description:     observation.Description,
+				Collected:       observation.Collected,
+				DropReason:      DropReasonValidationFailed,
+			}
+			if ev.Collected.IsZero() {
+				ev.Collected = collected
+			}
+			evidence = append(evidence, ev)
+		}
+	}
+	return evidence, nil
+}
+
+func (o OSCALEvidence) ToJSON() ([]byte, error) {
+	return json.Marshal(o)
+}
+
+func (o OSCALEvidence) Attributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String(COMPLIANCE_ASSESSMENT_ID, o.ResultUUID),
+	}
+	if o.ControlID != "" {
+		attrs = append(attrs, metrics.PolicyIDKey.String(o.ControlID))
+	}
+	if o.Status != "" {
+		attrs = append(attrs, metrics.EvaluationStatusKey.String(string(o.Status)))
+	}
+	if o.Title != "" {
+		attrs = append(attrs, attribute.String(POLICY_RULE_NAME, o.Title))
+	}
+	return attrs
+}
+
+func (o OSCALEvidence) Timestamp() time.Time {
+	if o.Collected.IsZero() {
+		return time.Now()
+	}
+	return o.Collected
+}
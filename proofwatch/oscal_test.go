@@ -0,0 +1,78 @@
+package proofwatch
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestFromOSCALAssessmentResults(t *testing.T) {
+	t.Run("parses findings and unresolved observations", func(t *testing.T) {
+		f, err := os.Open("testdata/oscal-assessment-results.json")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = f.Close() })
+
+		evidence, err := FromOSCALAssessmentResults(f)
+		require.NoError(t, err)
+		require.Len(t, evidence, 3)
+
+		satisfied := evidence[0].(OSCALEvidence)
+		assert.Equal(t, "ac-2", satisfied.ControlID)
+		assert.Equal(t, EvaluationStatusPass, satisfied.Status)
+		assert.Empty(t, satisfied.DropReason)
+		assert.Equal(t, time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC), satisfied.Timestamp())
+		assert.Contains(t, satisfied.Attributes(), attribute.String("policy.id", "ac-2"))
+		assert.Contains(t, satisfied.Attributes(), attribute.String("policy.evaluation.status", "pass"))
+		assert.Contains(t, satisfied.Attributes(), attribute.String(COMPLIANCE_ASSESSMENT_ID, "7ee4f0ab-4b5e-4f5e-9b8a-2c7a0c1ed1e1"))
+
+		notSatisfied := evidence[1].(OSCALEvidence)
+		assert.Equal(t, "ia-2.1", notSatisfied.ControlID)
+		assert.Equal(t, EvaluationStatusFail, notSatisfied.Status)
+		assert.Contains(t, notSatisfied.Attributes(), attribute.String("policy.evaluation.status", "fail"))
+
+		orphan := evidence[2].(OSCALEvidence)
+		assert.Equal(t, "f1e2d3c4-b5a6-4978-8a9b-0c1d2e3f4a5b", orphan.ObservationUUID)
+		assert.Equal(t, DropReasonValidationFailed, orphan.DropReason)
+		assert.Empty(t, orphan.Status)
+		assert.Equal(t, time.Date(2025, 3, 4, 5, 3, 0, 0, time.UTC), orphan.Timestamp())
+
+		data, err := orphan.ToJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"drop-reason":"validation_failed"`)
+	})
+
+	t.Run("drops findings without a state", func(t *testing.T) {
+		doc := `{"assessment-results": {"uuid": "ar", "results": [{
+			"uuid": "r1",
+			"start": "2025-03-04T05:00:00Z",
+			"observations": [{"uuid": "o1", "collected": "2025-03-04T05:01:00Z"}],
+			"findings": [{
+				"uuid": "f1",
+				"target": {"type": "objective-id", "target-id": "ac-3", "status": {}},
+				"related-observations": [{"observation-uuid": "o1"}]
+			}]
+		}]}}`
+
+		evidence, err := FromOSCALAssessmentResults(strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Len(t, evidence, 2)
+
+		finding := evidence[0].(OSCALEvidence)
+		assert.Equal(t, "ac-3", finding.ControlID)
+		assert.Equal(t, DropReasonValidationFailed, finding.DropReason)
+		assert.Equal(t, DropReasonValidationFailed, evidence[1].(OSCALEvidence).DropReason)
+	})
+
+	t.Run("rejects invalid documents", func(t *testing.T) {
+		_, err := FromOSCALAssessmentResults(strings.NewReader("{"))
+		assert.Error(t, err)
+
+		_, err = FromOSCALAssessmentResults(strings.NewReader(`{"catalog": {}}`))
+		assert.ErrorContains(t, err, "missing assessment-results")
+	})
+}
@@ -0,0 +1,37 @@
+package proofwatch
+
+import (
+	"context"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// OTLPConfig configures the OTLP/gRPC metric exporter created by NewOTLPMeterProvider.
+// Zero values defer to the standard OTEL_EXPORTER_OTLP_* and OTEL_METRIC_EXPORT_*
+// environment variables, and then to the exporter defaults.
+type OTLPConfig = metrics.OTLPConfig
+
+// OTLPOption configures NewOTLPMeterProvider beyond the exporter settings.
+type OTLPOption = metrics.OTLPOption
+
+// WithStartupCheck makes NewOTLPMeterProvider fail fast when the collector endpoint
+// cannot be reached within timeout. A non-positive timeout uses five seconds.
+func WithStartupCheck(timeout time.Duration) OTLPOption {
+	return metrics.WithStartupCheck(timeout)
+}
+
+// WithExportCallback calls fn with the outcome of every export: nil when it succeeded,
+// and the export error otherwise.
+func WithExportCallback(fn func(error)) OTLPOption {
+	return metrics.WithExportCallback(fn)
+}
+
+// NewOTLPMeterProvider creates a MeterProvider that periodically pushes the evidence
+// metrics to an OpenTelemetry collector over OTLP/gRPC, to pass to WithMeterProvider.
+// Call Shutdown on the provider before exiting.
+func NewOTLPMeterProvider(ctx context.Context, cfg OTLPConfig, opts ...OTLPOption) (*sdkmetric.MeterProvider, error) {
+	return metrics.NewOTLPMeterProvider(ctx, cfg, opts...)
+}
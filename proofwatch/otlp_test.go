@@ -0,0 +1,70 @@
+package proofwatch
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+)
+
+// otlpReceiver is an in-process OTLP/gRPC metrics receiver recording exported metric
+// names.
+type otlpReceiver struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+func (r *otlpReceiver) Export(_ context.Context, req *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rm := range req.GetResourceMetrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				r.names[m.GetName()] = true
+			}
+		}
+	}
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (r *otlpReceiver) received(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.names[name]
+}
+
+func TestNewOTLPMeterProvider(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	receiver := &otlpReceiver{names: make(map[string]bool)}
+	server := grpc.NewServer()
+	collectormetrics.RegisterMetricsServiceServer(server, receiver)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	ctx := context.Background()
+
+	var exports []error
+	provider, err := NewOTLPMeterProvider(ctx,
+		OTLPConfig{Endpoint: lis.Addr().String(), Insecure: true, Interval: time.Hour},
+		WithStartupCheck(time.Second),
+		WithExportCallback(func(err error) { exports = append(exports, err) }),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	inst, err := NewInstrumentation(WithMeterProvider(provider))
+	require.NoError(t, err)
+	require.NoError(t, inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return nil }))
+
+	require.NoError(t, provider.ForceFlush(ctx))
+	assert.True(t, receiver.received("evidence_processed_count"))
+	assert.Equal(t, []error{nil}, exports)
+}
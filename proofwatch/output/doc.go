@@ -0,0 +1,3 @@
+// Package output encodes processed proofwatch evidence into the formats consumed by
+// downstream security tooling.
+package output
@@ -0,0 +1,146 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ocsf "github.com/Santiago-Labs/go-ocsf/ocsf/v1_5_0"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// OCSF Compliance Finding class identifiers.
+const (
+	ocsfVersion                 = "1.5.0"
+	ocsfFindingsCategoryUID     = 2
+	ocsfComplianceFindingUID    = 2003
+	ocsfActivityCreate          = 1
+	ocsfComplianceFindingCreate = ocsfComplianceFindingUID*100 + ocsfActivityCreate
+)
+
+// OCSF compliance status identifiers.
+const (
+	ocsfComplianceStatusPass  int32 = 1
+	ocsfComplianceStatusFail  int32 = 3
+	ocsfComplianceStatusOther int32 = 99
+)
+
+// policyIDKey is the attribute identifying the policy evidence was evaluated against.
+const policyIDKey = attribute.Key("policy.id")
+
+// ToOCSFComplianceFinding encodes evidence evaluated with status as an OCSF Compliance
+// Finding event. The control is taken from the policy.id attribute, falling back to
+// the compliance control and policy rule IDs. Pass and fail map to the matching
+// compliance status_id; any other status maps to Other with the status as status_detail.
+// The evidence JSON is carried as raw_data.
+func ToOCSFComplianceFinding(e proofwatch.Evidence, status proofwatch.EvaluationStatus) ([]byte, error) {
+	raw, err := e.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal evidence: %w", err)
+	}
+	attrs := attributeMap(e.Attributes())
+	timestamp := e.Timestamp()
+
+	finding := ocsf.ComplianceFinding{
+		ActivityId:   ocsfActivityCreate,
+		ActivityName: ptr("Create"),
+		CategoryUid:  ocsfFindingsCategoryUID,
+		CategoryName: ptr("Findings"),
+		ClassUid:     ocsfComplianceFindingUID,
+		ClassName:    ptr("Compliance Finding"),
+		TypeUid:      ocsfComplianceFindingCreate,
+		TypeName:     ptr("Compliance Finding: Create"),
+		Time:         timestamp.UnixMilli(),
+		EventDay:     int32(timestamp.Unix() / int64(24*time.Hour/time.Second)),
+		Metadata: ocsf.Metadata{
+			Version: ocsfVersion,
+			Product: ocsf.Product{
+				Name:    ptr(proofwatch.ScopeName),
+				Version: ptr(proofwatch.Version()),
+			},
+		},
+		FindingInfo: ocsf.FindingInformation{
+			Uid:         findingUID(attrs, raw),
+			Title:       optional(attrs[proofwatch.POLICY_RULE_NAME]),
+			CreatedTime: ptr(timestamp.UnixMilli()),
+		},
+		Compliance: ocsf.Compliance{
+			Control: optional(firstOf(attrs, string(policyIDKey), proofwatch.COMPLIANCE_CONTROL_ID, proofwatch.POLICY_RULE_ID)),
+		},
+		Message:     optional(attrs[proofwatch.POLICY_EVALUATION_MESSAGE]),
+		RawData:     ptr(string(raw)),
+		RawDataSize: ptr(int64(len(raw))),
+	}
+	if standard := attrs[proofwatch.COMPLIANCE_CONTROL_CATALOG_ID]; standard != "" {
+		finding.Compliance.Standards = []string{standard}
+	}
+
+	switch status {
+	case proofwatch.EvaluationStatusPass:
+		finding.Compliance.StatusId = ptr(ocsfComplianceStatusPass)
+		finding.Compliance.Status = ptr("Pass")
+	case proofwatch.EvaluationStatusFail:
+		finding.Compliance.StatusId = ptr(ocsfComplianceStatusFail)
+		finding.Compliance.Status = ptr("Fail")
+	default:
+		detail := string(status)
+		if detail == "" {
+			detail = "unknown"
+		}
+		finding.Compliance.StatusId = ptr(ocsfComplianceStatusOther)
+		finding.Compliance.Status = ptr("Other")
+		finding.Compliance.StatusDetails = []string{detail}
+		finding.StatusDetail = ptr(detail)
+	}
+
+	out, err := json.Marshal(finding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal compliance finding: %w", err)
+	}
+	return out, nil
+}
+
+// findingUID returns the assessment ID of the evidence, or a digest of its JSON when
+// it has none, so the same evidence always yields the same finding.
+func findingUID(attrs map[string]string, raw []byte) string {
+	if id := firstOf(attrs, proofwatch.COMPLIANCE_ASSESSMENT_ID, proofwatch.CLOUDEVENTS_EVENT_ID); id != "" {
+		return id
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:16])
+}
+
+// attributeMap returns the string form of attrs by key. Later values win.
+func attributeMap(attrs []attribute.KeyValue) map[string]string {
+	m := make(map[string]string, len(attrs))
+	for _, kv := range attrs {
+		m[string(kv.Key)] = kv.Value.Emit()
+	}
+	return m
+}
+
+// firstOf returns the first non-empty value of keys in attrs.
+func firstOf(attrs map[string]string, keys ...string) string {
+	for _, k := range keys {
+		if v := attrs[k]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// optional returns nil for the empty string so it is omitted from the output.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
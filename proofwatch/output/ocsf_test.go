@@ -0,0 +1,126 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	ocsf "github.com/Santiago-Labs/go-ocsf/ocsf/v1_5_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+type testEvidence struct {
+	attrs     []attribute.KeyValue
+	timestamp time.Time
+}
+
+func (e testEvidence) ToJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"kind": "test"})
+}
+
+func (e testEvidence) Attributes() []attribute.KeyValue {
+	return e.attrs
+}
+
+func (e testEvidence) Timestamp() time.Time {
+	return e.timestamp
+}
+
+func decodeFinding(t *testing.T, data []byte) ocsf.ComplianceFinding {
+	t.Helper()
+	var finding ocsf.ComplianceFinding
+	require.NoError(t, json.Unmarshal(data, &finding))
+	return finding
+}
+
+func TestToOCSFComplianceFinding(t *testing.T) {
+	timestamp := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	evidence := testEvidence{
+		attrs: []attribute.KeyValue{
+			attribute.String("policy.id", "AC-2"),
+			attribute.String(proofwatch.COMPLIANCE_CONTROL_ID, "ignored-control"),
+			attribute.String(proofwatch.COMPLIANCE_CONTROL_CATALOG_ID, "nist-800-53"),
+			attribute.String(proofwatch.COMPLIANCE_ASSESSMENT_ID, "assessment-1"),
+			attribute.String(proofwatch.POLICY_RULE_NAME, "Account management"),
+			attribute.String(proofwatch.POLICY_EVALUATION_MESSAGE, "all accounts reviewed"),
+		},
+		timestamp: timestamp,
+	}
+
+	t.Run("maps evidence to a compliance finding", func(t *testing.T) {
+		data, err := ToOCSFComplianceFinding(evidence, proofwatch.EvaluationStatusPass)
+		require.NoError(t, err)
+		finding := decodeFinding(t, data)
+
+		assert.Equal(t, int32(2003), finding.ClassUid)
+		assert.Equal(t, int32(2), finding.CategoryUid)
+		assert.Equal(t, int64(200301), finding.TypeUid)
+		assert.Equal(t, timestamp.UnixMilli(), finding.Time)
+		assert.Equal(t, int32(timestamp.Unix()/86400), finding.EventDay)
+		assert.Equal(t, "1.5.0", finding.Metadata.Version)
+		assert.Equal(t, proofwatch.ScopeName, *finding.Metadata.Product.Name)
+
+		assert.Equal(t, "assessment-1", finding.FindingInfo.Uid)
+		assert.Equal(t, "Account management", *finding.FindingInfo.Title)
+		assert.Equal(t, "all accounts reviewed", *finding.Message)
+		assert.Equal(t, "AC-2", *finding.Compliance.Control)
+		assert.Equal(t, []string{"nist-800-53"}, finding.Compliance.Standards)
+		assert.Equal(t, int32(1), *finding.Compliance.StatusId)
+		assert.Equal(t, "Pass", *finding.Compliance.Status)
+		assert.Nil(t, finding.StatusDetail)
+		assert.JSONEq(t, `{"kind":"test"}`, *finding.RawData)
+	})
+
+	t.Run("maps statuses", func(t *testing.T) {
+		tests := []struct {
+			status   proofwatch.EvaluationStatus
+			statusID int32
+			detail   string
+		}{
+			{status: proofwatch.EvaluationStatusPass, statusID: 1},
+			{status: proofwatch.EvaluationStatusFail, statusID: 3},
+			{status: proofwatch.EvaluationStatusError, statusID: 99, detail: "error"},
+			{status: proofwatch.EvaluationStatusSkipped, statusID: 99, detail: "skipped"},
+			{status: "not_applicable", statusID: 99, detail: "not_applicable"},
+			{status: "", statusID: 99, detail: "unknown"},
+		}
+		for _, tt := range tests {
+			t.Run(string(tt.status), func(t *testing.T) {
+				data, err := ToOCSFComplianceFinding(evidence, tt.status)
+				require.NoError(t, err)
+				finding := decodeFinding(t, data)
+
+				assert.Equal(t, tt.statusID, *finding.Compliance.StatusId)
+				if tt.detail == "" {
+					assert.Nil(t, finding.StatusDetail)
+					return
+				}
+				assert.Equal(t, "Other", *finding.Compliance.Status)
+				assert.Equal(t, tt.detail, *finding.StatusDetail)
+				assert.Equal(t, []string{tt.detail}, finding.Compliance.StatusDetails)
+			})
+		}
+	})
+
+	t.Run("falls back to rule ID and a content digest", func(t *testing.T) {
+		evidence := testEvidence{
+			attrs:     []attribute.KeyValue{attribute.String(proofwatch.POLICY_RULE_ID, "rule-7")},
+			timestamp: timestamp,
+		}
+
+		first, err := ToOCSFComplianceFinding(evidence, proofwatch.EvaluationStatusFail)
+		require.NoError(t, err)
+		second, err := ToOCSFComplianceFinding(evidence, proofwatch.EvaluationStatusFail)
+		require.NoError(t, err)
+
+		finding := decodeFinding(t, first)
+		assert.Equal(t, "rule-7", *finding.Compliance.Control)
+		assert.Len(t, finding.FindingInfo.Uid, 32)
+		assert.Equal(t, finding.FindingInfo.Uid, decodeFinding(t, second).FindingInfo.Uid)
+		assert.Nil(t, finding.FindingInfo.Title)
+	})
+}
@@ -0,0 +1,165 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// SARIF 2.1.0 identifiers.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// unknownRuleID is the rule ID of results without a policy ID.
+const unknownRuleID = "unknown"
+
+type sarifConfig struct {
+	includePassing bool
+}
+
+// SARIFOption configures ToSARIF.
+type SARIFOption func(*sarifConfig)
+
+// WithPassingResults sets whether passing evaluations are included as results of kind
+// pass and level none. If none is specified, passing evaluations are omitted.
+func WithPassingResults(include bool) SARIFOption {
+	return func(cfg *sarifConfig) {
+		cfg.includePassing = include
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// ToSARIF encodes evaluation results as a SARIF 2.1.0 log with a single run, so failed
+// compliance evaluations surface in code scanning tools. Each failed evaluation is a
+// result of level error whose rule ID is the policy ID; every policy gets a rule in the
+// tool driver. The result location is the evaluation Location, as a logical resource
+// location and, when it is a URI or path, a physical location. Passing evaluations are
+// included as level none only with WithPassingResults; other statuses are omitted.
+func ToSARIF(results []proofwatch.EvaluationResult, opts ...SARIFOption) ([]byte, error) {
+	var cfg sarifConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:    proofwatch.ScopeName,
+			Version: proofwatch.Version(),
+		}},
+		Results: []sarifResult{},
+	}
+	ruleIndex := map[string]int{}
+	for _, r := range results {
+		var kind, level, verb string
+		switch {
+		case r.Status == proofwatch.EvaluationStatusFail:
+			kind, level, verb = "fail", "error", "failed"
+		case r.Status == proofwatch.EvaluationStatusPass && cfg.includePassing:
+			kind, level, verb = "pass", "none", "passed"
+		default:
+			continue
+		}
+
+		ruleID := r.PolicyID
+		if ruleID == "" {
+			ruleID = unknownRuleID
+		}
+		index, ok := ruleIndex[ruleID]
+		if !ok {
+			index = len(run.Tool.Driver.Rules)
+			ruleIndex[ruleID] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: ruleID})
+		}
+
+		result := sarifResult{
+			RuleID:    ruleID,
+			RuleIndex: index,
+			Kind:      kind,
+			Level:     level,
+			Message:   sarifMessage{Text: fmt.Sprintf("Policy %s %s", ruleID, verb)},
+		}
+		if r.Location != "" {
+			result.Locations = []sarifLocation{sarifLocationOf(r.Location)}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	out, err := json.Marshal(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sarif log: %w", err)
+	}
+	return out, nil
+}
+
+// sarifLocationOf returns a logical resource location for location, along with a
+// physical location when location is a URI or a path, such as a URL or file path.
+func sarifLocationOf(location string) sarifLocation {
+	logical := []sarifLogicalLocation{{FullyQualifiedName: location, Kind: "resource"}}
+	if u, err := url.Parse(location); err == nil && (u.Scheme != "" || strings.Contains(u.Path, "/")) {
+		return sarifLocation{
+			PhysicalLocation: &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: u.String()}},
+			LogicalLocations: logical,
+		}
+	}
+	return sarifLocation{LogicalLocations: logical}
+}
@@ -0,0 +1,115 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// decodedSARIF is the subset of a SARIF log checked by the tests.
+type decodedSARIF struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					ID string `json:"id"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex int    `json:"ruleIndex"`
+			Kind      string `json:"kind"`
+			Level     string `json:"level"`
+			Message   struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation *struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+				} `json:"physicalLocation"`
+				LogicalLocations []struct {
+					FullyQualifiedName string `json:"fullyQualifiedName"`
+				} `json:"logicalLocations"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func decodeSARIF(t *testing.T, data []byte) decodedSARIF {
+	t.Helper()
+	var log decodedSARIF
+	require.NoError(t, json.Unmarshal(data, &log))
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	return log
+}
+
+func TestToSARIF(t *testing.T) {
+	results := []proofwatch.EvaluationResult{
+		{PolicyID: "encryption-at-rest", Status: proofwatch.EvaluationStatusFail, Location: "terraform/storage.tf"},
+		{PolicyID: "mfa-enabled", Status: proofwatch.EvaluationStatusPass, Location: "https://github.com/org/repo"},
+		{PolicyID: "encryption-at-rest", Status: proofwatch.EvaluationStatusFail, Location: "prod-bucket"},
+		{PolicyID: "logging", Status: proofwatch.EvaluationStatusError},
+		{Status: proofwatch.EvaluationStatusFail},
+	}
+
+	t.Run("failed evaluations only by default", func(t *testing.T) {
+		data, err := ToSARIF(results)
+		require.NoError(t, err)
+		run := decodeSARIF(t, data).Runs[0]
+
+		assert.Equal(t, proofwatch.ScopeName, run.Tool.Driver.Name)
+		require.Len(t, run.Tool.Driver.Rules, 2)
+		assert.Equal(t, "encryption-at-rest", run.Tool.Driver.Rules[0].ID)
+		assert.Equal(t, "unknown", run.Tool.Driver.Rules[1].ID)
+
+		require.Len(t, run.Results, 3)
+		for _, r := range run.Results {
+			assert.Equal(t, "error", r.Level)
+			assert.Equal(t, "fail", r.Kind)
+			assert.Equal(t, r.RuleID, run.Tool.Driver.Rules[r.RuleIndex].ID)
+		}
+		assert.Equal(t, []string{"encryption-at-rest", "encryption-at-rest", "unknown"},
+			[]string{run.Results[0].RuleID, run.Results[1].RuleID, run.Results[2].RuleID})
+		assert.Equal(t, "Policy encryption-at-rest failed", run.Results[0].Message.Text)
+
+		require.Len(t, run.Results[0].Locations, 1)
+		require.NotNil(t, run.Results[0].Locations[0].PhysicalLocation)
+		assert.Equal(t, "terraform/storage.tf", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		require.Len(t, run.Results[1].Locations, 1)
+		assert.Nil(t, run.Results[1].Locations[0].PhysicalLocation)
+		assert.Equal(t, "prod-bucket", run.Results[1].Locations[0].LogicalLocations[0].FullyQualifiedName)
+		assert.Empty(t, run.Results[2].Locations)
+	})
+
+	t.Run("passing evaluations marked as level none", func(t *testing.T) {
+		data, err := ToSARIF(results, WithPassingResults(true))
+		require.NoError(t, err)
+		run := decodeSARIF(t, data).Runs[0]
+
+		require.Len(t, run.Results, 4)
+		levels := map[string]string{}
+		for _, r := range run.Results {
+			levels[r.RuleID] = r.Level
+		}
+		assert.Equal(t, map[string]string{"encryption-at-rest": "error", "mfa-enabled": "none", "unknown": "error"}, levels)
+		assert.Equal(t, "pass", run.Results[1].Kind)
+		assert.Equal(t, "https://github.com/org/repo", run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	})
+
+	t.Run("no results yields an empty run", func(t *testing.T) {
+		data, err := ToSARIF(nil)
+		require.NoError(t, err)
+		assert.Empty(t, decodeSARIF(t, data).Runs[0].Results)
+		assert.Contains(t, string(data), `"results":[]`)
+	})
+}
@@ -0,0 +1,175 @@
+package proofwatch
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueFull is returned by Pool.Submit when the queue is full and the pool
+	// does not block.
+	ErrQueueFull = errors.New("evidence pool queue is full")
+	// ErrPoolClosed is returned by Pool.Submit after Pool.Close.
+	ErrPoolClosed = errors.New("evidence pool is closed")
+)
+
+// defaultQueueSize is the number of queued evidence items used unless WithQueueSize is set.
+const defaultQueueSize = 64
+
+type poolConfig struct {
+	workers     int
+	queueSize   int
+	blockOnFull bool
+}
+
+// PoolOption configures a Pool.
+type PoolOption func(*poolConfig)
+
+// WithWorkers sets the number of workers processing evidence concurrently.
+// If none is specified, GOMAXPROCS workers are used.
+func WithWorkers(n int) PoolOption {
+	return func(cfg *poolConfig) {
+		if n > 0 {
+			cfg.workers = n
+		}
+	}
+}
+
+// WithQueueSize sets the number of evidence items that may wait for a worker.
+// Negative values are ignored; zero means items are only handed directly to idle workers.
+func WithQueueSize(n int) PoolOption {
+	return func(cfg *poolConfig) {
+		if n >= 0 {
+			cfg.queueSize = n
+		}
+	}
+}
+
+// WithBlockOnFull makes Submit wait for room in a full queue instead of dropping the
+// evidence with reason queue_full.
+func WithBlockOnFull(block bool) PoolOption {
+	return func(cfg *poolConfig) {
+		cfg.blockOnFull = block
+	}
+}
+
+// poolItem is an evidence item waiting in the queue.
+type poolItem struct {
+	ctx      context.Context
+	evidence Evidence
+	queued   time.Time
+}
+
+// Pool processes evidence on a bounded number of workers fed by a bounded queue.
+// Each item is run through Instrumentation.Process, and the queue depth, active
+// workers and queue wait time are recorded on the Instrumentation's observer.
+type Pool struct {
+	inst  *Instrumentation
+	fn    ProcessFunc
+	cfg   poolConfig
+	queue chan poolItem
+	wg    sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewPool creates a Pool processing evidence with fn and starts its workers.
+// Call Close to stop accepting evidence and wait for the queue to drain.
+func NewPool(inst *Instrumentation, fn ProcessFunc, opts ...PoolOption) *Pool {
+	cfg := poolConfig{
+		workers:   runtime.GOMAXPROCS(0),
+		queueSize: defaultQueueSize,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	p := &Pool{
+		inst:  inst,
+		fn:    fn,
+		cfg:   cfg,
+		queue: make(chan poolItem, cfg.queueSize),
+	}
+	p.wg.Add(cfg.workers)
+	for range cfg.workers {
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues evidence for processing and records it as accepted, so the gap between
+// accepted and processed evidence reveals the backlog. When the queue is full, Submit
+// either waits for room or until ctx is done, if the pool was created WithBlockOnFull,
+// or records the evidence as dropped with reason queue_full and returns ErrQueueFull.
+// Processing keeps ctx's values but is not cancelled with it, and its error is recorded
+// by Instrumentation.Process rather than returned to the caller.
+func (p *Pool) Submit(ctx context.Context, evidence Evidence) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	observer := p.inst.observer
+	item := poolItem{ctx: context.WithoutCancel(ctx), evidence: evidence, queued: time.Now()}
+	observer.Enqueued(ctx)
+	if p.cfg.blockOnFull {
+		select {
+		case p.queue <- item:
+			observer.RecordAccepted(ctx, metricAttributes(p.inst.metricKeys, evidence.Attributes())...)
+			return nil
+		case <-ctx.Done():
+			observer.Unqueued(ctx)
+			return ctx.Err()
+		}
+	}
+	select {
+	case p.queue <- item:
+		observer.RecordAccepted(ctx, metricAttributes(p.inst.metricKeys, evidence.Attributes())...)
+		return nil
+	default:
+		observer.Unqueued(ctx)
+		p.inst.drop(ctx, evidence, DropReasonQueueFull, ErrQueueFull, metricAttributes(p.inst.metricKeys, evidence.Attributes()))
+		return ErrQueueFull
+	}
+}
+
+// Pressure reports how saturated the pool's queue is, from 0 when no evidence is
+// waiting for a worker to 1 when the queue is full and further evidence is dropped with
+// reason queue_full. Producers can use it to back off before that happens. A pool
+// created WithQueueSize(0) has no queue and always reports 0.
+func (p *Pool) Pressure() float64 {
+	if cap(p.queue) == 0 {
+		return 0
+	}
+	return float64(len(p.queue)) / float64(cap(p.queue))
+}
+
+// Close stops accepting evidence and waits until all queued evidence is processed.
+// Calling Close more than once is safe.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for item := range p.queue {
+		// Read the observer per item, so items picked up after Instrumentation.Reset are
+		// recorded on the new one.
+		observer := p.inst.observer
+		observer.Dequeued(item.ctx, time.Since(item.queued))
+		observer.WorkerStarted(item.ctx)
+		_ = p.inst.Process(item.ctx, item.evidence, p.fn)
+		observer.WorkerFinished(item.ctx)
+	}
+}
@@ -0,0 +1,193 @@
+package proofwatch
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// int64Sums returns the values of an int64 sum metric by the value of its reason attribute.
+func int64Sums(t *testing.T, reader *sdkmetric.ManualReader, name string) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	sums := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			data, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok, "metric %s is not an int64 sum", name)
+			for _, dp := range data.DataPoints {
+				reason, _ := dp.Attributes.Value("reason")
+				sums[reason.AsString()] += dp.Value
+			}
+		}
+	}
+	return sums
+}
+
+// histogramCount returns the number of measurements recorded by a float64 histogram.
+func histogramCount(t *testing.T, reader *sdkmetric.ManualReader, name string) uint64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	var count uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			data, ok := m.Data.(metricdata.Histogram[float64])
+			require.True(t, ok, "metric %s is not a float64 histogram", name)
+			for _, dp := range data.DataPoints {
+				count += dp.Count
+			}
+		}
+	}
+	return count
+}
+
+// blockingProcess returns a ProcessFunc that signals on started and then waits for
+// release to be closed.
+func blockingProcess(started chan<- struct{}, release <-chan struct{}) ProcessFunc {
+	return func(ctx context.Context, _ Evidence) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+}
+
+func TestPool(t *testing.T) {
+	t.Run("drops when the queue saturates", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		ctx := context.Background()
+		started, release := make(chan struct{}, 1), make(chan struct{})
+
+		pool := NewPool(inst, blockingProcess(started, release), WithWorkers(1), WithQueueSize(1))
+
+		require.NoError(t, pool.Submit(ctx, createTestEvidence()))
+		<-started
+		require.NoError(t, pool.Submit(ctx, createTestEvidence()))
+		assert.ErrorIs(t, pool.Submit(ctx, createTestEvidence()), ErrQueueFull)
+		assert.ErrorIs(t, pool.Submit(ctx, createTestEvidence()), ErrQueueFull)
+
+		assert.Equal(t, map[string]int64{"queue_full": 2}, int64Sums(t, reader, "evidence_dropped_count"))
+		assert.Equal(t, int64(1), int64Sums(t, reader, "evidence_queue_depth")[""])
+		assert.Equal(t, int64(1), int64Sums(t, reader, "evidence_active_workers")[""])
+
+		close(release)
+		<-started
+		pool.Close()
+
+		assert.Equal(t, int64(2), int64Sums(t, reader, "evidence_processed_count")[""])
+		assert.Equal(t, int64(0), int64Sums(t, reader, "evidence_queue_depth")[""])
+		assert.Equal(t, int64(0), int64Sums(t, reader, "evidence_active_workers")[""])
+		// Only the items picked up by the worker waited in the queue.
+		assert.Equal(t, uint64(2), histogramCount(t, reader, "evidence_queue_wait_seconds"))
+	})
+
+	t.Run("blocks when configured", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		ctx := context.Background()
+		started, release := make(chan struct{}, 4), make(chan struct{})
+
+		pool := NewPool(inst, blockingProcess(started, release), WithWorkers(1), WithQueueSize(1), WithBlockOnFull(true))
+
+		require.NoError(t, pool.Submit(ctx, createTestEvidence()))
+		<-started
+		require.NoError(t, pool.Submit(ctx, createTestEvidence()))
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		assert.ErrorIs(t, pool.Submit(cancelled, createTestEvidence()), context.Canceled)
+
+		submitted := make(chan error)
+		go func() { submitted <- pool.Submit(ctx, createTestEvidence()) }()
+		close(release)
+		require.NoError(t, <-submitted)
+		pool.Close()
+
+		assert.Equal(t, int64(3), int64Sums(t, reader, "evidence_processed_count")[""])
+		assert.False(t, metricNames(t, reader)["evidence_dropped_count"])
+		assert.Equal(t, int64(0), int64Sums(t, reader, "evidence_queue_depth")[""])
+		assert.Equal(t, uint64(3), histogramCount(t, reader, "evidence_queue_wait_seconds"))
+		assert.Equal(t, int64(0), int64Sums(t, reader, "evidence_active_workers")[""])
+	})
+
+	t.Run("processes concurrent submissions", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		ctx := context.Background()
+
+		pool := NewPool(inst, func(ctx context.Context, _ Evidence) error { return nil },
+			WithWorkers(4), WithQueueSize(8), WithBlockOnFull(true))
+
+		const items = 100
+		var wg sync.WaitGroup
+		wg.Add(items)
+		for range items {
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, pool.Submit(ctx, createTestEvidence()))
+			}()
+		}
+		wg.Wait()
+		pool.Close()
+
+		assert.Equal(t, int64(items), int64Sums(t, reader, "evidence_processed_count")[""])
+		assert.Equal(t, int64(0), int64Sums(t, reader, "evidence_queue_depth")[""])
+		assert.Equal(t, int64(0), int64Sums(t, reader, "evidence_active_workers")[""])
+	})
+
+	t.Run("pressure rises as the queue fills", func(t *testing.T) {
+		inst, _, _ := setupInstrumentationTest(t)
+		ctx := context.Background()
+		// Every accepted item signals on started, so started must hold all of them.
+		started, release := make(chan struct{}, 3), make(chan struct{})
+
+		pool := NewPool(inst, blockingProcess(started, release), WithWorkers(1), WithQueueSize(2))
+		assert.Zero(t, pool.Pressure())
+
+		require.NoError(t, pool.Submit(ctx, createTestEvidence()))
+		<-started
+		assert.Zero(t, pool.Pressure())
+		require.NoError(t, pool.Submit(ctx, createTestEvidence()))
+		assert.Equal(t, 0.5, pool.Pressure())
+		require.NoError(t, pool.Submit(ctx, createTestEvidence()))
+		assert.Equal(t, 1.0, pool.Pressure())
+		assert.ErrorIs(t, pool.Submit(ctx, createTestEvidence()), ErrQueueFull)
+		assert.Equal(t, 1.0, pool.Pressure())
+
+		close(release)
+		pool.Close()
+		assert.Zero(t, pool.Pressure())
+	})
+
+	t.Run("pool without a queue reports no pressure", func(t *testing.T) {
+		inst, _, _ := setupInstrumentationTest(t)
+
+		pool := NewPool(inst, func(context.Context, Evidence) error { return nil }, WithQueueSize(0))
+		defer pool.Close()
+		assert.Zero(t, pool.Pressure())
+	})
+
+	t.Run("rejects evidence after close", func(t *testing.T) {
+		inst, _, _ := setupInstrumentationTest(t)
+
+		pool := NewPool(inst, func(ctx context.Context, _ Evidence) error { return nil })
+		pool.Close()
+		pool.Close()
+
+		assert.ErrorIs(t, pool.Submit(context.Background(), createTestEvidence()), ErrPoolClosed)
+	})
+}
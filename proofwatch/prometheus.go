@@ -0,0 +1,18 @@
+package proofwatch
+
+import (
+	"net/http"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// NewPrometheusMeterProvider creates a MeterProvider backed by an OpenTelemetry
+// Prometheus exporter, to pass to WithMeterProvider, along with an http.Handler serving
+// the evidence metrics in the Prometheus exposition format, ready to be mounted on
+// /metrics. Further settings, such as views, are passed through opts. Call Shutdown on
+// the provider before exiting.
+func NewPrometheusMeterProvider(opts ...sdkmetric.Option) (*sdkmetric.MeterProvider, http.Handler, error) {
+	return metrics.NewPrometheusMeterProvider(opts...)
+}
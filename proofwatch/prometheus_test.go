@@ -0,0 +1,36 @@
+package proofwatch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrometheusMeterProvider(t *testing.T) {
+	provider, handler, err := NewPrometheusMeterProvider()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	inst, err := NewInstrumentation(WithMeterProvider(provider))
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return nil }))
+	require.Error(t, inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return assert.AnError }))
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	resp, err := http.Get(server.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Regexp(t, `evidence_processed_count\{[^}]*policy_rule_id="test-policy"[^}]*\} 1`, string(body))
+	assert.Regexp(t, `evidence_dropped_count\{[^}]*reason="processing_error"[^}]*\} 1`, string(body))
+}
@@ -8,7 +8,6 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	olog "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/log/global"
-	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
@@ -24,9 +23,12 @@ type ProofWatch struct {
 	tracer        trace.Tracer
 	observer      *metrics.EvidenceObserver
 	levelSeverity olog.Severity
+	redactor      *Redactor
+	metricKeys    map[attribute.Key]struct{}
 }
 
-// NewProofWatch creates a new ProofWatch instance with OpenTelemetry logging.
+// NewProofWatch creates a new ProofWatch instance with OpenTelemetry logging. Its
+// evidence observer is built as for NewInstrumentation, so observer options apply.
 func NewProofWatch(opts ...OptionFunc) (*ProofWatch, error) {
 	cfg := config{
 		MeterProvider:  otel.GetMeterProvider(),
@@ -37,8 +39,7 @@ func NewProofWatch(opts ...OptionFunc) (*ProofWatch, error) {
 		opt(&cfg)
 	}
 
-	meter := cfg.MeterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(Version()))
-	observer, err := metrics.NewEvidenceObserver(meter)
+	observer, err := newObserver(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +49,8 @@ func NewProofWatch(opts ...OptionFunc) (*ProofWatch, error) {
 		observer: observer,
 		// Default severity
 		levelSeverity: olog.SeverityInfo,
+		redactor:      cfg.Redactor,
+		metricKeys:    newMetricKeys(cfg.MetricKeys),
 	}, nil
 }
 
@@ -56,12 +59,19 @@ func (w *ProofWatch) Log(ctx context.Context, evidence Evidence) error {
 	return w.LogWithSeverity(ctx, evidence, w.levelSeverity)
 }
 
-// LogWithSeverity logs a policy event using OpenTelemetry's log API with a given severity level
+// LogWithSeverity logs a policy event using OpenTelemetry's log API with a given severity level.
+// When a redactor is configured, the redacted copy of the evidence is logged. The log
+// record and span event carry every evidence attribute, while metrics only carry those
+// with bounded values, as for Instrumentation.
 func (w *ProofWatch) LogWithSeverity(ctx context.Context, evidence Evidence, severity olog.Severity) error {
 
 	ctx, span := w.tracer.Start(ctx, "evidence.log_evidence")
 	defer span.End()
 
+	evidence, err := redact(w.redactor, evidence)
+	if err != nil {
+		return err
+	}
 	attrs := evidence.Attributes()
 
 	jsonData, err := evidence.ToJSON()
@@ -82,7 +92,7 @@ func (w *ProofWatch) LogWithSeverity(ctx context.Context, evidence Evidence, sev
 
 	w.logger.Emit(ctx, record)
 
-	w.observer.Processed(ctx, attrs...)
+	w.observer.Processed(ctx, metricAttributes(w.metricKeys, attrs)...)
 
 	return nil
 }
@@ -116,6 +116,36 @@ func TestNewProofWatch(t *testing.T) {
 	})
 }
 
+func TestNewProofWatchObserverOptions(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	pw, err := NewProofWatch(
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		WithLoggerProvider(noop.NewLoggerProvider()),
+		WithNamePrefix("complybeacon"),
+	)
+	require.NoError(t, err)
+
+	evidence := createTestEvidence()
+	require.NoError(t, pw.Log(context.Background(), evidence))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	var processed *metricdata.Sum[int64]
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == "complybeacon_evidence_processed_count" {
+			sum := m.Data.(metricdata.Sum[int64])
+			processed = &sum
+		}
+	}
+	require.NotNil(t, processed, "prefixed processed counter not recorded")
+	require.Len(t, processed.DataPoints, 1)
+	// Only bounded evidence attributes are recorded as labels.
+	want := attribute.NewSet(metricAttributes(newMetricKeys(nil), evidence.Attributes())...)
+	assert.True(t, want.Equals(&processed.DataPoints[0].Attributes))
+	assert.Less(t, want.Len(), len(evidence.Attributes()))
+}
+
 func TestProofWatchLog(t *testing.T) {
 	t.Run("log with default severity", func(t *testing.T) {
 		fixture := setupProofWatchTest(t)
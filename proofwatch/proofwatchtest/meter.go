@@ -0,0 +1,89 @@
+// Package proofwatchtest provides helpers for testing code instrumented with proofwatch.
+package proofwatchtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// MeterProvider is a MeterProvider collecting metrics with a ManualReader that can be
+// reset between test cases, discarding everything recorded so far.
+//
+// Meters obtained before a Reset keep recording into the discarded provider, so callers
+// must obtain their meters again after resetting. Instrumentation.Reset does so.
+type MeterProvider struct {
+	embedded.MeterProvider
+
+	opts []sdkmetric.Option
+
+	mu       sync.Mutex
+	provider *sdkmetric.MeterProvider
+	reader   *sdkmetric.ManualReader
+}
+
+// NewMeterProvider creates a MeterProvider collecting metrics with a ManualReader. opts
+// configure each underlying SDK MeterProvider, such as with views or a resource; they
+// must not add readers.
+func NewMeterProvider(opts ...sdkmetric.Option) *MeterProvider {
+	mp := &MeterProvider{opts: opts}
+	mp.provider, mp.reader = mp.newProvider()
+	return mp
+}
+
+func (mp *MeterProvider) newProvider() (*sdkmetric.MeterProvider, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	opts := append(mp.opts[:len(mp.opts):len(mp.opts)], sdkmetric.WithReader(reader))
+	return sdkmetric.NewMeterProvider(opts...), reader
+}
+
+// Meter returns a Meter from the current underlying provider.
+func (mp *MeterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	return mp.provider.Meter(name, opts...)
+}
+
+// Collect gathers the metrics recorded since the MeterProvider was created or last reset.
+func (mp *MeterProvider) Collect(ctx context.Context) (metricdata.ResourceMetrics, error) {
+	mp.mu.Lock()
+	reader := mp.reader
+	mp.mu.Unlock()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		return metricdata.ResourceMetrics{}, fmt.Errorf("failed to collect metrics: %w", err)
+	}
+	return rm, nil
+}
+
+// ForceFlush is a no-op: metrics are only read through Collect.
+func (mp *MeterProvider) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Reset discards all recorded metrics by swapping in a fresh SDK MeterProvider and
+// ManualReader, then shuts the previous provider down.
+func (mp *MeterProvider) Reset(ctx context.Context) error {
+	mp.mu.Lock()
+	previous := mp.provider
+	mp.provider, mp.reader = mp.newProvider()
+	mp.mu.Unlock()
+
+	if err := previous.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down previous meter provider: %w", err)
+	}
+	return nil
+}
+
+// Shutdown shuts down the current underlying provider.
+func (mp *MeterProvider) Shutdown(ctx context.Context) error {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	return mp.provider.Shutdown(ctx)
+}
@@ -0,0 +1,52 @@
+package proofwatchtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func counterTotal(t *testing.T, mp *MeterProvider, name string) int64 {
+	t.Helper()
+
+	rm, err := mp.Collect(context.Background())
+	require.NoError(t, err)
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				total += dp.Value
+			}
+		}
+	}
+	return total
+}
+
+func TestMeterProviderReset(t *testing.T) {
+	ctx := context.Background()
+	mp := NewMeterProvider()
+	t.Cleanup(func() { _ = mp.Shutdown(ctx) })
+
+	counter, err := mp.Meter("test").Int64Counter("events")
+	require.NoError(t, err)
+	counter.Add(ctx, 3)
+	assert.Equal(t, int64(3), counterTotal(t, mp, "events"))
+
+	require.NoError(t, mp.Reset(ctx))
+	assert.Equal(t, int64(0), counterTotal(t, mp, "events"))
+
+	// The meter obtained before the reset records into the discarded provider.
+	counter.Add(ctx, 5)
+	assert.Equal(t, int64(0), counterTotal(t, mp, "events"))
+
+	counter, err = mp.Meter("test").Int64Counter("events")
+	require.NoError(t, err)
+	counter.Add(ctx, 1)
+	assert.Equal(t, int64(1), counterTotal(t, mp, "events"))
+}
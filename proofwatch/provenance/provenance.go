@@ -0,0 +1,251 @@
+// Package provenance verifies in-toto attestations wrapped in DSSE envelopes, such as
+// SLSA provenance, so evidence can be tied to the build that produced it.
+package provenance
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// PayloadType is the DSSE payload type of in-toto statements.
+const PayloadType = "application/vnd.in-toto+json"
+
+// StatementType is the in-toto statement type accepted by Verify.
+const StatementType = "https://in-toto.io/Statement/v1"
+
+// ErrVerificationFailed is wrapped by every error returned by Verify for an attestation
+// that could not be verified.
+var ErrVerificationFailed = errors.New("attestation verification failed")
+
+// KeySet holds the public keys trusted to sign attestations, by key ID. Supported keys
+// are ECDSA, Ed25519 and RSA public keys.
+type KeySet map[string]crypto.PublicKey
+
+// AddPEM parses a PEM encoded PKIX public key and adds it under keyID.
+func (k KeySet) AddPEM(keyID string, data []byte) error {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("failed to decode PEM public key %q", keyID)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key %q: %w", keyID, err)
+	}
+	k[keyID] = key
+	return nil
+}
+
+// Subject describes the artifact an attestation was verified for.
+type Subject struct {
+	// Name is the name of the first subject of the statement.
+	Name string
+	// Digest maps digest algorithms to the hex encoded digest of the subject.
+	Digest map[string]string
+	// PredicateType is the type of the statement predicate, such as
+	// https://slsa.dev/provenance/v1.
+	PredicateType string
+}
+
+// envelope is a DSSE envelope.
+type envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []signature `json:"signatures"`
+}
+
+type signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// statement is an in-toto statement.
+type statement struct {
+	Type    string `json:"_type"`
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	PredicateType string `json:"predicateType"`
+}
+
+// Verify verifies the DSSE envelope attestation holding an in-toto statement. At least
+// one signature must verify against keys: a signature naming a key ID is checked against
+// that key only, and one without a key ID against every key. It returns the first
+// subject of the verified statement.
+func Verify(ctx context.Context, attestation []byte, keys KeySet) (Subject, error) {
+	stmt, err := verifyStatement(ctx, attestation, keys)
+	if err != nil {
+		return Subject{}, err
+	}
+	return stmt.subject(0), nil
+}
+
+// VerifyArtifact verifies attestation like Verify, and additionally requires artifact
+// to match the digests of one of the statement subjects, so a valid attestation cannot
+// vouch for content it was not issued for. Every digest of the matching subject with a
+// supported algorithm (sha256, sha384 or sha512) must match, and there must be at least
+// one. It returns the matching subject.
+func VerifyArtifact(ctx context.Context, attestation, artifact []byte, keys KeySet) (Subject, error) {
+	stmt, err := verifyStatement(ctx, attestation, keys)
+	if err != nil {
+		return Subject{}, err
+	}
+	for i := range stmt.Subject {
+		if matchDigest(stmt.Subject[i].Digest, artifact) {
+			return stmt.subject(i), nil
+		}
+	}
+	return Subject{}, fmt.Errorf("%w: artifact does not match any subject digest", ErrVerificationFailed)
+}
+
+// verifyStatement verifies the envelope signatures and returns the decoded statement.
+func verifyStatement(ctx context.Context, attestation []byte, keys KeySet) (statement, error) {
+	if err := ctx.Err(); err != nil {
+		return statement{}, err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(attestation, &env); err != nil {
+		return statement{}, fmt.Errorf("%w: failed to decode envelope: %w", ErrVerificationFailed, err)
+	}
+	if env.PayloadType != PayloadType {
+		return statement{}, fmt.Errorf("%w: unsupported payload type %q", ErrVerificationFailed, env.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return statement{}, fmt.Errorf("%w: failed to decode payload: %w", ErrVerificationFailed, err)
+	}
+	if err := verifySignatures(env, payload, keys); err != nil {
+		return statement{}, err
+	}
+
+	var stmt statement
+	if err := json.Unmarshal(payload, &stmt); err != nil {
+		return statement{}, fmt.Errorf("%w: failed to decode statement: %w", ErrVerificationFailed, err)
+	}
+	if stmt.Type != StatementType {
+		return statement{}, fmt.Errorf("%w: unsupported statement type %q", ErrVerificationFailed, stmt.Type)
+	}
+	if len(stmt.Subject) == 0 {
+		return statement{}, fmt.Errorf("%w: statement has no subject", ErrVerificationFailed)
+	}
+	if stmt.PredicateType == "" {
+		return statement{}, fmt.Errorf("%w: statement has no predicate type", ErrVerificationFailed)
+	}
+	return stmt, nil
+}
+
+// subject returns the i-th subject of the statement.
+func (s statement) subject(i int) Subject {
+	return Subject{
+		Name:          s.Subject[i].Name,
+		Digest:        s.Subject[i].Digest,
+		PredicateType: s.PredicateType,
+	}
+}
+
+// digestAlgorithms are the subject digest algorithms VerifyArtifact checks.
+var digestAlgorithms = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// matchDigest reports whether every supported digest in digests matches artifact, with
+// at least one supported digest present.
+func matchDigest(digests map[string]string, artifact []byte) bool {
+	var matched bool
+	for alg, want := range digests {
+		newHash, ok := digestAlgorithms[strings.ToLower(alg)]
+		if !ok {
+			continue
+		}
+		h := newHash()
+		h.Write(artifact)
+		if hex.EncodeToString(h.Sum(nil)) != strings.ToLower(want) {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+// verifySignatures returns nil when at least one signature of env verifies against keys.
+func verifySignatures(env envelope, payload []byte, keys KeySet) error {
+	if len(env.Signatures) == 0 {
+		return fmt.Errorf("%w: envelope has no signatures", ErrVerificationFailed)
+	}
+	message := PAE(env.PayloadType, payload)
+	for _, s := range env.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if s.KeyID != "" {
+			if key, ok := keys[s.KeyID]; ok && verifySignature(key, message, sig) {
+				return nil
+			}
+			continue
+		}
+		for _, key := range keys {
+			if verifySignature(key, message, sig) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: no signature matches a trusted key", ErrVerificationFailed)
+}
+
+// verifySignature reports whether sig is a valid signature of message by key. ECDSA
+// signatures are ASN.1 encoded over the digest matching the curve size, and RSA
+// signatures are PKCS #1 v1.5 or PSS over the SHA-256 digest.
+func verifySignature(key crypto.PublicKey, message, sig []byte) bool {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, sig)
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, ecdsaDigest(key.Curve, message), sig)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil ||
+			rsa.VerifyPSS(key, crypto.SHA256, digest[:], sig, nil) == nil
+	default:
+		return false
+	}
+}
+
+// ecdsaDigest hashes message with the digest conventionally paired with curve.
+func ecdsaDigest(curve elliptic.Curve, message []byte) []byte {
+	switch curve.Params().BitSize {
+	case 384:
+		digest := sha512.Sum384(message)
+		return digest[:]
+	case 521:
+		digest := sha512.Sum512(message)
+		return digest[:]
+	default:
+		digest := sha256.Sum256(message)
+		return digest[:]
+	}
+}
+
+// PAE returns the DSSE pre-authentication encoding of payload, which is what envelope
+// signatures are computed over.
+func PAE(payloadType string, payload []byte) []byte {
+	return fmt.Appendf(nil, "DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)
+}
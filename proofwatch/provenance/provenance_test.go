@@ -0,0 +1,234 @@
+package provenance
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const slsaPredicateType = "https://slsa.dev/provenance/v1"
+
+func testStatement(t *testing.T) []byte {
+	t.Helper()
+
+	stmt, err := json.Marshal(map[string]any{
+		"_type": StatementType,
+		"subject": []map[string]any{{
+			"name":   "evidence.json",
+			"digest": map[string]string{"sha256": "abc123"},
+		}},
+		"predicateType": slsaPredicateType,
+		"predicate":     map[string]any{"buildDefinition": map[string]any{"buildType": "test"}},
+	})
+	require.NoError(t, err)
+	return stmt
+}
+
+// signEnvelope returns a DSSE envelope of payload signed with sign under keyID.
+func signEnvelope(t *testing.T, payloadType string, payload []byte, keyID string, sign func([]byte) []byte) envelope {
+	t.Helper()
+
+	return envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []signature{{
+			KeyID: keyID,
+			Sig:   base64.StdEncoding.EncodeToString(sign(PAE(payloadType, payload))),
+		}},
+	}
+}
+
+func marshalEnvelope(t *testing.T, env envelope) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(env)
+	require.NoError(t, err)
+	return data
+}
+
+func TestVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys := KeySet{"builder": pub}
+
+	edSign := func(priv ed25519.PrivateKey) func([]byte) []byte {
+		return func(msg []byte) []byte { return ed25519.Sign(priv, msg) }
+	}
+	stmt := testStatement(t)
+
+	t.Run("valid envelope", func(t *testing.T) {
+		env := signEnvelope(t, PayloadType, stmt, "builder", edSign(priv))
+
+		subject, err := Verify(context.Background(), marshalEnvelope(t, env), keys)
+		require.NoError(t, err)
+		assert.Equal(t, Subject{
+			Name:          "evidence.json",
+			Digest:        map[string]string{"sha256": "abc123"},
+			PredicateType: slsaPredicateType,
+		}, subject)
+	})
+
+	t.Run("signature without key ID", func(t *testing.T) {
+		env := signEnvelope(t, PayloadType, stmt, "", edSign(priv))
+
+		_, err := Verify(context.Background(), marshalEnvelope(t, env), keys)
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name string
+		env  func() envelope
+	}{
+		{
+			name: "tampered payload",
+			env: func() envelope {
+				env := signEnvelope(t, PayloadType, stmt, "builder", edSign(priv))
+				var tampered map[string]any
+				require.NoError(t, json.Unmarshal(stmt, &tampered))
+				tampered["predicateType"] = "https://example.com/forged/v1"
+				payload, err := json.Marshal(tampered)
+				require.NoError(t, err)
+				env.Payload = base64.StdEncoding.EncodeToString(payload)
+				return env
+			},
+		},
+		{
+			name: "untrusted key",
+			env: func() envelope {
+				return signEnvelope(t, PayloadType, stmt, "builder", edSign(otherPriv))
+			},
+		},
+		{
+			name: "unknown key ID",
+			env: func() envelope {
+				return signEnvelope(t, PayloadType, stmt, "other", edSign(priv))
+			},
+		},
+		{
+			name: "no signatures",
+			env: func() envelope {
+				env := signEnvelope(t, PayloadType, stmt, "builder", edSign(priv))
+				env.Signatures = nil
+				return env
+			},
+		},
+		{
+			name: "unsupported payload type",
+			env: func() envelope {
+				return signEnvelope(t, "application/json", stmt, "builder", edSign(priv))
+			},
+		},
+		{
+			name: "statement without subject",
+			env: func() envelope {
+				payload := []byte(`{"_type":"` + StatementType + `","subject":[],"predicateType":"` + slsaPredicateType + `"}`)
+				return signEnvelope(t, PayloadType, payload, "builder", edSign(priv))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Verify(context.Background(), marshalEnvelope(t, tt.env()), keys)
+			assert.ErrorIs(t, err, ErrVerificationFailed)
+		})
+	}
+
+	t.Run("malformed envelope", func(t *testing.T) {
+		_, err := Verify(context.Background(), []byte("not json"), keys)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+}
+
+func TestVerifyArtifact(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys := KeySet{"builder": pub}
+
+	artifact := []byte(`{"policy":{"uid":"policy-1"}}`)
+	sum := sha256.Sum256(artifact)
+	attest := func(t *testing.T, digests ...map[string]string) []byte {
+		subjects := make([]map[string]any, 0, len(digests))
+		for _, digest := range digests {
+			subjects = append(subjects, map[string]any{"name": "evidence.json", "digest": digest})
+		}
+		stmt, err := json.Marshal(map[string]any{
+			"_type":         StatementType,
+			"subject":       subjects,
+			"predicateType": slsaPredicateType,
+		})
+		require.NoError(t, err)
+		env := signEnvelope(t, PayloadType, stmt, "builder", func(msg []byte) []byte { return ed25519.Sign(priv, msg) })
+		return marshalEnvelope(t, env)
+	}
+
+	t.Run("matching subject", func(t *testing.T) {
+		digest := map[string]string{"sha256": hex.EncodeToString(sum[:])}
+		subject, err := VerifyArtifact(context.Background(), attest(t, map[string]string{"sha256": "abc123"}, digest), artifact, keys)
+		require.NoError(t, err)
+		assert.Equal(t, digest, subject.Digest)
+	})
+
+	tests := []struct {
+		name     string
+		digest   map[string]string
+		artifact []byte
+	}{
+		{name: "tampered artifact", digest: map[string]string{"sha256": hex.EncodeToString(sum[:])}, artifact: []byte(`{"policy":{"uid":"policy-2"}}`)},
+		{name: "mismatched digest", digest: map[string]string{"sha256": "abc123"}, artifact: artifact},
+		{name: "one of several digests mismatched", digest: map[string]string{"sha256": hex.EncodeToString(sum[:]), "sha512": "abc123"}, artifact: artifact},
+		{name: "no supported digest", digest: map[string]string{"md5": "abc123"}, artifact: artifact},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyArtifact(context.Background(), attest(t, tt.digest), tt.artifact, keys)
+			assert.ErrorIs(t, err, ErrVerificationFailed)
+		})
+	}
+}
+
+func TestVerifyECDSA(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	keys := KeySet{}
+	require.NoError(t, keys.AddPEM("builder", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+
+	env := signEnvelope(t, PayloadType, testStatement(t), "builder", func(msg []byte) []byte {
+		digest := sha256.Sum256(msg)
+		sig, err := priv.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		return sig
+	})
+
+	subject, err := Verify(context.Background(), marshalEnvelope(t, env), keys)
+	require.NoError(t, err)
+	assert.Equal(t, slsaPredicateType, subject.PredicateType)
+}
+
+func TestKeySetAddPEM(t *testing.T) {
+	assert.Error(t, KeySet{}.AddPEM("builder", []byte("not pem")))
+	assert.Error(t, KeySet{}.AddPEM("builder", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("garbage")})))
+}
+
+func TestPAE(t *testing.T) {
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world",
+		string(PAE("http://example.com/HelloWorld", []byte("hello world"))))
+}
@@ -0,0 +1,27 @@
+package proofwatch
+
+import (
+	"errors"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned for evidence shed by the rate limiter set with
+// WithRateLimiter.
+var ErrRateLimited = errors.New("evidence rate limited")
+
+// RateLimiter decides whether evidence may enter the processing pipeline.
+type RateLimiter interface {
+	// Allow reports whether an evidence item may be processed now, consuming a token
+	// if so.
+	Allow() bool
+
+	// Tokens returns the number of tokens currently available.
+	Tokens() float64
+}
+
+// NewRateLimiter returns a token bucket RateLimiter refilled at perSecond tokens per
+// second and holding at most burst tokens. It starts full.
+func NewRateLimiter(perSecond float64, burst int) RateLimiter {
+	return rate.NewLimiter(rate.Limit(perSecond), burst)
+}
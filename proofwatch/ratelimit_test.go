@@ -0,0 +1,94 @@
+package proofwatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func setupRateLimitTest(t *testing.T, limiter RateLimiter) (*Instrumentation, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = meterProvider.Shutdown(context.Background()) })
+
+	inst, err := NewInstrumentation(WithMeterProvider(meterProvider), WithRateLimiter(limiter))
+	require.NoError(t, err)
+	return inst, reader
+}
+
+// rateLimitTokens returns the value reported by the evidence_rate_limit_tokens gauge.
+func rateLimitTokens(t *testing.T, reader *sdkmetric.ManualReader) float64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "evidence_rate_limit_tokens" {
+				data := m.Data.(metricdata.Gauge[float64])
+				require.Len(t, data.DataPoints, 1)
+				return data.DataPoints[0].Value
+			}
+		}
+	}
+	require.Fail(t, "metric not found", "expected metric evidence_rate_limit_tokens to be collected")
+	return 0
+}
+
+func TestRateLimiter(t *testing.T) {
+	// A refill rate this low leaves no room for tokens to come back during the test.
+	inst, reader := setupRateLimitTest(t, NewRateLimiter(0.001, 3))
+	assert.InDelta(t, 3, rateLimitTokens(t, reader), 0.01)
+
+	var processed, limited int
+	for range 10 {
+		err := inst.Process(context.Background(), createTestEvidence(), func(context.Context, Evidence) error {
+			processed++
+			return nil
+		})
+		if err != nil {
+			require.ErrorIs(t, err, ErrRateLimited)
+			limited++
+		}
+	}
+
+	assert.Equal(t, 3, processed)
+	assert.Equal(t, 7, limited)
+	assert.Equal(t, map[string]int64{"rate_limited": 7}, int64Sums(t, reader, "evidence_dropped_count"))
+	assert.InDelta(t, 0, rateLimitTokens(t, reader), 0.01)
+}
+
+// fixedLimiter allows a fixed number of evidence items.
+type fixedLimiter struct {
+	remaining int
+}
+
+func (l *fixedLimiter) Allow() bool {
+	if l.remaining == 0 {
+		return false
+	}
+	l.remaining--
+	return true
+}
+
+func (l *fixedLimiter) Tokens() float64 {
+	return float64(l.remaining)
+}
+
+func TestRateLimiterCustom(t *testing.T) {
+	limiter := &fixedLimiter{remaining: 1}
+	inst, reader := setupRateLimitTest(t, limiter)
+	process := func(context.Context, Evidence) error { return nil }
+
+	require.NoError(t, inst.Process(context.Background(), createTestEvidence(), process))
+	require.ErrorIs(t, inst.Process(context.Background(), createTestEvidence(), process), ErrRateLimited)
+
+	assert.Equal(t, map[string]int64{"rate_limited": 1}, int64Sums(t, reader, "evidence_dropped_count"))
+	assert.Zero(t, rateLimitTokens(t, reader))
+}
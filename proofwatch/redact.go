@@ -0,0 +1,325 @@
+package proofwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RedactedValue replaces values masked by a Redactor.
+const RedactedValue = "[REDACTED]"
+
+// RedactorOption configures a Redactor.
+type RedactorOption func(*Redactor)
+
+// WithRedactedPaths masks the values at the given paths. A path is a dot-separated list
+// of object keys, where "*" matches any key; arrays are traversed, so "resources.uid"
+// masks the uid of every element of resources. Paths also match evidence attribute
+// keys, so "cloud.account.uid" masks that attribute as well as the JSON field.
+func WithRedactedPaths(paths ...string) RedactorOption {
+	return func(r *Redactor) {
+		for _, path := range paths {
+			if path != "" {
+				r.paths = append(r.paths, strings.Split(path, "."))
+			}
+		}
+	}
+}
+
+// WithRedactedPatterns masks the parts of string values, and of string attribute
+// values, matching any of the regular expressions.
+func WithRedactedPatterns(patterns ...string) RedactorOption {
+	return func(r *Redactor) {
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				r.err = errors.Join(r.err, fmt.Errorf("failed to compile redaction pattern: %w", err))
+				continue
+			}
+			r.patterns = append(r.patterns, re)
+		}
+	}
+}
+
+// Redactor masks sensitive values in copies of evidence, so secrets and personal data
+// are not persisted or logged.
+type Redactor struct {
+	paths    [][]string
+	patterns []*regexp.Regexp
+	err      error
+}
+
+// NewRedactor creates a Redactor masking the configured paths and patterns.
+func NewRedactor(opts ...RedactorOption) (*Redactor, error) {
+	r := &Redactor{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r, nil
+}
+
+// Redact returns a copy of evidence whose JSON and attributes have the configured
+// values masked. The evidence itself is left untouched.
+func (r *Redactor) Redact(evidence Evidence) (Evidence, error) {
+	data, err := evidence.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal evidence: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode evidence: %w", err)
+	}
+	for _, path := range r.paths {
+		doc = redactPath(doc, path)
+	}
+	doc = r.redactPatterns(doc)
+	redacted, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal redacted evidence: %w", err)
+	}
+
+	return redactedEvidence{
+		data:      redacted,
+		attrs:     r.redactAttributes(evidence.Attributes()),
+		timestamp: evidence.Timestamp(),
+	}, nil
+}
+
+// redact returns the copy of evidence redacted by redactor, or evidence itself when
+// redactor is nil.
+func redact(redactor *Redactor, evidence Evidence) (Evidence, error) {
+	if redactor == nil {
+		return evidence, nil
+	}
+	return redactor.Redact(evidence)
+}
+
+// RedactError returns err with its message masked: the values evidence holds at the
+// configured paths, and matches of the configured patterns, are replaced. The returned
+// error still matches err with errors.Is and errors.As, and is err itself when nothing
+// was masked.
+func (r *Redactor) RedactError(evidence Evidence, err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	values := r.pathValues(evidence)
+	// Mask longer values first, so a value contained in another is not left behind.
+	slices.SortFunc(values, func(a, b string) int { return len(b) - len(a) })
+	for _, value := range values {
+		msg = strings.ReplaceAll(msg, value, RedactedValue)
+	}
+	msg = r.redactString(msg)
+	if msg == err.Error() {
+		return err
+	}
+	return &redactedError{msg: msg, err: err}
+}
+
+// redactError returns err redacted by redactor, or err itself when redactor is nil.
+func redactError(redactor *Redactor, evidence Evidence, err error) error {
+	if redactor == nil {
+		return err
+	}
+	return redactor.RedactError(evidence, err)
+}
+
+// redactAttributes returns attrs redacted by redactor, or attrs itself when redactor is
+// nil.
+func redactAttributes(redactor *Redactor, attrs []attribute.KeyValue) []attribute.KeyValue {
+	if redactor == nil {
+		return attrs
+	}
+	return redactor.redactAttributes(attrs)
+}
+
+// redactedError is an error whose message was masked by a Redactor, optionally wrapping
+// the original error.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// pathValues returns the non-empty scalar values evidence holds at the configured paths,
+// in its JSON and its attributes, as text.
+func (r *Redactor) pathValues(evidence Evidence) []string {
+	var values []string
+	if data, err := evidence.ToJSON(); err == nil {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var doc any
+		if dec.Decode(&doc) == nil {
+			for _, path := range r.paths {
+				values = collectPath(doc, path, values)
+			}
+		}
+	}
+	for _, kv := range evidence.Attributes() {
+		if r.matchesKey(string(kv.Key)) && kv.Value.Emit() != "" {
+			values = append(values, kv.Value.Emit())
+		}
+	}
+	return values
+}
+
+// collectPath appends the scalar values at path within v to values, as text.
+func collectPath(v any, path []string, values []string) []string {
+	if len(path) == 0 {
+		return collectScalars(v, values)
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if path[0] == "*" || path[0] == key {
+				values = collectPath(child, path[1:], values)
+			}
+		}
+	case []any:
+		for _, child := range v {
+			values = collectPath(child, path, values)
+		}
+	}
+	return values
+}
+
+// collectScalars appends every non-empty scalar value within v to values, as text.
+func collectScalars(v any, values []string) []string {
+	switch v := v.(type) {
+	case string:
+		if v != "" {
+			values = append(values, v)
+		}
+	case json.Number:
+		values = append(values, v.String())
+	case map[string]any:
+		for _, child := range v {
+			values = collectScalars(child, values)
+		}
+	case []any:
+		for _, child := range v {
+			values = collectScalars(child, values)
+		}
+	}
+	return values
+}
+
+// redactPath masks the values at path within v, returning the updated value.
+func redactPath(v any, path []string) any {
+	if len(path) == 0 {
+		return RedactedValue
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if path[0] == "*" || path[0] == key {
+				v[key] = redactPath(child, path[1:])
+			}
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactPath(child, path)
+		}
+	}
+	return v
+}
+
+// redactPatterns masks the parts of string values within v matching the patterns,
+// returning the updated value.
+func (r *Redactor) redactPatterns(v any) any {
+	switch v := v.(type) {
+	case string:
+		return r.redactString(v)
+	case map[string]any:
+		for key, child := range v {
+			v[key] = r.redactPatterns(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = r.redactPatterns(child)
+		}
+	}
+	return v
+}
+
+func (r *Redactor) redactString(s string) string {
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, RedactedValue)
+	}
+	return s
+}
+
+// redactAttributes returns a copy of attrs with attributes matching a path masked and
+// pattern matches in string values masked.
+func (r *Redactor) redactAttributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, len(attrs))
+	for i, kv := range attrs {
+		switch {
+		case r.matchesKey(string(kv.Key)):
+			kv = kv.Key.String(RedactedValue)
+		case kv.Value.Type() == attribute.STRING:
+			kv = kv.Key.String(r.redactString(kv.Value.AsString()))
+		}
+		out[i] = kv
+	}
+	return out
+}
+
+// matchesKey reports whether the dotted attribute key matches a configured path.
+func (r *Redactor) matchesKey(key string) bool {
+	segments := strings.Split(key, ".")
+	for _, path := range r.paths {
+		if len(path) != len(segments) {
+			continue
+		}
+		matched := true
+		for i, segment := range segments {
+			if path[i] != "*" && path[i] != segment {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// redactedEvidence is a redacted copy of evidence.
+type redactedEvidence struct {
+	data      []byte
+	attrs     []attribute.KeyValue
+	timestamp time.Time
+}
+
+func (r redactedEvidence) ToJSON() ([]byte, error) {
+	return r.data, nil
+}
+
+func (r redactedEvidence) Attributes() []attribute.KeyValue {
+	return r.attrs
+}
+
+func (r redactedEvidence) Timestamp() time.Time {
+	return r.timestamp
+}
@@ -0,0 +1,35 @@
+package proofwatch
+
+import (
+	"context"
+	"fmt"
+)
+
+// Resetter is implemented by MeterProviders able to discard the metrics recorded so far,
+// such as proofwatchtest.MeterProvider.
+type Resetter interface {
+	Reset(ctx context.Context) error
+}
+
+// Reset clears the metrics recorded by the Instrumentation, so that the next collection
+// only yields what is recorded afterwards. It is intended for tests sharing an
+// Instrumentation across cases and must not be called concurrently with processing.
+//
+// Reset only has an effect when the configured MeterProvider implements Resetter: the
+// provider is reset and the instruments are created again from it. With other
+// MeterProviders Reset is a no-op.
+func (i *Instrumentation) Reset(ctx context.Context) error {
+	resetter, ok := i.cfg.MeterProvider.(Resetter)
+	if !ok {
+		return nil
+	}
+	if err := resetter.Reset(ctx); err != nil {
+		return fmt.Errorf("failed to reset meter provider: %w", err)
+	}
+	observer, err := newObserver(i.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to recreate evidence observer: %w", err)
+	}
+	i.observer = observer
+	return nil
+}
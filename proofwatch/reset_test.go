@@ -0,0 +1,104 @@
+package proofwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/complytime/complybeacon/proofwatch/proofwatchtest"
+)
+
+func TestInstrumentationReset(t *testing.T) {
+	ctx := context.Background()
+	process := func(context.Context, Evidence) error { return nil }
+
+	t.Run("resettable provider only yields post-reset data", func(t *testing.T) {
+		mp := proofwatchtest.NewMeterProvider()
+		t.Cleanup(func() { _ = mp.Shutdown(ctx) })
+		inst, err := NewInstrumentation(WithMeterProvider(mp))
+		require.NoError(t, err)
+
+		require.NoError(t, inst.Process(ctx, createTestEvidence(), process))
+		require.NoError(t, inst.Process(ctx, createTestEvidence(), process))
+		require.NoError(t, inst.Reset(ctx))
+		require.NoError(t, inst.Process(ctx, createTestEvidence(), process))
+
+		rm, err := mp.Collect(ctx)
+		require.NoError(t, err)
+		var processed int64
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != "evidence_processed_count" {
+					continue
+				}
+				for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+					processed += dp.Value
+				}
+			}
+		}
+		assert.Equal(t, int64(1), processed)
+	})
+
+	t.Run("pool workers record on the new observer", func(t *testing.T) {
+		mp := proofwatchtest.NewMeterProvider()
+		t.Cleanup(func() { _ = mp.Shutdown(ctx) })
+		inst, err := NewInstrumentation(WithMeterProvider(mp))
+		require.NoError(t, err)
+		pool := NewPool(inst, process, WithWorkers(1))
+		// collect returns the queue depth and the number of queue waits recorded, and
+		// whether the worker has finished all evidence.
+		collect := func() (depth int64, waits uint64, idle bool) {
+			rm, err := mp.Collect(ctx)
+			require.NoError(t, err)
+			var processed, active int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					switch m.Name {
+					case "evidence_queue_depth", "evidence_active_workers", "evidence_processed_count":
+						for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+							switch m.Name {
+							case "evidence_queue_depth":
+								depth += dp.Value
+							case "evidence_active_workers":
+								active += dp.Value
+							default:
+								processed += dp.Value
+							}
+						}
+					case "evidence_queue_wait_seconds":
+						for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+							waits += dp.Count
+						}
+					}
+				}
+			}
+			return depth, waits, processed == 1 && active == 0
+		}
+
+		require.NoError(t, pool.Submit(ctx, createTestEvidence()))
+		// Reset must not run concurrently with processing, so wait for the worker.
+		require.Eventually(t, func() bool { _, _, idle := collect(); return idle }, time.Second, time.Millisecond)
+		require.NoError(t, inst.Reset(ctx))
+		require.NoError(t, pool.Submit(ctx, createTestEvidence()))
+		pool.Close()
+
+		depth, waits, idle := collect()
+		assert.True(t, idle)
+		assert.Equal(t, int64(0), depth)
+		assert.Equal(t, uint64(1), waits)
+	})
+
+	t.Run("no-op for other providers", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+
+		require.NoError(t, inst.Process(ctx, createTestEvidence(), process))
+		require.NoError(t, inst.Reset(ctx))
+		require.NoError(t, inst.Process(ctx, createTestEvidence(), process))
+
+		assert.Equal(t, map[string]int64{"": 2}, int64Sums(t, reader, "evidence_processed_count"))
+	})
+}
@@ -0,0 +1,44 @@
+package proofwatch
+
+import (
+	"context"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// ResourceConfig describes the service emitting evidence metrics.
+type ResourceConfig struct {
+	// ServiceName defaults to "proofwatch".
+	ServiceName string
+	// ServiceVersion defaults to the proofwatch Version.
+	ServiceVersion string
+	// Environment is omitted when empty.
+	Environment string
+}
+
+// NewResource builds a Resource carrying service.name, service.version and, when set,
+// deployment.environment, on top of the SDK defaults and any OTEL_RESOURCE_ATTRIBUTES
+// and OTEL_SERVICE_NAME settings. Non-empty config values take precedence over the
+// environment.
+func NewResource(ctx context.Context, cfg ResourceConfig) (*resource.Resource, error) {
+	return metrics.NewResource(ctx, cfg.internal())
+}
+
+// NewMeterProvider creates a MeterProvider, to pass to WithMeterProvider, whose metrics
+// carry the Resource built by NewResource from cfg. Readers and other settings are
+// passed through opts.
+func NewMeterProvider(ctx context.Context, cfg ResourceConfig, opts ...sdkmetric.Option) (*sdkmetric.MeterProvider, error) {
+	return metrics.NewMeterProvider(ctx, cfg.internal(), opts...)
+}
+
+func (c ResourceConfig) internal() metrics.ResourceConfig {
+	return metrics.ResourceConfig{
+		ServiceName:           c.ServiceName,
+		ServiceVersion:        c.ServiceVersion,
+		DefaultServiceVersion: Version(),
+		Environment:           c.Environment,
+	}
+}
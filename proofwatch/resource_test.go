@@ -0,0 +1,66 @@
+package proofwatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewMeterProvider(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+
+	collectResource := func(t *testing.T, cfg ResourceConfig) *attribute.Set {
+		t.Helper()
+		ctx := context.Background()
+
+		reader := sdkmetric.NewManualReader()
+		provider, err := NewMeterProvider(ctx, cfg, sdkmetric.WithReader(reader))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+		inst, err := NewInstrumentation(WithMeterProvider(provider))
+		require.NoError(t, err)
+		require.NoError(t, inst.Process(ctx, createTestEvidence(), func(context.Context, Evidence) error { return nil }))
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		return rm.Resource.Set()
+	}
+
+	t.Run("defaults to the proofwatch version", func(t *testing.T) {
+		set := collectResource(t, ResourceConfig{})
+
+		name, _ := set.Value("service.name")
+		assert.Equal(t, "proofwatch", name.AsString())
+		version, _ := set.Value("service.version")
+		assert.Equal(t, Version(), version.AsString())
+	})
+
+	t.Run("uses config values", func(t *testing.T) {
+		set := collectResource(t, ResourceConfig{ServiceName: "beacon", ServiceVersion: "v1.2.3", Environment: "prod"})
+
+		for key, want := range map[attribute.Key]string{
+			"service.name":           "beacon",
+			"service.version":        "v1.2.3",
+			"deployment.environment": "prod",
+		} {
+			got, _ := set.Value(key)
+			assert.Equal(t, want, got.AsString(), key)
+		}
+	})
+}
+
+func TestNewResource(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.version=v2.0.0")
+
+	res, err := NewResource(context.Background(), ResourceConfig{})
+	require.NoError(t, err)
+	version, _ := res.Set().Value("service.version")
+	assert.Equal(t, "v2.0.0", version.AsString(), "the environment overrides the default version")
+}
@@ -0,0 +1,141 @@
+package proofwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Retry defaults used unless overridden by a RetryOption.
+const (
+	defaultRetryAttempts   = 3
+	defaultRetryBackoff    = 100 * time.Millisecond
+	defaultRetryMaxBackoff = 10 * time.Second
+)
+
+type retryConfig struct {
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	retryable   func(error) bool
+}
+
+// RetryOption configures Instrumentation.Retry.
+type RetryOption func(*retryConfig)
+
+// WithMaxAttempts sets the number of attempts, including the first. Values below one
+// are ignored. If none is specified, three attempts are made.
+func WithMaxAttempts(n int) RetryOption {
+	return func(cfg *retryConfig) {
+		if n >= 1 {
+			cfg.maxAttempts = n
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the cap on later delays, which
+// double with each retry. Non-positive values keep the defaults of 100ms and 10s.
+func WithBackoff(initial, maxDelay time.Duration) RetryOption {
+	return func(cfg *retryConfig) {
+		if initial > 0 {
+			cfg.backoff = initial
+		}
+		if maxDelay > 0 {
+			cfg.maxBackoff = maxDelay
+		}
+	}
+}
+
+// WithRetryable sets the function classifying errors as retryable. Errors it rejects
+// are terminal and returned without further attempts.
+// If none is specified, IsRetryable is used.
+func WithRetryable(retryable func(error) bool) RetryOption {
+	return func(cfg *retryConfig) {
+		if retryable != nil {
+			cfg.retryable = retryable
+		}
+	}
+}
+
+// retryableError marks an error as transient.
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string { return e.err.Error() }
+func (e retryableError) Unwrap() error { return e.err }
+
+// Retryable marks err as transient so IsRetryable reports true for it and for errors
+// wrapping it. A nil err returns nil.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return retryableError{err: err}
+}
+
+// IsRetryable reports whether err is transient: marked with Retryable, a deadline
+// exceeded, or a network timeout.
+func IsRetryable(err error) bool {
+	return errors.As(err, &retryableError{}) || isTimeout(err)
+}
+
+// RetryError is returned by a ProcessFunc wrapped with Instrumentation.Retry when its
+// last attempt failed, either because the error was terminal or attempts ran out.
+type RetryError struct {
+	// Attempts is the number of attempts made.
+	Attempts int
+	// Err is the error returned by the last attempt.
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("processing failed after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Retry wraps fn so retryable errors are retried with exponential backoff and jitter,
+// recording each delay in retry_backoff_seconds. When fn finally fails, the returned
+// *RetryError carries the attempt count, which Process records on the dropped evidence
+// alongside the reason for the last error. A context done while waiting stops retries.
+func (i *Instrumentation) Retry(fn ProcessFunc, opts ...RetryOption) ProcessFunc {
+	cfg := retryConfig{
+		maxAttempts: defaultRetryAttempts,
+		backoff:     defaultRetryBackoff,
+		maxBackoff:  defaultRetryMaxBackoff,
+		retryable:   IsRetryable,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context, evidence Evidence) error {
+		backoff := cfg.backoff
+		for attempt := 1; ; attempt++ {
+			err := fn(ctx, evidence)
+			if err == nil {
+				return nil
+			}
+			if attempt >= cfg.maxAttempts || !cfg.retryable(err) {
+				return &RetryError{Attempts: attempt, Err: err}
+			}
+
+			// Wait between half and all of the backoff so concurrent retries spread out.
+			delay := backoff/2 + rand.N(backoff/2+1)
+			i.observer.RecordRetryBackoff(ctx, attempt, delay)
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return &RetryError{Attempts: attempt, Err: errors.Join(err, ctx.Err())}
+			}
+			backoff = min(backoff*2, cfg.maxBackoff)
+		}
+	}
+}
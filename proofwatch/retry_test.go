@@ -0,0 +1,129 @@
+package proofwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// failingProcess returns a ProcessFunc failing with errs in turn and then succeeding,
+// along with a pointer to the number of calls made.
+func failingProcess(errs ...error) (ProcessFunc, *int) {
+	var calls int
+	return func(context.Context, Evidence) error {
+		calls++
+		if calls <= len(errs) {
+			return errs[calls-1]
+		}
+		return nil
+	}, &calls
+}
+
+// droppedAttempts returns the dropped evidence count by reason and retry attempt.
+func droppedAttempts(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	out := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "evidence_dropped_count" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				reason, _ := dp.Attributes.Value("reason")
+				attempt, _ := dp.Attributes.Value("retry.attempt")
+				out[fmt.Sprintf("%s/%d", reason.AsString(), attempt.AsInt64())] += dp.Value
+			}
+		}
+	}
+	return out
+}
+
+func TestInstrumentationRetry(t *testing.T) {
+	fastBackoff := WithBackoff(time.Millisecond, 2*time.Millisecond)
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		fn, calls := failingProcess(Retryable(assert.AnError), context.DeadlineExceeded)
+
+		err := inst.Process(context.Background(), createTestEvidence(), inst.Retry(fn, fastBackoff))
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, *calls)
+		assert.Equal(t, int64(1), int64Sums(t, reader, "evidence_processed_count")[""])
+		assert.False(t, metricNames(t, reader)["evidence_dropped_count"])
+		assert.True(t, metricNames(t, reader)["retry_backoff_seconds"])
+	})
+
+	t.Run("drops with the final reason when attempts run out", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		fn, calls := failingProcess(Retryable(assert.AnError), context.DeadlineExceeded, context.DeadlineExceeded)
+
+		err := inst.Process(context.Background(), createTestEvidence(), inst.Retry(fn, fastBackoff, WithMaxAttempts(3)))
+
+		var retryErr *RetryError
+		require.ErrorAs(t, err, &retryErr)
+		assert.Equal(t, 3, retryErr.Attempts)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 3, *calls)
+		assert.Equal(t, map[string]int64{"timeout/3": 1}, droppedAttempts(t, reader))
+		assert.False(t, metricNames(t, reader)["evidence_processed_count"])
+	})
+
+	t.Run("does not retry terminal errors", func(t *testing.T) {
+		inst, _, reader := setupInstrumentationTest(t)
+		fn, calls := failingProcess(assert.AnError)
+
+		err := inst.Process(context.Background(), createTestEvidence(), inst.Retry(fn, fastBackoff))
+		require.ErrorIs(t, err, assert.AnError)
+
+		assert.Equal(t, 1, *calls)
+		assert.Equal(t, map[string]int64{"processing_error/1": 1}, droppedAttempts(t, reader))
+		assert.False(t, metricNames(t, reader)["retry_backoff_seconds"])
+	})
+
+	t.Run("uses the configured classifier", func(t *testing.T) {
+		inst, _, _ := setupInstrumentationTest(t)
+		fn, calls := failingProcess(assert.AnError, assert.AnError)
+
+		retryAll := WithRetryable(func(error) bool { return true })
+		err := inst.Process(context.Background(), createTestEvidence(), inst.Retry(fn, fastBackoff, retryAll))
+		require.NoError(t, err)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		inst, _, _ := setupInstrumentationTest(t)
+		fn, calls := failingProcess(Retryable(assert.AnError), Retryable(assert.AnError))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := inst.Process(ctx, createTestEvidence(), inst.Retry(fn, WithBackoff(time.Minute, time.Minute)))
+
+		var retryErr *RetryError
+		require.ErrorAs(t, err, &retryErr)
+		assert.Equal(t, 1, retryErr.Attempts)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, *calls)
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(Retryable(assert.AnError)))
+	assert.True(t, IsRetryable(fmt.Errorf("wrapped: %w", Retryable(assert.AnError))))
+	assert.True(t, IsRetryable(context.DeadlineExceeded))
+	assert.False(t, IsRetryable(assert.AnError))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.Nil(t, Retryable(nil))
+	assert.True(t, errors.Is(Retryable(assert.AnError), assert.AnError))
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"errors"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/complytime/complybeacon/proofwatch"
+	"github.com/complytime/complybeacon/proofwatch/server/ingestpb"
+)
+
+// IngestServer implements the EvidenceIngest gRPC service, evaluating streamed evidence
+// through proofwatch Instrumentation as a Handler does.
+type IngestServer struct {
+	ingestpb.UnimplementedEvidenceIngestServer
+	ingester
+}
+
+var _ ingestpb.EvidenceIngestServer = (*IngestServer)(nil)
+
+// NewIngestServer creates an IngestServer evaluating evidence with evaluator. Register
+// it with ingestpb.RegisterEvidenceIngestServer.
+func NewIngestServer(inst *proofwatch.Instrumentation, evaluator proofwatch.PolicyEvaluator, opts ...Option) *IngestServer {
+	cfg := newConfig(opts)
+	return &IngestServer{
+		ingester: newIngester(inst, evaluator, cfg),
+	}
+}
+
+// Submit evaluates each evidence item on the stream in order. Items failing decoding,
+// validation or evaluation are dropped without ending the stream; the summary sent once
+// the client closes the stream counts the processed and dropped items. Items handed to
+// the pool set with WithBackpressure count as processed. When the pool or the rate
+// limiter turns an item away, the stream ends with RESOURCE_EXHAUSTED so the client
+// backs off.
+func (s *IngestServer) Submit(stream ingestpb.EvidenceIngest_SubmitServer) error {
+	var summary ingestpb.SubmitSummary
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&summary)
+		}
+		if err != nil {
+			return err
+		}
+		if _, _, err := s.ingest(stream.Context(), req.GetEvidence()); err != nil {
+			if isBackpressure(err) {
+				return status.Errorf(codes.ResourceExhausted, "%v after %d processed and %d dropped items", err, summary.Processed, summary.Dropped+1)
+			}
+			summary.Dropped++
+			continue
+		}
+		summary.Processed++
+	}
+}
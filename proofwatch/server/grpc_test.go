@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/complytime/complybeacon/proofwatch"
+	"github.com/complytime/complybeacon/proofwatch/server/ingestpb"
+)
+
+func setupIngestTest(t *testing.T, evaluator proofwatch.PolicyEvaluator) (ingestpb.EvidenceIngestClient, func() map[string]map[string]int64) {
+	t.Helper()
+
+	inst, reader := setupInstrumentation(t)
+	return serveIngest(t, NewIngestServer(inst, evaluator)), func() map[string]map[string]int64 { return counts(t, reader) }
+}
+
+// serveIngest serves server over an in-memory listener and returns a client for it.
+func serveIngest(t *testing.T, server *IngestServer) ingestpb.EvidenceIngestClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	ingestpb.RegisterEvidenceIngestServer(srv, server)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return ingestpb.NewEvidenceIngestClient(conn)
+}
+
+func TestIngestServerSubmit(t *testing.T) {
+	evaluator := evaluatorFunc(func(_ context.Context, evidence proofwatch.Evidence) (proofwatch.EvaluationResult, error) {
+		if *evidence.(proofwatch.OCSFEvidence).Policy.Uid == "broken" {
+			return proofwatch.EvaluationResult{}, assert.AnError
+		}
+		return proofwatch.EvaluationResult{PolicyID: "policy-1", Status: proofwatch.EvaluationStatusPass}, nil
+	})
+	client, collect := setupIngestTest(t, evaluator)
+
+	stream, err := client.Submit(context.Background())
+	require.NoError(t, err)
+	for _, evidence := range []string{
+		validEvidence,
+		validEvidence,
+		validEvidence,
+		`{"time": 1700000000000, "policy": {"name": "policy-1"}}`,
+		`{"time":`,
+		`{"time": 1700000000000, "policy": {"uid": "broken"}}`,
+	} {
+		require.NoError(t, stream.Send(&ingestpb.EvidenceRequest{Evidence: []byte(evidence)}))
+	}
+	summary, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(3), summary.GetProcessed())
+	assert.Equal(t, uint64(3), summary.GetDropped())
+	assert.Equal(t, map[string]map[string]int64{
+		"evidence_processed_count": {"": 3},
+		"evidence_dropped_count":   {"validation_failed": 2, "processing_error": 1},
+	}, collect())
+}
+
+func TestIngestServerSubmitEmptyStream(t *testing.T) {
+	client, collect := setupIngestTest(t, nil)
+
+	stream, err := client.Submit(context.Background())
+	require.NoError(t, err)
+	summary, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	assert.Zero(t, summary.GetProcessed())
+	assert.Zero(t, summary.GetDropped())
+	assert.Empty(t, collect())
+}
+
+func TestIngestServerBackpressure(t *testing.T) {
+	inst, reader := setupInstrumentation(t)
+	pool, _ := setupBlockedPool(t, inst)
+	client := serveIngest(t, NewIngestServer(inst, nil, WithBackpressure(pool, 0, time.Second)))
+
+	stream, err := client.Submit(context.Background())
+	require.NoError(t, err)
+	// The worker blocks on the first item, so the queue fills by the third at the latest.
+	for range 3 {
+		_ = stream.Send(&ingestpb.EvidenceRequest{Evidence: []byte(validEvidence)})
+	}
+	_, err = stream.CloseAndRecv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, map[string]int64{"backpressure": 1}, counts(t, reader)["evidence_dropped_count"])
+}
+
+func TestIngestServerRateLimited(t *testing.T) {
+	inst, reader := setupInstrumentation(t, proofwatch.WithRateLimiter(proofwatch.NewRateLimiter(0, 1)))
+	client := serveIngest(t, NewIngestServer(inst, evaluatorFunc(func(context.Context, proofwatch.Evidence) (proofwatch.EvaluationResult, error) {
+		return proofwatch.EvaluationResult{Status: proofwatch.EvaluationStatusPass}, nil
+	})))
+
+	stream, err := client.Submit(context.Background())
+	require.NoError(t, err)
+	for range 2 {
+		_ = stream.Send(&ingestpb.EvidenceRequest{Evidence: []byte(validEvidence)})
+	}
+	_, err = stream.CloseAndRecv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, map[string]int64{"rate_limited": 1}, counts(t, reader)["evidence_dropped_count"])
+}
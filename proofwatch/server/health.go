@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"sync"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// Health serves liveness and readiness probes. Readiness reflects the outcome of the
+// latest metric export and of any registered dependency checks.
+type Health struct {
+	mu        sync.RWMutex
+	exportErr error
+	checks    map[string]func(context.Context) error
+}
+
+// NewHealth creates a Health that is ready until an export fails or a check does.
+func NewHealth() *Health {
+	return &Health{checks: make(map[string]func(context.Context) error)}
+}
+
+// ObserveExport records the outcome of a metric export: nil when it succeeded, and the
+// export error otherwise. Pass it as the export callback of the metric exporter, or
+// use WrapExporter.
+func (h *Health) ObserveExport(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.exportErr = err
+}
+
+// WrapExporter returns an exporter delegating to exporter whose exports are observed
+// with ObserveExport.
+func (h *Health) WrapExporter(exporter sdkmetric.Exporter) sdkmetric.Exporter {
+	return metrics.WrapExporter(exporter, h.ObserveExport)
+}
+
+// AddCheck registers a downstream dependency check run on each readiness probe. A check
+// returning an error makes the probe fail. Adding a check under an existing name
+// replaces it.
+func (h *Health) AddCheck(name string, check func(ctx context.Context) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// healthResponse is the response body of the health probes.
+type healthResponse struct {
+	Status string            `json:"status"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Live returns a handler reporting that the process is up. It always responds
+// 200 OK.
+func (h *Health) Live() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+	})
+}
+
+// Ready returns a handler reporting whether the process can serve traffic. It responds
+// 503 Service Unavailable, listing what failed, while the latest metric export failed
+// or any check fails, and 200 OK otherwise.
+func (h *Health) Ready() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errs := h.failures(r.Context())
+		if len(errs) > 0 {
+			writeJSON(w, http.StatusServiceUnavailable, healthResponse{Status: "unavailable", Errors: errs})
+			return
+		}
+		writeJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+	})
+}
+
+// failures returns the error of the latest export, under "export", and of each failing
+// check, under its name.
+func (h *Health) failures(ctx context.Context) map[string]string {
+	h.mu.RLock()
+	exportErr := h.exportErr
+	checks := maps.Clone(h.checks)
+	h.mu.RUnlock()
+
+	errs := map[string]string{}
+	if exportErr != nil {
+		errs["export"] = exportErr.Error()
+	}
+	for name, check := range checks {
+		if err := check(ctx); err != nil {
+			errs[name] = err.Error()
+		}
+	}
+	return errs
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// flakyExporter is an exporter whose exports fail with err.
+type flakyExporter struct {
+	sdkmetric.Exporter
+	err error
+}
+
+func (f *flakyExporter) Export(context.Context, *metricdata.ResourceMetrics) error {
+	return f.err
+}
+
+func probe(t *testing.T, handler http.Handler) (int, healthResponse) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var resp healthResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return rec.Code, resp
+}
+
+func TestHealthReadyTracksExports(t *testing.T) {
+	ctx := context.Background()
+	health := NewHealth()
+	exporter := &flakyExporter{}
+	wrapped := health.WrapExporter(exporter)
+
+	code, _ := probe(t, health.Ready())
+	assert.Equal(t, http.StatusOK, code)
+
+	exporter.err = errors.New("connection refused")
+	require.Error(t, wrapped.Export(ctx, &metricdata.ResourceMetrics{}))
+	code, resp := probe(t, health.Ready())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, healthResponse{Status: "unavailable", Errors: map[string]string{"export": "connection refused"}}, resp)
+
+	// Liveness does not depend on exports.
+	code, _ = probe(t, health.Live())
+	assert.Equal(t, http.StatusOK, code)
+
+	exporter.err = nil
+	require.NoError(t, wrapped.Export(ctx, &metricdata.ResourceMetrics{}))
+	code, resp = probe(t, health.Ready())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, healthResponse{Status: "ok"}, resp)
+}
+
+func TestHealthReadyRunsChecks(t *testing.T) {
+	health := NewHealth()
+	var storeErr error
+	health.AddCheck("store", func(context.Context) error { return storeErr })
+
+	code, _ := probe(t, health.Ready())
+	assert.Equal(t, http.StatusOK, code)
+
+	storeErr = errors.New("database is locked")
+	code, resp := probe(t, health.Ready())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, map[string]string{"store": "database is locked"}, resp.Errors)
+}
@@ -0,0 +1,122 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: ingest.proto
+
+package ingestpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EvidenceIngest_Submit_FullMethodName = "/proofwatch.ingest.v1.EvidenceIngest/Submit"
+)
+
+// EvidenceIngestClient is the client API for EvidenceIngest service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EvidenceIngest accepts evidence from producers streaming it to proofwatch.
+type EvidenceIngestClient interface {
+	// Submit processes each evidence item streamed by the client and, once the client
+	// closes the stream, returns how many items were processed and dropped.
+	Submit(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[EvidenceRequest, SubmitSummary], error)
+}
+
+type evidenceIngestClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEvidenceIngestClient(cc grpc.ClientConnInterface) EvidenceIngestClient {
+	return &evidenceIngestClient{cc}
+}
+
+func (c *evidenceIngestClient) Submit(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[EvidenceRequest, SubmitSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EvidenceIngest_ServiceDesc.Streams[0], EvidenceIngest_Submit_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EvidenceRequest, SubmitSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EvidenceIngest_SubmitClient = grpc.ClientStreamingClient[EvidenceRequest, SubmitSummary]
+
+// EvidenceIngestServer is the server API for EvidenceIngest service.
+// All implementations must embed UnimplementedEvidenceIngestServer
+// for forward compatibility.
+//
+// EvidenceIngest accepts evidence from producers streaming it to proofwatch.
+type EvidenceIngestServer interface {
+	// Submit processes each evidence item streamed by the client and, once the client
+	// closes the stream, returns how many items were processed and dropped.
+	Submit(grpc.ClientStreamingServer[EvidenceRequest, SubmitSummary]) error
+	mustEmbedUnimplementedEvidenceIngestServer()
+}
+
+// UnimplementedEvidenceIngestServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEvidenceIngestServer struct{}
+
+func (UnimplementedEvidenceIngestServer) Submit(grpc.ClientStreamingServer[EvidenceRequest, SubmitSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method Submit not implemented")
+}
+func (UnimplementedEvidenceIngestServer) mustEmbedUnimplementedEvidenceIngestServer() {}
+func (UnimplementedEvidenceIngestServer) testEmbeddedByValue()                        {}
+
+// UnsafeEvidenceIngestServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EvidenceIngestServer will
+// result in compilation errors.
+type UnsafeEvidenceIngestServer interface {
+	mustEmbedUnimplementedEvidenceIngestServer()
+}
+
+func RegisterEvidenceIngestServer(s grpc.ServiceRegistrar, srv EvidenceIngestServer) {
+	// If the following call pancis, it indicates UnimplementedEvidenceIngestServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EvidenceIngest_ServiceDesc, srv)
+}
+
+func _EvidenceIngest_Submit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EvidenceIngestServer).Submit(&grpc.GenericServerStream[EvidenceRequest, SubmitSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EvidenceIngest_SubmitServer = grpc.ClientStreamingServer[EvidenceRequest, SubmitSummary]
+
+// EvidenceIngest_ServiceDesc is the grpc.ServiceDesc for EvidenceIngest service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EvidenceIngest_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proofwatch.ingest.v1.EvidenceIngest",
+	HandlerType: (*EvidenceIngestServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Submit",
+			Handler:       _EvidenceIngest_Submit_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "ingest.proto",
+}
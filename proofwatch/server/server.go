@@ -0,0 +1,270 @@
+// Package server exposes proofwatch evidence ingestion over HTTP and gRPC.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch"
+	"github.com/complytime/complybeacon/proofwatch/provenance"
+)
+
+// DefaultMaxBodyBytes is the largest request body accepted unless WithMaxBodyBytes is set.
+const DefaultMaxBodyBytes = 1 << 20
+
+// DefaultRetryAfter is the delay suggested to producers rejected under backpressure or
+// by the rate limiter unless WithBackpressure sets one.
+const DefaultRetryAfter = time.Second
+
+// DecodeFunc decodes a request body into evidence.
+type DecodeFunc func(body []byte) (proofwatch.Evidence, error)
+
+type config struct {
+	decode       DecodeFunc
+	maxBodyBytes int64
+	pool         *proofwatch.Pool
+	threshold    float64
+	retryAfter   time.Duration
+}
+
+// Option configures a Handler or IngestServer.
+type Option func(*config)
+
+// WithDecoder sets how request bodies are decoded into evidence.
+// If none is specified, bodies are decoded as proofwatch.OCSFEvidence.
+func WithDecoder(decode DecodeFunc) Option {
+	return func(cfg *config) {
+		if decode != nil {
+			cfg.decode = decode
+		}
+	}
+}
+
+// WithMaxBodyBytes sets the largest request body accepted by a Handler. Larger bodies
+// are rejected with 413 Request Entity Too Large.
+// If none is specified, DefaultMaxBodyBytes is used.
+func WithMaxBodyBytes(n int64) Option {
+	return func(cfg *config) {
+		if n > 0 {
+			cfg.maxBodyBytes = n
+		}
+	}
+}
+
+// WithBackpressure hands decoded evidence to pool instead of evaluating it inline, and
+// turns producers away while the pool's pressure exceeds threshold: a Handler responds
+// 429 Too Many Requests with a Retry-After of retryAfter, and an IngestServer ends the
+// stream with RESOURCE_EXHAUSTED. Turned away evidence is recorded as dropped with
+// reason backpressure, and evidence the full pool drops with reason queue_full is
+// answered the same way. The pool processes evidence with its own ProcessFunc, so the
+// evaluator given to NewHandler or NewIngestServer is not used. A non-positive
+// retryAfter uses DefaultRetryAfter.
+// If none is specified, evidence is evaluated inline and producers are never turned away.
+func WithBackpressure(pool *proofwatch.Pool, threshold float64, retryAfter time.Duration) Option {
+	return func(cfg *config) {
+		if pool == nil {
+			return
+		}
+		if retryAfter <= 0 {
+			retryAfter = DefaultRetryAfter
+		}
+		cfg.pool, cfg.threshold, cfg.retryAfter = pool, threshold, retryAfter
+	}
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		decode:       decodeOCSF,
+		maxBodyBytes: DefaultMaxBodyBytes,
+		retryAfter:   DefaultRetryAfter,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// ingester decodes evidence and evaluates it through proofwatch Instrumentation, or
+// hands it to a Pool when one is configured.
+type ingester struct {
+	inst      *proofwatch.Instrumentation
+	evaluator proofwatch.PolicyEvaluator
+	decode    DecodeFunc
+	pool      *proofwatch.Pool
+	threshold float64
+}
+
+func newIngester(inst *proofwatch.Instrumentation, evaluator proofwatch.PolicyEvaluator, cfg config) ingester {
+	return ingester{inst: inst, evaluator: evaluator, decode: cfg.decode, pool: cfg.pool, threshold: cfg.threshold}
+}
+
+// ingest decodes and evaluates the evidence in body. Evidence that cannot be decoded
+// is recorded as dropped with reason validation_failed and a *proofwatch.ValidationError
+// is returned. With a pool, the evidence is queued instead and queued is true; while the
+// pool is under pressure the evidence is recorded as dropped with reason backpressure and
+// proofwatch.ErrBackpressure is returned.
+func (g ingester) ingest(ctx context.Context, body []byte) (res proofwatch.EvaluationResult, queued bool, err error) {
+	evidence, err := g.decode(body)
+	if err != nil {
+		verr := &proofwatch.ValidationError{Fields: []proofwatch.FieldError{{Message: err.Error()}}}
+		return proofwatch.EvaluationResult{}, false, g.inst.Reject(ctx, rawEvidence{body: body, received: time.Now()}, verr)
+	}
+	if g.pool == nil {
+		res, err = g.inst.Evaluate(ctx, evidence, g.evaluator)
+		return res, false, err
+	}
+	if g.pool.Pressure() > g.threshold {
+		return proofwatch.EvaluationResult{}, false, g.inst.Reject(ctx, evidence, proofwatch.ErrBackpressure)
+	}
+	if err := g.pool.Submit(ctx, evidence); err != nil {
+		return proofwatch.EvaluationResult{}, false, err
+	}
+	return proofwatch.EvaluationResult{}, true, nil
+}
+
+// isBackpressure reports whether err turned evidence away because the pipeline is
+// saturated or the rate limiter shed it.
+func isBackpressure(err error) bool {
+	return errors.Is(err, proofwatch.ErrBackpressure) || errors.Is(err, proofwatch.ErrQueueFull) ||
+		errors.Is(err, proofwatch.ErrRateLimited)
+}
+
+// Handler accepts evidence POSTed as JSON and evaluates it through proofwatch
+// Instrumentation, so every request is recorded as processed or dropped evidence.
+type Handler struct {
+	ingester
+	maxBodyBytes int64
+	retryAfter   time.Duration
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a Handler evaluating evidence with evaluator.
+func NewHandler(inst *proofwatch.Instrumentation, evaluator proofwatch.PolicyEvaluator, opts ...Option) *Handler {
+	cfg := newConfig(opts)
+	return &Handler{
+		ingester:     newIngester(inst, evaluator, cfg),
+		maxBodyBytes: cfg.maxBodyBytes,
+		retryAfter:   cfg.retryAfter,
+	}
+}
+
+// statusQueued is the status of accepted evidence handed to a pool for processing.
+const statusQueued = "queued"
+
+// result is the response body for accepted evidence.
+type result struct {
+	PolicyID string `json:"policy_id,omitempty"`
+	Status   string `json:"status"`
+}
+
+// errorResponse is the response body for rejected evidence.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP evaluates the evidence in the request body. It responds 202 Accepted with the
+// evaluation result, or with status "queued" when the evidence is handed to the pool set
+// with WithBackpressure, 400 Bad Request when the body cannot be decoded or the evidence
+// fails validation or provenance verification, 409 Conflict for duplicate evidence, 422
+// Unprocessable Entity for expired evidence, 429 Too Many Requests when the pool or the
+// rate limiter turns it away, and 500 Internal Server Error when the evaluation fails.
+// Bodies that cannot be decoded are recorded as dropped with reason validation_failed.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: "request body too large"})
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "failed to read request body"})
+		return
+	}
+
+	res, queued, err := h.ingest(r.Context(), body)
+	if err != nil {
+		if isBackpressure(err) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(h.retryAfter.Seconds()))))
+			writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "evidence pipeline is saturated"})
+			return
+		}
+		if status, ok := clientErrorStatus(err); ok {
+			writeJSON(w, status, errorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to process evidence"})
+		return
+	}
+	if queued {
+		writeJSON(w, http.StatusAccepted, result{Status: statusQueued})
+		return
+	}
+	writeJSON(w, http.StatusAccepted, result{PolicyID: res.PolicyID, Status: string(res.Status)})
+}
+
+// clientErrorStatus returns the status answering err when it is caused by the evidence
+// submitted rather than by processing it.
+func clientErrorStatus(err error) (int, bool) {
+	var verr *proofwatch.ValidationError
+	switch {
+	case errors.As(err, &verr), errors.Is(err, provenance.ErrVerificationFailed):
+		return http.StatusBadRequest, true
+	case errors.Is(err, proofwatch.ErrDuplicate):
+		return http.StatusConflict, true
+	case errors.Is(err, proofwatch.ErrExpired):
+		return http.StatusUnprocessableEntity, true
+	}
+	return 0, false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// decodeOCSF decodes body as OCSF evidence.
+func decodeOCSF(body []byte) (proofwatch.Evidence, error) {
+	var evidence proofwatch.OCSFEvidence
+	if err := json.Unmarshal(body, &evidence); err != nil {
+		return nil, fmt.Errorf("failed to decode evidence: %w", err)
+	}
+	return evidence, nil
+}
+
+// rawEvidence is a request body that could not be decoded into evidence.
+type rawEvidence struct {
+	body     []byte
+	received time.Time
+}
+
+func (r rawEvidence) ToJSON() ([]byte, error) {
+	if !json.Valid(r.body) {
+		return json.Marshal(string(r.body))
+	}
+	return r.body, nil
+}
+
+func (r rawEvidence) Attributes() []attribute.KeyValue {
+	return nil
+}
+
+func (r rawEvidence) Timestamp() time.Time {
+	return r.received
+}
@@ -0,0 +1,288 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const validEvidence = `{"time": 1700000000000, "status": "success", "policy": {"uid": "policy-1", "name": "policy-1"}}`
+
+type evaluatorFunc func(ctx context.Context, evidence proofwatch.Evidence) (proofwatch.EvaluationResult, error)
+
+func (f evaluatorFunc) Evaluate(ctx context.Context, evidence proofwatch.Evidence) (proofwatch.EvaluationResult, error) {
+	return f(ctx, evidence)
+}
+
+// setupInstrumentation returns Instrumentation validating that evidence names a policy
+// UID and configured with opts, along with the reader collecting its metrics.
+func setupInstrumentation(t *testing.T, opts ...proofwatch.OptionFunc) (*proofwatch.Instrumentation, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	validator, err := proofwatch.NewValidator([]byte(`{"type": "object", "properties": {"policy": {"required": ["uid"]}}}`))
+	require.NoError(t, err)
+
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = meterProvider.Shutdown(context.Background()) })
+
+	inst, err := proofwatch.NewInstrumentation(append([]proofwatch.OptionFunc{
+		proofwatch.WithMeterProvider(meterProvider),
+		proofwatch.WithValidator(validator),
+	}, opts...)...)
+	require.NoError(t, err)
+	return inst, reader
+}
+
+func setupHandlerTest(t *testing.T, evaluator proofwatch.PolicyEvaluator) (*httptest.Server, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	inst, reader := setupInstrumentation(t)
+	srv := httptest.NewServer(NewHandler(inst, evaluator))
+	t.Cleanup(srv.Close)
+	return srv, reader
+}
+
+// counts returns the total of each evidence counter, keyed by metric name and then by
+// the value of the reason attribute.
+func counts(t *testing.T, reader *sdkmetric.ManualReader) map[string]map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	got := map[string]map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "evidence_processed_count" && m.Name != "evidence_dropped_count" {
+				continue
+			}
+			got[m.Name] = map[string]int64{}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				reason, _ := dp.Attributes.Value("reason")
+				got[m.Name][reason.AsString()] += dp.Value
+			}
+		}
+	}
+	return got
+}
+
+func post(t *testing.T, srv *httptest.Server, body string) (*http.Response, map[string]string) {
+	t.Helper()
+
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var decoded map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	return resp, decoded
+}
+
+func TestHandler(t *testing.T) {
+	pass := evaluatorFunc(func(context.Context, proofwatch.Evidence) (proofwatch.EvaluationResult, error) {
+		return proofwatch.EvaluationResult{PolicyID: "policy-1", Status: proofwatch.EvaluationStatusPass}, nil
+	})
+	failing := evaluatorFunc(func(context.Context, proofwatch.Evidence) (proofwatch.EvaluationResult, error) {
+		return proofwatch.EvaluationResult{}, assert.AnError
+	})
+
+	tests := []struct {
+		name       string
+		evaluator  proofwatch.PolicyEvaluator
+		body       string
+		wantStatus int
+		wantCounts map[string]map[string]int64
+	}{
+		{
+			name:       "accepted",
+			evaluator:  pass,
+			body:       validEvidence,
+			wantStatus: http.StatusAccepted,
+			wantCounts: map[string]map[string]int64{"evidence_processed_count": {"": 1}},
+		},
+		{
+			name:       "validation failure",
+			evaluator:  pass,
+			body:       `{"time": 1700000000000, "policy": {"name": "policy-1"}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCounts: map[string]map[string]int64{"evidence_dropped_count": {"validation_failed": 1}},
+		},
+		{
+			name:       "undecodable body",
+			evaluator:  pass,
+			body:       `{"time":`,
+			wantStatus: http.StatusBadRequest,
+			wantCounts: map[string]map[string]int64{"evidence_dropped_count": {"validation_failed": 1}},
+		},
+		{
+			name:       "processing error",
+			evaluator:  failing,
+			body:       validEvidence,
+			wantStatus: http.StatusInternalServerError,
+			wantCounts: map[string]map[string]int64{"evidence_dropped_count": {"processing_error": 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, reader := setupHandlerTest(t, tt.evaluator)
+
+			resp, body := post(t, srv, tt.body)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			assert.Equal(t, tt.wantCounts, counts(t, reader))
+			if tt.wantStatus == http.StatusAccepted {
+				assert.Equal(t, map[string]string{"policy_id": "policy-1", "status": "pass"}, body)
+			} else {
+				assert.NotEmpty(t, body["error"])
+			}
+		})
+	}
+}
+
+func TestHandlerRejectsRequests(t *testing.T) {
+	t.Run("method not allowed", func(t *testing.T) {
+		srv, reader := setupHandlerTest(t, nil)
+
+		resp, err := http.Get(srv.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		assert.Equal(t, http.MethodPost, resp.Header.Get("Allow"))
+		assert.Empty(t, counts(t, reader))
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		inst, err := proofwatch.NewInstrumentation(proofwatch.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+		require.NoError(t, err)
+		srv := httptest.NewServer(NewHandler(inst, nil, WithMaxBodyBytes(8)))
+		t.Cleanup(srv.Close)
+
+		resp, _ := post(t, srv, validEvidence)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+}
+
+func TestHandlerRejectsEvidence(t *testing.T) {
+	pass := evaluatorFunc(func(context.Context, proofwatch.Evidence) (proofwatch.EvaluationResult, error) {
+		return proofwatch.EvaluationResult{PolicyID: "policy-1", Status: proofwatch.EvaluationStatusPass}, nil
+	})
+	duplicate := evaluatorFunc(func(context.Context, proofwatch.Evidence) (proofwatch.EvaluationResult, error) {
+		return proofwatch.EvaluationResult{}, proofwatch.ErrDuplicate
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		inst, reader := setupInstrumentation(t, proofwatch.WithRateLimiter(proofwatch.NewRateLimiter(0, 1)))
+		srv := httptest.NewServer(NewHandler(inst, pass))
+		t.Cleanup(srv.Close)
+
+		resp, _ := post(t, srv, validEvidence)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		resp, body := post(t, srv, validEvidence)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+		assert.NotEmpty(t, body["error"])
+		assert.Equal(t, map[string]int64{"rate_limited": 1}, counts(t, reader)["evidence_dropped_count"])
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		srv, reader := setupHandlerTest(t, duplicate)
+
+		resp, body := post(t, srv, validEvidence)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.NotEmpty(t, body["error"])
+		assert.Equal(t, map[string]int64{"duplicate": 1}, counts(t, reader)["evidence_dropped_count"])
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		inst, reader := setupInstrumentation(t, proofwatch.WithMaxAge(time.Hour))
+		srv := httptest.NewServer(NewHandler(inst, pass))
+		t.Cleanup(srv.Close)
+
+		resp, body := post(t, srv, validEvidence)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		assert.NotEmpty(t, body["error"])
+		assert.Equal(t, map[string]int64{"expired": 1}, counts(t, reader)["evidence_dropped_count"])
+	})
+}
+
+// setupBlockedPool returns a Pool on inst with one worker and a queue of two, whose
+// workers signal on started and then block until the test ends.
+func setupBlockedPool(t *testing.T, inst *proofwatch.Instrumentation) (*proofwatch.Pool, <-chan struct{}) {
+	t.Helper()
+
+	started, release := make(chan struct{}, 8), make(chan struct{})
+	pool := proofwatch.NewPool(inst, func(context.Context, proofwatch.Evidence) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}, proofwatch.WithWorkers(1), proofwatch.WithQueueSize(2))
+	t.Cleanup(func() {
+		close(release)
+		pool.Close()
+	})
+	return pool, started
+}
+
+func TestHandlerRedactsErrors(t *testing.T) {
+	validator, err := proofwatch.NewValidator([]byte(`{"properties": {"policy": {"properties": {"desc": {"pattern": "^public"}}}}}`))
+	require.NoError(t, err)
+	redactor, err := proofwatch.NewRedactor(proofwatch.WithRedactedPaths("policy.desc"))
+	require.NoError(t, err)
+	inst, err := proofwatch.NewInstrumentation(
+		proofwatch.WithMeterProvider(sdkmetric.NewMeterProvider()),
+		proofwatch.WithValidator(validator),
+		proofwatch.WithRedactor(redactor),
+	)
+	require.NoError(t, err)
+	srv := httptest.NewServer(NewHandler(inst, evaluatorFunc(func(context.Context, proofwatch.Evidence) (proofwatch.EvaluationResult, error) {
+		t.Fatal("evaluator called for evidence failing validation")
+		return proofwatch.EvaluationResult{}, nil
+	})))
+	t.Cleanup(srv.Close)
+
+	resp, body := post(t, srv, `{"time": 1700000000000, "policy": {"uid": "policy-1", "desc": "db password hunter2"}}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, body["error"], proofwatch.RedactedValue)
+	assert.NotContains(t, body["error"], "hunter2")
+}
+
+func TestHandlerBackpressure(t *testing.T) {
+	inst, reader := setupInstrumentation(t)
+	pool, started := setupBlockedPool(t, inst)
+	srv := httptest.NewServer(NewHandler(inst, nil, WithBackpressure(pool, 0.5, 3*time.Second)))
+	t.Cleanup(srv.Close)
+
+	resp, body := post(t, srv, validEvidence)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "queued", body["status"])
+	<-started
+
+	// Queued evidence raises the pressure to 0.5 and then 1.
+	for _, want := range []float64{0.5, 1} {
+		resp, _ = post(t, srv, validEvidence)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Equal(t, want, pool.Pressure())
+	}
+
+	resp, body = post(t, srv, validEvidence)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "3", resp.Header.Get("Retry-After"))
+	assert.NotEmpty(t, body["error"])
+	assert.Equal(t, map[string]map[string]int64{
+		"evidence_dropped_count": {"backpressure": 1},
+	}, counts(t, reader))
+}
@@ -0,0 +1,150 @@
+// Package sqlite provides a proofwatch.Store backed by SQLite, for keeping evidence
+// queryable across restarts on a single node.
+//
+// The driver uses cgo, so building this package requires a C toolchain.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS evidence (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	policy_id TEXT    NOT NULL,
+	status    TEXT    NOT NULL,
+	timestamp INTEGER,
+	evidence  BLOB    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS evidence_policy_id ON evidence (policy_id, timestamp);
+CREATE INDEX IF NOT EXISTS evidence_timestamp ON evidence (timestamp);
+`
+
+// busyTimeout is how long a statement waits for a lock held by another connection to
+// the database before failing with SQLITE_BUSY.
+const busyTimeout = 5 * time.Second
+
+var _ proofwatch.Store = (*Store)(nil)
+
+// Store keeps evidence in a SQLite database. Timestamps are stored with nanosecond
+// precision and returned in UTC. Evidence without a timestamp is stored without one and
+// sorts before, and is matched by Until like, any timestamped evidence, as in the
+// in-memory store.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore opens the SQLite database at path, creating it and its schema if needed.
+// The store uses a single connection, so concurrent calls are serialized rather than
+// failing with SQLITE_BUSY, and an in-memory database such as ":memory:" keeps its
+// evidence across calls. Call Close to release the database.
+func NewStore(ctx context.Context, path string) (*Store, error) {
+	db, err := sql.Open("sqlite3", dsn(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open evidence database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create evidence schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// dsn returns the data source name opening path with the busy timeout set.
+func dsn(path string) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + "_busy_timeout=" + strconv.FormatInt(busyTimeout.Milliseconds(), 10)
+}
+
+func (s *Store) Put(ctx context.Context, evidence proofwatch.Evidence, result proofwatch.EvaluationResult) error {
+	data, err := evidence.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal evidence: %w", err)
+	}
+	var timestamp sql.NullInt64
+	if ts := evidence.Timestamp(); !ts.IsZero() {
+		timestamp = sql.NullInt64{Int64: ts.UnixNano(), Valid: true}
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO evidence (policy_id, status, timestamp, evidence) VALUES (?, ?, ?, ?)`,
+		result.PolicyID, string(result.Status), timestamp, data,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert evidence: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) Query(ctx context.Context, filter proofwatch.Filter) ([]proofwatch.StoredEvidence, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	if filter.PolicyID != "" {
+		conds = append(conds, "policy_id = ?")
+		args = append(args, filter.PolicyID)
+	}
+	if filter.Status != "" {
+		conds = append(conds, "status = ?")
+		args = append(args, string(filter.Status))
+	}
+	if !filter.Since.IsZero() {
+		conds = append(conds, "timestamp >= ?")
+		args = append(args, filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		conds = append(conds, "(timestamp IS NULL OR timestamp < ?)")
+		args = append(args, filter.Until.UnixNano())
+	}
+
+	query := `SELECT policy_id, status, timestamp, evidence FROM evidence`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY timestamp, id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query evidence: %w", err)
+	}
+	defer rows.Close()
+
+	var stored []proofwatch.StoredEvidence
+	for rows.Next() {
+		var (
+			item      proofwatch.StoredEvidence
+			status    string
+			timestamp sql.NullInt64
+		)
+		if err := rows.Scan(&item.PolicyID, &status, &timestamp, &item.Evidence); err != nil {
+			return nil, fmt.Errorf("failed to scan evidence: %w", err)
+		}
+		item.Status = proofwatch.EvaluationStatus(status)
+		if timestamp.Valid {
+			item.Timestamp = time.Unix(0, timestamp.Int64).UTC()
+		}
+		stored = append(stored, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query evidence: %w", err)
+	}
+	return stored, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
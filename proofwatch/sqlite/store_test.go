@@ -0,0 +1,182 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// policyEvidence is evidence for a single policy collected at a fixed time.
+type policyEvidence struct {
+	PolicyID string    `json:"policy_id"`
+	At       time.Time `json:"at"`
+}
+
+func (p policyEvidence) ToJSON() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p policyEvidence) Attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String("policy.id", p.PolicyID)}
+}
+
+func (p policyEvidence) Timestamp() time.Time {
+	return p.At
+}
+
+func TestStoreQuery(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "evidence.db")
+	store, err := NewStore(ctx, path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	puts := []struct {
+		policyID string
+		status   proofwatch.EvaluationStatus
+		offset   time.Duration
+	}{
+		{"policy-a", proofwatch.EvaluationStatusPass, 2 * time.Hour},
+		{"policy-a", proofwatch.EvaluationStatusFail, 0},
+		{"policy-b", proofwatch.EvaluationStatusFail, time.Hour},
+		{"policy-a", proofwatch.EvaluationStatusFail, 3 * time.Hour},
+		{"policy-b", proofwatch.EvaluationStatusPass, 4 * time.Hour},
+	}
+	for _, p := range puts {
+		evidence := policyEvidence{PolicyID: p.policyID, At: base.Add(p.offset)}
+		require.NoError(t, store.Put(ctx, evidence, proofwatch.EvaluationResult{PolicyID: p.policyID, Status: p.status}))
+	}
+
+	tests := []struct {
+		name        string
+		filter      proofwatch.Filter
+		wantOffsets []time.Duration
+	}{
+		{
+			name:        "everything",
+			wantOffsets: []time.Duration{0, time.Hour, 2 * time.Hour, 3 * time.Hour, 4 * time.Hour},
+		},
+		{
+			name:        "by policy",
+			filter:      proofwatch.Filter{PolicyID: "policy-a"},
+			wantOffsets: []time.Duration{0, 2 * time.Hour, 3 * time.Hour},
+		},
+		{
+			name:        "by status",
+			filter:      proofwatch.Filter{Status: proofwatch.EvaluationStatusFail},
+			wantOffsets: []time.Duration{0, time.Hour, 3 * time.Hour},
+		},
+		{
+			name:        "by policy and status",
+			filter:      proofwatch.Filter{PolicyID: "policy-a", Status: proofwatch.EvaluationStatusFail},
+			wantOffsets: []time.Duration{0, 3 * time.Hour},
+		},
+		{
+			name:        "by time range",
+			filter:      proofwatch.Filter{Since: base.Add(time.Hour), Until: base.Add(3 * time.Hour)},
+			wantOffsets: []time.Duration{time.Hour, 2 * time.Hour},
+		},
+		{
+			name:   "no match",
+			filter: proofwatch.Filter{PolicyID: "policy-c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Query(ctx, tt.filter)
+			require.NoError(t, err)
+
+			var offsets []time.Duration
+			for _, stored := range got {
+				offsets = append(offsets, stored.Timestamp.Sub(base))
+			}
+			assert.Equal(t, tt.wantOffsets, offsets)
+		})
+	}
+
+	t.Run("persists across reopening", func(t *testing.T) {
+		reopened, err := NewStore(ctx, path)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = reopened.Close() })
+
+		got, err := reopened.Query(ctx, proofwatch.Filter{PolicyID: "policy-b", Status: proofwatch.EvaluationStatusFail})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, base.Add(time.Hour), got[0].Timestamp)
+		assert.JSONEq(t, `{"policy_id": "policy-b", "at": "2025-01-01T01:00:00Z"}`, string(got[0].Evidence))
+	})
+}
+
+func TestStoreConcurrentPut(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, path := range map[string]string{
+		"file":      filepath.Join(t.TempDir(), "evidence.db"),
+		"in memory": ":memory:",
+	} {
+		t.Run(name, func(t *testing.T) {
+			store, err := NewStore(ctx, path)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = store.Close() })
+
+			const puts = 50
+			var wg sync.WaitGroup
+			wg.Add(puts)
+			start := make(chan struct{})
+			for i := range puts {
+				go func() {
+					defer wg.Done()
+					<-start
+					evidence := policyEvidence{PolicyID: "policy-a", At: base.Add(time.Duration(i) * time.Second)}
+					assert.NoError(t, store.Put(ctx, evidence, proofwatch.EvaluationResult{PolicyID: "policy-a", Status: proofwatch.EvaluationStatusPass}))
+				}()
+			}
+			close(start)
+			wg.Wait()
+
+			got, err := store.Query(ctx, proofwatch.Filter{})
+			require.NoError(t, err)
+			assert.Len(t, got, puts)
+		})
+	}
+}
+
+func TestStoreZeroTimestamp(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewStore(ctx, ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	result := proofwatch.EvaluationResult{PolicyID: "policy-a", Status: proofwatch.EvaluationStatusPass}
+	require.NoError(t, store.Put(ctx, policyEvidence{PolicyID: "policy-a", At: base}, result))
+	require.NoError(t, store.Put(ctx, policyEvidence{PolicyID: "policy-a"}, result))
+
+	got, err := store.Query(ctx, proofwatch.Filter{})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.True(t, got[0].Timestamp.IsZero())
+	assert.Equal(t, base, got[1].Timestamp)
+
+	// Evidence without a timestamp sorts before any other, as in the in-memory store.
+	got, err = store.Query(ctx, proofwatch.Filter{Since: base})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, base, got[0].Timestamp)
+	got, err = store.Query(ctx, proofwatch.Filter{Until: base})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.True(t, got[0].Timestamp.IsZero())
+}
@@ -0,0 +1,13 @@
+package proofwatch
+
+import "github.com/complytime/complybeacon/proofwatch/internal/metrics"
+
+// EvaluationStatus is the outcome of a policy evaluation.
+type EvaluationStatus = metrics.EvaluationStatus
+
+const (
+	EvaluationStatusPass    = metrics.EvaluationStatusPass
+	EvaluationStatusFail    = metrics.EvaluationStatusFail
+	EvaluationStatusError   = metrics.EvaluationStatusError
+	EvaluationStatusSkipped = metrics.EvaluationStatusSkipped
+)
@@ -0,0 +1,146 @@
+package proofwatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/complytime/complybeacon/proofwatch/internal/metrics"
+)
+
+// StoredEvidence is an evidence item as recorded in a Store.
+type StoredEvidence struct {
+	PolicyID  string
+	Status    EvaluationStatus
+	Timestamp time.Time
+	Evidence  json.RawMessage
+}
+
+// Filter selects stored evidence. Zero fields match everything.
+type Filter struct {
+	PolicyID string
+	Status   EvaluationStatus
+	// Since and Until bound the evidence timestamp: Since is inclusive and Until
+	// exclusive.
+	Since time.Time
+	Until time.Time
+}
+
+// Match reports whether stored matches the filter.
+func (f Filter) Match(stored StoredEvidence) bool {
+	switch {
+	case f.PolicyID != "" && stored.PolicyID != f.PolicyID:
+		return false
+	case f.Status != "" && stored.Status != f.Status:
+		return false
+	case !f.Since.IsZero() && stored.Timestamp.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !stored.Timestamp.Before(f.Until):
+		return false
+	default:
+		return true
+	}
+}
+
+// Store persists evidence along with the result of processing it, so past results can
+// be queried. Implementations must be safe for concurrent use.
+type Store interface {
+	// Put records evidence with its result.
+	Put(ctx context.Context, evidence Evidence, result EvaluationResult) error
+
+	// Query returns the stored evidence matching filter, oldest first.
+	Query(ctx context.Context, filter Filter) ([]StoredEvidence, error)
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore keeps evidence in memory.
+type MemoryStore struct {
+	mu       sync.Mutex
+	evidence []StoredEvidence
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (m *MemoryStore) Put(_ context.Context, evidence Evidence, result EvaluationResult) error {
+	data, err := evidence.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal evidence: %w", err)
+	}
+	stored := StoredEvidence{
+		PolicyID:  result.PolicyID,
+		Status:    result.Status,
+		Timestamp: evidence.Timestamp(),
+		Evidence:  data,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i, _ := slices.BinarySearchFunc(m.evidence, stored.Timestamp, func(s StoredEvidence, t time.Time) int {
+		if s.Timestamp.After(t) {
+			return 1
+		}
+		return -1
+	})
+	m.evidence = slices.Insert(m.evidence, i, stored)
+	return nil
+}
+
+func (m *MemoryStore) Query(_ context.Context, filter Filter) ([]StoredEvidence, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []StoredEvidence
+	for _, stored := range m.evidence {
+		if filter.Match(stored) {
+			matched = append(matched, stored)
+		}
+	}
+	return matched, nil
+}
+
+// attributeResult returns the result carried by the evidence attributes, if any. The
+// policy is identified by policy.id or, for evidence without one such as OCSF
+// evidence, by policy.rule.id. The status is taken from policy.evaluation.status or,
+// for OCSF and Gemara evidence, normalized from policy.evaluation.result.
+func attributeResult(attrs []attribute.KeyValue) EvaluationResult {
+	var result EvaluationResult
+	var ruleID string
+	for _, kv := range attrs {
+		switch kv.Key {
+		case metrics.PolicyIDKey:
+			result.PolicyID = kv.Value.Emit()
+		case POLICY_RULE_ID:
+			ruleID = kv.Value.Emit()
+		}
+	}
+	if result.PolicyID == "" {
+		result.PolicyID = ruleID
+	}
+	result.Status = metrics.StatusFromAttributes(attrs)
+	return result
+}
+
+// persist writes evidence to the configured store, if any, reporting failures through
+// the OpenTelemetry error handler.
+func (i *Instrumentation) persist(ctx context.Context, evidence Evidence, result EvaluationResult) {
+	if i.store == nil {
+		return
+	}
+	redacted, err := redact(i.redactor, evidence)
+	if err != nil {
+		otel.Handle(fmt.Errorf("failed to redact stored evidence: %w", err))
+		return
+	}
+	if err := i.store.Put(ctx, redacted, result); err != nil {
+		otel.Handle(fmt.Errorf("failed to store evidence: %w", err))
+	}
+}
@@ -0,0 +1,217 @@
+package proofwatch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ossf/gemara/layer4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// policyEvidence is evidence for a single policy collected at a fixed time.
+type policyEvidence struct {
+	PolicyID string    `json:"policy_id"`
+	At       time.Time `json:"at"`
+}
+
+func (p policyEvidence) ToJSON() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p policyEvidence) Attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String("policy.id", p.PolicyID)}
+}
+
+func (p policyEvidence) Timestamp() time.Time {
+	return p.At
+}
+
+func TestMemoryStoreQuery(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Inserted out of order to check that results come back oldest first.
+	puts := []struct {
+		policyID string
+		status   EvaluationStatus
+		offset   time.Duration
+	}{
+		{"policy-a", EvaluationStatusPass, 2 * time.Hour},
+		{"policy-a", EvaluationStatusFail, 0},
+		{"policy-b", EvaluationStatusFail, time.Hour},
+		{"policy-a", EvaluationStatusFail, 3 * time.Hour},
+		{"policy-b", EvaluationStatusPass, 4 * time.Hour},
+	}
+	for _, p := range puts {
+		evidence := policyEvidence{PolicyID: p.policyID, At: base.Add(p.offset)}
+		require.NoError(t, store.Put(ctx, evidence, EvaluationResult{PolicyID: p.policyID, Status: p.status}))
+	}
+
+	tests := []struct {
+		name        string
+		filter      Filter
+		wantOffsets []time.Duration
+	}{
+		{
+			name:        "everything",
+			wantOffsets: []time.Duration{0, time.Hour, 2 * time.Hour, 3 * time.Hour, 4 * time.Hour},
+		},
+		{
+			name:        "by policy",
+			filter:      Filter{PolicyID: "policy-a"},
+			wantOffsets: []time.Duration{0, 2 * time.Hour, 3 * time.Hour},
+		},
+		{
+			name:        "by status",
+			filter:      Filter{Status: EvaluationStatusFail},
+			wantOffsets: []time.Duration{0, time.Hour, 3 * time.Hour},
+		},
+		{
+			name:        "by policy and status",
+			filter:      Filter{PolicyID: "policy-a", Status: EvaluationStatusFail},
+			wantOffsets: []time.Duration{0, 3 * time.Hour},
+		},
+		{
+			name:        "by time range",
+			filter:      Filter{Since: base.Add(time.Hour), Until: base.Add(3 * time.Hour)},
+			wantOffsets: []time.Duration{time.Hour, 2 * time.Hour},
+		},
+		{
+			name:   "no match",
+			filter: Filter{PolicyID: "policy-c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Query(ctx, tt.filter)
+			require.NoError(t, err)
+
+			var offsets []time.Duration
+			for _, stored := range got {
+				assert.True(t, tt.filter.Match(stored))
+				offsets = append(offsets, stored.Timestamp.Sub(base))
+			}
+			assert.Equal(t, tt.wantOffsets, offsets)
+		})
+	}
+
+	got, err := store.Query(ctx, Filter{PolicyID: "policy-b", Status: EvaluationStatusFail})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.JSONEq(t, `{"policy_id": "policy-b", "at": "2025-01-01T01:00:00Z"}`, string(got[0].Evidence))
+}
+
+func TestInstrumentationStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	inst, err := NewInstrumentation(
+		WithMeterProvider(sdkmetric.NewMeterProvider()),
+		WithStore(store),
+	)
+	require.NoError(t, err)
+
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, inst.Process(ctx, policyEvidence{PolicyID: "processed", At: at}, func(context.Context, Evidence) error {
+		return nil
+	}))
+	_, err = inst.Evaluate(ctx, policyEvidence{PolicyID: "evaluated", At: at.Add(time.Second)}, evaluatorFunc(func(context.Context, Evidence) (EvaluationResult, error) {
+		return EvaluationResult{PolicyID: "evaluated", Status: EvaluationStatusFail}, nil
+	}))
+	require.NoError(t, err)
+	require.Error(t, inst.Process(ctx, policyEvidence{PolicyID: "dropped", At: at.Add(2 * time.Second)}, func(context.Context, Evidence) error {
+		return assert.AnError
+	}))
+
+	got, err := store.Query(ctx, Filter{})
+	require.NoError(t, err)
+	var results []EvaluationResult
+	for _, stored := range got {
+		results = append(results, EvaluationResult{PolicyID: stored.PolicyID, Status: stored.Status})
+	}
+	assert.Equal(t, []EvaluationResult{
+		{PolicyID: "processed"},
+		{PolicyID: "evaluated", Status: EvaluationStatusFail},
+		{PolicyID: "dropped", Status: EvaluationStatusError},
+	}, results)
+}
+
+func TestInstrumentationStoreEvidenceResult(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	inst, err := NewInstrumentation(
+		WithMeterProvider(sdkmetric.NewMeterProvider()),
+		WithStore(store),
+	)
+	require.NoError(t, err)
+
+	failed := "failure"
+	ocsfFailed := createTestEvidence()
+	ocsfFailed.Status = &failed
+	gemaraNotRun := createTestGemaraEvidence()
+	gemaraNotRun.Result = layer4.NotRun
+
+	for _, evidence := range []Evidence{createTestEvidence(), ocsfFailed, createTestGemaraEvidence(), gemaraNotRun} {
+		require.NoError(t, inst.Process(ctx, evidence, func(context.Context, Evidence) error { return nil }))
+	}
+
+	got, err := store.Query(ctx, Filter{})
+	require.NoError(t, err)
+	statuses := map[EvaluationStatus]int{}
+	for _, stored := range got {
+		assert.NotEmpty(t, stored.PolicyID)
+		statuses[stored.Status]++
+	}
+	assert.Equal(t, map[EvaluationStatus]int{
+		EvaluationStatusPass:    2,
+		EvaluationStatusFail:    1,
+		EvaluationStatusSkipped: 1,
+	}, statuses)
+}
+
+func TestAttributeResult(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs []attribute.KeyValue
+		want  EvaluationResult
+	}{
+		{
+			name:  "status attribute",
+			attrs: []attribute.KeyValue{attribute.String("policy.id", "p-1"), attribute.String("policy.evaluation.status", "fail")},
+			want:  EvaluationResult{PolicyID: "p-1", Status: EvaluationStatusFail},
+		},
+		{
+			name:  "result attribute",
+			attrs: []attribute.KeyValue{attribute.String(POLICY_RULE_ID, "rule-1"), attribute.String(POLICY_EVALUATION_RESULT, "Passed")},
+			want:  EvaluationResult{PolicyID: "rule-1", Status: EvaluationStatusPass},
+		},
+		{
+			name: "status wins over result",
+			attrs: []attribute.KeyValue{
+				attribute.String(POLICY_EVALUATION_RESULT, "Passed"),
+				attribute.String("policy.evaluation.status", "error"),
+			},
+			want: EvaluationResult{Status: EvaluationStatusError},
+		},
+		{
+			name:  "unrecognized result",
+			attrs: []attribute.KeyValue{attribute.String(POLICY_EVALUATION_RESULT, "Needs Review")},
+			want:  EvaluationResult{Status: "unknown"},
+		},
+		{
+			name: "no result",
+			want: EvaluationResult{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, attributeResult(tt.attrs))
+		})
+	}
+}
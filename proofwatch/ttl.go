@@ -0,0 +1,75 @@
+package proofwatch
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// AgeBucketKey is the attribute carrying the age bucket of evidence dropped with reason
+// expired.
+const AgeBucketKey = attribute.Key("evidence.age_bucket")
+
+// ErrExpired marks evidence older than the maximum age set with WithMaxAge. An
+// *ExpiredError matches it.
+var ErrExpired = errors.New("evidence expired")
+
+// ageBuckets bound the age bucket attribute of expired evidence; ages beyond the last
+// bucket are recorded as ">30d".
+var ageBuckets = []struct {
+	max   time.Duration
+	label string
+}{
+	{time.Hour, "<=1h"},
+	{6 * time.Hour, "<=6h"},
+	{24 * time.Hour, "<=24h"},
+	{7 * 24 * time.Hour, "<=7d"},
+	{30 * 24 * time.Hour, "<=30d"},
+}
+
+// ageBucket returns the bucket label of age.
+func ageBucket(age time.Duration) string {
+	for _, b := range ageBuckets {
+		if age <= b.max {
+			return b.label
+		}
+	}
+	return ">30d"
+}
+
+// ExpiredError reports evidence whose event time is older than the maximum age.
+type ExpiredError struct {
+	// Age is how long before it was checked the evidence event happened.
+	Age time.Duration
+	// MaxAge is the maximum age evidence may have, clock skew tolerance included.
+	MaxAge time.Duration
+}
+
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("evidence expired: event is %s old, more than the maximum of %s", e.Age.Round(time.Second), e.MaxAge)
+}
+
+// Is reports whether target is ErrExpired.
+func (e *ExpiredError) Is(target error) bool {
+	return target == ErrExpired
+}
+
+// checkAge returns an *ExpiredError when evidence happened more than the configured
+// maximum age, extended by the clock skew tolerance, before now. Evidence without a
+// timestamp, or when no maximum age is configured, is never expired.
+func (i *Instrumentation) checkAge(evidence Evidence, now time.Time) error {
+	if i.cfg.MaxAge <= 0 {
+		return nil
+	}
+	ts := evidence.Timestamp()
+	if ts.IsZero() {
+		return nil
+	}
+	maxAge := i.cfg.MaxAge + i.cfg.ClockSkew
+	if age := now.Sub(ts); age > maxAge {
+		return &ExpiredError{Age: age, MaxAge: maxAge}
+	}
+	return nil
+}
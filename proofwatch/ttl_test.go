@@ -0,0 +1,103 @@
+package proofwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func setupTTLTest(t *testing.T, opts ...OptionFunc) (*Instrumentation, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = meterProvider.Shutdown(context.Background()) })
+
+	inst, err := NewInstrumentation(append([]OptionFunc{WithMeterProvider(meterProvider)}, opts...)...)
+	require.NoError(t, err)
+	return inst, reader
+}
+
+// droppedAgeBuckets returns the age bucket of each data point of evidence_dropped_count.
+func droppedAgeBuckets(t *testing.T, reader *sdkmetric.ManualReader) []string {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var buckets []string
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "evidence_dropped_count" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				bucket, _ := dp.Attributes.Value(AgeBucketKey)
+				buckets = append(buckets, bucket.AsString())
+			}
+		}
+	}
+	return buckets
+}
+
+func TestInstrumentationMaxAge(t *testing.T) {
+	ctx := context.Background()
+	process := func(context.Context, Evidence) error { return nil }
+
+	t.Run("evidence beyond the TTL is dropped as expired", func(t *testing.T) {
+		inst, reader := setupTTLTest(t, WithMaxAge(time.Hour))
+		evidence := policyEvidence{PolicyID: "policy-1", At: time.Now().Add(-3 * time.Hour)}
+
+		err := inst.Process(ctx, evidence, func(context.Context, Evidence) error {
+			t.Fatal("process called for expired evidence")
+			return nil
+		})
+		var expired *ExpiredError
+		require.ErrorAs(t, err, &expired)
+		assert.ErrorIs(t, err, ErrExpired)
+		assert.Equal(t, time.Hour, expired.MaxAge)
+
+		assert.Equal(t, map[string]int64{"expired": 1}, int64Sums(t, reader, "evidence_dropped_count"))
+		assert.Equal(t, []string{"<=6h"}, droppedAgeBuckets(t, reader))
+		assert.False(t, metricNames(t, reader)["evidence_processed_count"])
+	})
+
+	t.Run("evidence within the TTL is processed", func(t *testing.T) {
+		inst, reader := setupTTLTest(t, WithMaxAge(time.Hour))
+
+		require.NoError(t, inst.Process(ctx, policyEvidence{PolicyID: "policy-1", At: time.Now().Add(-10 * time.Minute)}, process))
+		require.NoError(t, inst.Process(ctx, policyEvidence{PolicyID: "policy-1", At: time.Now().Add(time.Minute)}, process))
+		require.NoError(t, inst.Process(ctx, policyEvidence{PolicyID: "policy-1"}, process))
+
+		assert.Equal(t, int64(3), int64Sums(t, reader, "evidence_processed_count")[""])
+		assert.False(t, metricNames(t, reader)["evidence_dropped_count"])
+	})
+
+	t.Run("clock skew extends the TTL", func(t *testing.T) {
+		inst, reader := setupTTLTest(t, WithMaxAge(time.Hour), WithClockSkew(30*time.Minute))
+
+		require.NoError(t, inst.Process(ctx, policyEvidence{PolicyID: "policy-1", At: time.Now().Add(-80 * time.Minute)}, process))
+		assert.ErrorIs(t, inst.Process(ctx, policyEvidence{PolicyID: "policy-1", At: time.Now().Add(-2 * time.Hour)}, process), ErrExpired)
+
+		assert.Equal(t, int64(1), int64Sums(t, reader, "evidence_processed_count")[""])
+		assert.Equal(t, map[string]int64{"expired": 1}, int64Sums(t, reader, "evidence_dropped_count"))
+	})
+
+	t.Run("no TTL by default", func(t *testing.T) {
+		inst, reader := setupTTLTest(t)
+
+		require.NoError(t, inst.Process(ctx, policyEvidence{PolicyID: "policy-1", At: time.Now().Add(-365 * 24 * time.Hour)}, process))
+		assert.Equal(t, int64(1), int64Sums(t, reader, "evidence_processed_count")[""])
+	})
+}
+
+func TestAgeBucket(t *testing.T) {
+	assert.Equal(t, "<=1h", ageBucket(time.Hour))
+	assert.Equal(t, "<=24h", ageBucket(7*time.Hour))
+	assert.Equal(t, "<=30d", ageBucket(8*24*time.Hour))
+	assert.Equal(t, ">30d", ageBucket(31*24*time.Hour))
+}
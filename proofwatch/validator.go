@@ -0,0 +1,156 @@
+package proofwatch
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// ValidationFieldKey is the JSON pointer to the first field failing validation, logged
+// for evidence dropped with reason validation_failed. Pointers are unbounded, so it is
+// never recorded on metrics.
+const ValidationFieldKey = attribute.Key("validation.field")
+
+// ValidationKeywordKey is the schema keyword the first field failing validation broke,
+// such as required or type, recorded on evidence dropped with reason validation_failed.
+const ValidationKeywordKey = attribute.Key("validation.keyword")
+
+// schemaURL is the location the schema is registered under when compiled.
+const schemaURL = "proofwatch://evidence.schema.json"
+
+// FieldError describes a field failing validation.
+type FieldError struct {
+	// Field is the JSON pointer to the field, empty for the document itself.
+	Field string
+	// Keyword is the schema keyword the field failed, empty when the document could not
+	// be validated at all.
+	Keyword string
+	// Message describes why the field failed validation.
+	Message string
+}
+
+// ValidationError reports every field of a document failing validation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		field := f.Field
+		if field == "" {
+			field = "/"
+		}
+		msgs = append(msgs, field+": "+f.Message)
+	}
+	return "evidence failed validation: " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Validator validates evidence documents against a JSON Schema.
+type Validator struct {
+	schema *jsonschema.Schema
+}
+
+// NewValidator creates a Validator from a JSON Schema document.
+func NewValidator(schema []byte) (*Validator, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JSON schema: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, doc); err != nil {
+		return nil, fmt.Errorf("failed to add JSON schema: %w", err)
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile JSON schema: %w", err)
+	}
+	return &Validator{schema: compiled}, nil
+}
+
+// NewValidatorFromFile creates a Validator from the JSON Schema document at path.
+func NewValidatorFromFile(path string) (*Validator, error) {
+	schema, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON schema: %w", err)
+	}
+	return NewValidator(schema)
+}
+
+// Validate validates the raw JSON document, returning a *ValidationError listing the
+// failing fields when it is not valid JSON or does not match the schema.
+func (v *Validator) Validate(raw []byte) error {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		return &ValidationError{Fields: []FieldError{{Message: "invalid JSON: " + err.Error()}}}
+	}
+	err = v.schema.Validate(doc)
+	if err == nil {
+		return nil
+	}
+	verr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return fmt.Errorf("failed to validate evidence: %w", err)
+	}
+
+	var fields []FieldError
+	collectFieldErrors(verr, message.NewPrinter(language.English), &fields)
+	return &ValidationError{Fields: fields}
+}
+
+// collectFieldErrors appends the leaf errors of verr to fields. Missing required
+// properties are reported at the location of each missing property.
+func collectFieldErrors(verr *jsonschema.ValidationError, p *message.Printer, fields *[]FieldError) {
+	if len(verr.Causes) > 0 {
+		for _, cause := range verr.Causes {
+			collectFieldErrors(cause, p, fields)
+		}
+		return
+	}
+	if required, ok := verr.ErrorKind.(*kind.Required); ok {
+		for _, name := range required.Missing {
+			*fields = append(*fields, FieldError{
+				Field:   jsonPointer(append(verr.InstanceLocation[:len(verr.InstanceLocation):len(verr.InstanceLocation)], name)),
+				Keyword: "required",
+				Message: "missing required property",
+			})
+		}
+		return
+	}
+	*fields = append(*fields, FieldError{
+		Field:   jsonPointer(verr.InstanceLocation),
+		Keyword: keyword(verr.ErrorKind),
+		Message: verr.ErrorKind.LocalizedString(p),
+	})
+}
+
+// keyword returns the schema keyword an error kind reports, which is the first token
+// of its keyword path.
+func keyword(k jsonschema.ErrorKind) string {
+	if path := k.KeywordPath(); len(path) > 0 {
+		return path[0]
+	}
+	return ""
+}
+
+// jsonPointer returns the JSON pointer for the path tokens.
+func jsonPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, tok := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(tok))
+	}
+	return sb.String()
+}
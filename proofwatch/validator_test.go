@@ -0,0 +1,127 @@
+package proofwatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestValidatorValidate(t *testing.T) {
+	validator, err := NewValidatorFromFile(filepath.Join("testdata", "evidence.schema.json"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		doc  string
+		want []FieldError
+	}{
+		{
+			name: "valid document",
+			doc:  `{"time": 1700000000000, "status": "success", "policy": {"uid": "policy-1"}}`,
+		},
+		{
+			name: "missing required field",
+			doc:  `{"time": 1700000000000, "policy": {"name": "policy-1"}}`,
+			want: []FieldError{{Field: "/policy/uid", Keyword: "required", Message: "missing required property"}},
+		},
+		{
+			name: "wrong type",
+			doc:  `{"time": "yesterday", "policy": {"uid": "policy-1"}}`,
+			want: []FieldError{{Field: "/time", Keyword: "type", Message: "got string, want integer"}},
+		},
+		{
+			name: "invalid JSON",
+			doc:  `{"time":`,
+			want: []FieldError{{Field: ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate([]byte(tt.doc))
+			if tt.want == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			require.Len(t, verr.Fields, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, want.Field, verr.Fields[i].Field)
+				assert.Equal(t, want.Keyword, verr.Fields[i].Keyword)
+				if want.Message != "" {
+					assert.Equal(t, want.Message, verr.Fields[i].Message)
+				}
+			}
+		})
+	}
+}
+
+func TestNewValidator(t *testing.T) {
+	t.Run("loads embedded bytes", func(t *testing.T) {
+		schema, err := os.ReadFile(filepath.Join("testdata", "evidence.schema.json"))
+		require.NoError(t, err)
+
+		validator, err := NewValidator(schema)
+		require.NoError(t, err)
+		assert.NoError(t, validator.Validate([]byte(`{"time": 1, "policy": {"uid": "p"}}`)))
+	})
+
+	t.Run("rejects invalid schema", func(t *testing.T) {
+		_, err := NewValidator([]byte(`{"type": 1}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects missing file", func(t *testing.T) {
+		_, err := NewValidatorFromFile(filepath.Join("testdata", "missing.schema.json"))
+		assert.Error(t, err)
+	})
+}
+
+func TestInstrumentationValidation(t *testing.T) {
+	validator, err := NewValidator([]byte(`{"type": "object", "required": ["owner"]}`))
+	require.NoError(t, err)
+
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = meterProvider.Shutdown(context.Background()) })
+	inst, err := NewInstrumentation(WithMeterProvider(meterProvider), WithValidator(validator))
+	require.NoError(t, err)
+
+	var called bool
+	err = inst.Process(context.Background(), createTestEvidence(), func(context.Context, Evidence) error {
+		called = true
+		return nil
+	})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.False(t, called)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var found bool
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "evidence_dropped_count" {
+				continue
+			}
+			dps := m.Data.(metricdata.Sum[int64]).DataPoints
+			require.Len(t, dps, 1)
+			reason, _ := dps[0].Attributes.Value("reason")
+			keyword, _ := dps[0].Attributes.Value(ValidationKeywordKey)
+			assert.Equal(t, "validation_failed", reason.AsString())
+			assert.Equal(t, "required", keyword.AsString())
+			assert.False(t, dps[0].Attributes.HasValue(ValidationFieldKey))
+			found = true
+		}
+	}
+	assert.True(t, found)
+	assert.False(t, metricNames(t, reader)["evidence_processed_count"])
+}